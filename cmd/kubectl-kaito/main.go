@@ -37,7 +37,5 @@ func main() {
 
 	// Create and execute root command
 	rootCmd := cmd.NewRootCmd(configFlags, isPlugin)
-	if err := rootCmd.Execute(); err != nil {
-		os.Exit(1)
-	}
+	os.Exit(cmd.Execute(rootCmd, os.Args[1:]))
 }
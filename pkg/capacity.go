@@ -0,0 +1,133 @@
+/*
+Copyright (c) 2024 Kaito Project
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+)
+
+// gpuResourceName is the extended resource advertised by the NVIDIA device plugin
+const gpuResourceName corev1.ResourceName = "nvidia.com/gpu"
+
+// capacityRequest describes the nodes a workspace asks for
+type capacityRequest struct {
+	InstanceType   string
+	LabelSelector  map[string]string
+	PreferredNodes []string
+	Count          int
+}
+
+// capacityReport is the result of matching a capacityRequest against the cluster nodes
+type capacityReport struct {
+	Matching []string
+	NotReady []string
+}
+
+// checkNodeCapacity lists the cluster nodes and counts the ready, schedulable nodes
+// that match the requested instance type, node selector and preferred nodes
+func checkNodeCapacity(ctx context.Context, clientset kubernetes.Interface, request capacityRequest) (*capacityReport, error) {
+	klog.V(3).Infof("Checking node capacity for %d node(s) of instance type %q", request.Count, request.InstanceType)
+
+	nodes, err := clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	preferred := make(map[string]bool, len(request.PreferredNodes))
+	for _, name := range request.PreferredNodes {
+		preferred[name] = true
+	}
+
+	report := &capacityReport{}
+	for _, node := range nodes.Items {
+		if !nodeMatchesRequest(node, request, preferred) {
+			continue
+		}
+		if !isNodeReady(node) || node.Spec.Unschedulable {
+			report.NotReady = append(report.NotReady, node.Name)
+			continue
+		}
+		report.Matching = append(report.Matching, node.Name)
+	}
+
+	klog.V(4).Infof("Found %d matching ready node(s), %d matching node(s) not ready", len(report.Matching), len(report.NotReady))
+	return report, nil
+}
+
+// nodeMatchesRequest reports whether a node has the requested instance type and
+// labels. Without an instance type or selector, any node with GPUs matches.
+func nodeMatchesRequest(node corev1.Node, request capacityRequest, preferred map[string]bool) bool {
+	if len(preferred) > 0 && !preferred[node.Name] {
+		return false
+	}
+	if request.InstanceType != "" && node.Labels[corev1.LabelInstanceTypeStable] != request.InstanceType {
+		return false
+	}
+	for key, value := range request.LabelSelector {
+		if node.Labels[key] != value {
+			return false
+		}
+	}
+	if request.InstanceType == "" && len(request.LabelSelector) == 0 && len(preferred) == 0 {
+		gpus, ok := node.Status.Allocatable[gpuResourceName]
+		return ok && !gpus.IsZero()
+	}
+	return true
+}
+
+// isNodeReady reports whether the node's Ready condition is True
+func isNodeReady(node corev1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// printCapacityReport summarizes a capacity check and returns whether enough nodes are available
+func printCapacityReport(out io.Writer, request capacityRequest, report *capacityReport) bool {
+	description := "GPU nodes"
+	if request.InstanceType != "" {
+		description = fmt.Sprintf("nodes of instance type %s", request.InstanceType)
+	}
+
+	if len(report.Matching) >= request.Count {
+		printStatus(out, "✓ Capacity check: %d of %d requested %s available\n", len(report.Matching), request.Count, description)
+		return true
+	}
+
+	printStatus(out, "⚠️  Capacity check: only %d of %d requested %s are ready and schedulable\n",
+		len(report.Matching), request.Count, description)
+	if len(report.Matching) > 0 {
+		fmt.Fprintf(out, "   Matching nodes: %s\n", strings.Join(report.Matching, ", "))
+	}
+	if len(report.NotReady) > 0 {
+		fmt.Fprintf(out, "   Matching but not ready or cordoned: %s\n", strings.Join(report.NotReady, ", "))
+	}
+	printStatus(out, "💡 If node auto-provisioning (gpu-provisioner or Karpenter) is installed, Kaito creates the missing nodes.\n")
+	fmt.Fprintln(out, "   Otherwise the workspace will stay ResourceReady=False until matching nodes are added.")
+	return false
+}
@@ -17,10 +17,25 @@ limitations under the License.
 package cmd
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes/fake"
 )
 
 func TestNewChatCmd(t *testing.T) {
@@ -55,6 +70,8 @@ func TestNewChatCmd(t *testing.T) {
 			"temperature",
 			"top-p",
 			"max-tokens",
+			"prompt",
+			"message",
 		}
 
 		for _, flagName := range optionalFlags {
@@ -175,6 +192,31 @@ func TestChatOptionsValidation(t *testing.T) {
 			},
 			expectError: false,
 		},
+		{
+			name: "History file with load history",
+			options: ChatOptions{
+				WorkspaceName: "test-workspace",
+				Temperature:   0.7,
+				TopP:          0.9,
+				MaxTokens:     1024,
+				LoadHistory:   "old.json",
+				HistoryFile:   "session.json",
+			},
+			expectError: true,
+			errorMsg:    "--load-history and --history-file cannot be used together",
+		},
+		{
+			name: "Append history without history file",
+			options: ChatOptions{
+				WorkspaceName: "test-workspace",
+				Temperature:   0.7,
+				TopP:          0.9,
+				MaxTokens:     1024,
+				AppendHistory: true,
+			},
+			expectError: true,
+			errorMsg:    "--append-history requires --history-file",
+		},
 	}
 
 	for _, tt := range tests {
@@ -314,3 +356,879 @@ func TestChatCommandIntegration(t *testing.T) {
 		assert.Equal(t, "0.9", topPFlag.DefValue)
 	})
 }
+
+func TestChatKeepAlive(t *testing.T) {
+	t.Run("Keep-alive validation", func(t *testing.T) {
+		options := &ChatOptions{
+			WorkspaceName: "test",
+			Temperature:   0.7,
+			TopP:          0.9,
+			MaxTokens:     1024,
+		}
+
+		options.KeepAlive = 5 * time.Minute
+		assert.NoError(t, options.validate())
+
+		options.KeepAlive = -time.Second
+		assert.Error(t, options.validate())
+
+		options.KeepAlive = maxKeepAliveIdle + time.Minute
+		assert.Error(t, options.validate())
+	})
+
+	t.Run("Keep-alive payload is minimal", func(t *testing.T) {
+		payload := buildKeepAlivePayload("")
+		assert.Equal(t, 1, payload["max_tokens"])
+		assert.Len(t, payload["messages"], 1)
+		assert.NotContains(t, payload, "model")
+
+		assert.Equal(t, "phi-4", buildKeepAlivePayload("phi-4")["model"])
+	})
+}
+
+func TestChatHistory(t *testing.T) {
+	t.Run("Save and load round trip", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "session.json")
+		history := []chatMessage{
+			{Role: "system", Content: "You are helpful."},
+			{Role: "user", Content: "Hi"},
+			{Role: "assistant", Content: "Hello!"},
+		}
+
+		assert.NoError(t, saveChatHistory(path, history))
+
+		loaded, err := loadChatHistory(path)
+		assert.NoError(t, err)
+		assert.Equal(t, history, loaded)
+	})
+
+	t.Run("Invalid role is rejected", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "bad.json")
+		assert.NoError(t, os.WriteFile(path, []byte(`[{"role": "robot", "content": "beep"}]`), 0o600))
+
+		_, err := loadChatHistory(path)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "unsupported role")
+	})
+
+	t.Run("Malformed JSON is rejected", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "bad.json")
+		assert.NoError(t, os.WriteFile(path, []byte(`{"role": "user"}`), 0o600))
+
+		_, err := loadChatHistory(path)
+		assert.Error(t, err)
+	})
+
+	t.Run("System prompt replaces transcript system message", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "session.json")
+		assert.NoError(t, saveChatHistory(path, []chatMessage{
+			{Role: "system", Content: "Old prompt"},
+			{Role: "user", Content: "Hi"},
+		}))
+
+		options := &ChatOptions{LoadHistory: path, SystemPrompt: "New prompt"}
+		assert.NoError(t, options.initHistory())
+		assert.Equal(t, []chatMessage{
+			{Role: "system", Content: "New prompt"},
+			{Role: "user", Content: "Hi"},
+		}, options.history)
+
+		options.resetHistory()
+		assert.Equal(t, []chatMessage{{Role: "system", Content: "New prompt"}}, options.history)
+	})
+
+	t.Run("History file that does not exist starts a fresh session", func(t *testing.T) {
+		options := &ChatOptions{HistoryFile: filepath.Join(t.TempDir(), "new.json")}
+		assert.NoError(t, options.initHistory())
+		assert.Empty(t, options.history)
+	})
+
+	t.Run("Empty history file starts a fresh session", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "empty.json")
+		assert.NoError(t, os.WriteFile(path, []byte("\n"), 0o600))
+
+		options := &ChatOptions{HistoryFile: path, SystemPrompt: "Be brief."}
+		assert.NoError(t, options.initHistory())
+		assert.Equal(t, []chatMessage{{Role: "system", Content: "Be brief."}}, options.history)
+	})
+
+	t.Run("History file with invalid schema is rejected", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "bad.json")
+		assert.NoError(t, os.WriteFile(path, []byte(`[{"role": "user"}]`), 0o600))
+
+		options := &ChatOptions{HistoryFile: path}
+		assert.Error(t, options.initHistory())
+	})
+
+	t.Run("Append history writes the conversation back on exit", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "session.json")
+		options := &ChatOptions{HistoryFile: path, AppendHistory: true}
+		assert.NoError(t, options.initHistory())
+		options.history = append(options.history,
+			chatMessage{Role: "user", Content: "Hi"},
+			chatMessage{Role: "assistant", Content: "Hello!"})
+
+		sessionErr := fmt.Errorf("request failed")
+		assert.Equal(t, sessionErr, options.writeHistoryOnExit(sessionErr))
+
+		resumed := &ChatOptions{HistoryFile: path}
+		assert.NoError(t, resumed.initHistory())
+		assert.Equal(t, options.history, resumed.history)
+	})
+
+	t.Run("History file is not written without append history", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "session.json")
+		options := &ChatOptions{HistoryFile: path, history: []chatMessage{{Role: "user", Content: "Hi"}}}
+		assert.NoError(t, options.writeHistoryOnExit(nil))
+		_, err := os.Stat(path)
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("Payload includes history", func(t *testing.T) {
+		options := &ChatOptions{
+			history: []chatMessage{{Role: "user", Content: "Hi"}, {Role: "assistant", Content: "Hello!"}},
+		}
+
+		payload := options.buildRequestPayload("How are you?")
+		messages := payload["messages"].([]chatMessage)
+		assert.Len(t, messages, 3)
+		assert.Equal(t, chatMessage{Role: "user", Content: "How are you?"}, messages[2])
+		assert.NotContains(t, payload, "model")
+	})
+}
+
+func TestChatRaw(t *testing.T) {
+	newServer := func(body string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(body))
+		}))
+	}
+
+	t.Run("Full response is printed and the turn is recorded", func(t *testing.T) {
+		server := newServer(`{"choices":[{"message":{"role":"assistant","content":"Hello!"},"finish_reason":"stop"}],"usage":{"prompt_tokens":3,"completion_tokens":2,"total_tokens":5}}`)
+		defer server.Close()
+
+		options := &ChatOptions{Raw: true}
+		output, err := options.sendMessage(context.TODO(), server.URL, "Hi")
+		assert.NoError(t, err)
+		assert.Contains(t, output, `"finish_reason": "stop"`)
+		assert.Contains(t, output, `"total_tokens": 5`)
+		assert.Equal(t, []chatMessage{{Role: "user", Content: "Hi"}, {Role: "assistant", Content: "Hello!"}}, options.history)
+	})
+
+	t.Run("Unexpected shapes are printed instead of failing", func(t *testing.T) {
+		server := newServer(`{"error":{"message":"model not loaded"}}`)
+		defer server.Close()
+
+		options := &ChatOptions{}
+		_, err := options.sendMessage(context.TODO(), server.URL, "Hi")
+		assert.ErrorContains(t, err, "unexpected response format")
+
+		options.Raw = true
+		output, err := options.sendMessage(context.TODO(), server.URL, "Hi")
+		assert.NoError(t, err)
+		assert.Contains(t, output, "model not loaded")
+		assert.Empty(t, options.history)
+	})
+
+	t.Run("/raw toggles raw output", func(t *testing.T) {
+		options := &ChatOptions{}
+		options.handleCommand("/raw", "phi-4")
+		assert.True(t, options.Raw)
+		options.handleCommand("/raw", "phi-4")
+		assert.False(t, options.Raw)
+	})
+}
+
+func TestChatSamplingParameters(t *testing.T) {
+	t.Run("Unset parameters are omitted from the payload", func(t *testing.T) {
+		payload := (&ChatOptions{}).buildRequestPayload("Hi")
+		for _, key := range []string{"frequency_penalty", "presence_penalty", "stop", "seed"} {
+			assert.NotContains(t, payload, key)
+		}
+	})
+
+	t.Run("Flags are sent when given", func(t *testing.T) {
+		cmd := NewChatCmd(genericclioptions.NewConfigFlags(true))
+		assert.NoError(t, cmd.ParseFlags([]string{
+			"--frequency-penalty", "0.5", "--stop", "END", "--stop", "\n\n", "--seed", "0",
+		}))
+
+		options := &ChatOptions{Stop: []string{"END", "\n\n"}}
+		assert.NoError(t, options.setOptionalParameters(cmd.Flags()))
+		assert.Nil(t, options.PresencePenalty)
+
+		payload := options.buildRequestPayload("Hi")
+		assert.Equal(t, 0.5, payload["frequency_penalty"])
+		assert.NotContains(t, payload, "presence_penalty")
+		assert.Equal(t, []string{"END", "\n\n"}, payload["stop"])
+		assert.Equal(t, 0, payload["seed"])
+	})
+
+	t.Run("Penalties are validated", func(t *testing.T) {
+		tooHigh, tooLow := 2.5, -2.1
+		options := ChatOptions{WorkspaceName: "test-workspace", Temperature: 0.7, TopP: 0.9, MaxTokens: 1024, FrequencyPenalty: &tooHigh}
+		assert.ErrorContains(t, options.validate(), "frequency-penalty must be between -2.0 and 2.0")
+
+		options = ChatOptions{WorkspaceName: "test-workspace", Temperature: 0.7, TopP: 0.9, MaxTokens: 1024, PresencePenalty: &tooLow}
+		assert.ErrorContains(t, options.validate(), "presence-penalty must be between -2.0 and 2.0")
+
+		options = ChatOptions{WorkspaceName: "test-workspace", Temperature: 0.7, TopP: 0.9, MaxTokens: 1024, Stop: []string{""}}
+		assert.ErrorContains(t, options.validate(), "--stop sequences cannot be empty")
+	})
+
+	t.Run("/set updates and resets parameters", func(t *testing.T) {
+		options := &ChatOptions{}
+
+		options.handleCommand("/set frequency_penalty 1.5", "phi-4")
+		assert.Equal(t, 1.5, *options.FrequencyPenalty)
+		options.handleCommand("/set frequency_penalty 3", "phi-4")
+		assert.Equal(t, 1.5, *options.FrequencyPenalty)
+		options.handleCommand("/set frequency_penalty none", "phi-4")
+		assert.Nil(t, options.FrequencyPenalty)
+
+		options.handleCommand("/set presence_penalty -0.5", "phi-4")
+		assert.Equal(t, -0.5, *options.PresencePenalty)
+
+		options.handleCommand("/set stop ### END", "phi-4")
+		assert.Equal(t, []string{"### END"}, options.Stop)
+		options.handleCommand("/set stop none", "phi-4")
+		assert.Nil(t, options.Stop)
+
+		options.handleCommand("/set seed 42", "phi-4")
+		assert.Equal(t, 42, *options.Seed)
+		options.handleCommand("/set seed none", "phi-4")
+		assert.Nil(t, options.Seed)
+	})
+}
+
+func TestChatRequestModel(t *testing.T) {
+	t.Run("Workspace model is sent", func(t *testing.T) {
+		options := &ChatOptions{}
+		options.requestModel = options.resolveRequestModel("phi-4")
+		assert.Equal(t, "phi-4", options.buildRequestPayload("Hi")["model"])
+	})
+
+	t.Run("Served model name overrides the workspace model", func(t *testing.T) {
+		options := &ChatOptions{ServedModelName: "microsoft/phi-4"}
+		options.requestModel = options.resolveRequestModel("phi-4")
+		assert.Equal(t, "microsoft/phi-4", options.buildRequestPayload("Hi")["model"])
+	})
+
+	t.Run("Unknown model is omitted", func(t *testing.T) {
+		options := &ChatOptions{}
+		options.requestModel = options.resolveRequestModel("Unknown")
+		assert.NotContains(t, options.buildRequestPayload("Hi"), "model")
+	})
+
+	t.Run("Served model name flag is registered", func(t *testing.T) {
+		cmd := NewChatCmd(genericclioptions.NewConfigFlags(true))
+		assert.NotNil(t, cmd.Flags().Lookup("served-model-name"))
+	})
+}
+
+func TestChatPrompt(t *testing.T) {
+	t.Run("Piped input is read as one prompt", func(t *testing.T) {
+		prompt, err := readPipedPrompt(strings.NewReader("  What is AI?\nExplain briefly.\n"))
+		assert.NoError(t, err)
+		assert.Equal(t, "What is AI?\nExplain briefly.", prompt)
+	})
+
+	t.Run("Empty piped input is rejected", func(t *testing.T) {
+		_, err := readPipedPrompt(strings.NewReader("\n  \n"))
+		assert.Error(t, err)
+	})
+
+	t.Run("Prompt with keep-alive is rejected", func(t *testing.T) {
+		options := &ChatOptions{
+			WorkspaceName: "test",
+			Temperature:   0.7,
+			TopP:          0.9,
+			MaxTokens:     1024,
+			Prompt:        "hi",
+			KeepAlive:     time.Minute,
+		}
+		assert.Error(t, options.validate())
+	})
+
+	t.Run("Single prompt is sent once", func(t *testing.T) {
+		requests := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			var payload map[string]interface{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+			assert.Len(t, payload["messages"], 1)
+			_, _ = w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"Hello!"}}]}`))
+		}))
+		defer server.Close()
+
+		options := &ChatOptions{Prompt: "hi"}
+		assert.NoError(t, options.sendPrompt(context.TODO(), server.URL+"/v1/chat/completions"))
+		assert.Equal(t, 1, requests)
+	})
+}
+
+func TestReadLines(t *testing.T) {
+	lines, done := readLines(strings.NewReader("hello\n/quit\n"))
+	assert.Equal(t, "hello", <-lines)
+	assert.Equal(t, "/quit", <-lines)
+	assert.NoError(t, <-done)
+}
+
+func TestChatAttachments(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(name string, data []byte) string {
+		path := filepath.Join(dir, name)
+		assert.NoError(t, os.WriteFile(path, data, 0o644))
+		return path
+	}
+	mainGo := writeFile("main.go", []byte("package main\n"))
+	notes := writeFile("notes.txt", []byte("todo"))
+
+	t.Run("Attachments are prepended to the message", func(t *testing.T) {
+		options := &ChatOptions{}
+		assert.NoError(t, options.attachFile(mainGo))
+		assert.NoError(t, options.attachFile(notes))
+
+		message := options.withAttachments("Review this")
+		assert.Contains(t, message, "----- BEGIN FILE: "+mainGo+" -----\npackage main\n----- END FILE: "+mainGo+" -----")
+		assert.Contains(t, message, "----- BEGIN FILE: "+notes+" -----\ntodo\n----- END FILE: "+notes+" -----")
+		assert.True(t, strings.HasSuffix(message, "\n\nReview this"))
+		assert.Less(t, strings.Index(message, mainGo), strings.Index(message, notes))
+	})
+
+	t.Run("No attachments", func(t *testing.T) {
+		assert.Equal(t, "hi", (&ChatOptions{}).withAttachments("hi"))
+	})
+
+	t.Run("Size cap covers all pending attachments", func(t *testing.T) {
+		half := writeFile("half.txt", bytes.Repeat([]byte("a"), maxAttachmentBytes/2+1))
+		options := &ChatOptions{}
+		assert.NoError(t, options.attachFile(half))
+		assert.ErrorContains(t, options.attachFile(half), "would exceed the 64 KiB limit")
+		assert.Len(t, options.attachments, 1)
+	})
+
+	t.Run("Rejected files", func(t *testing.T) {
+		options := &ChatOptions{}
+		assert.ErrorContains(t, options.attachFile(filepath.Join(dir, "missing.txt")), "failed to read attachment")
+		assert.ErrorContains(t, options.attachFile(dir), "is a directory")
+		assert.ErrorContains(t, options.attachFile(writeFile("model.bin", []byte{0x00, 0xff, 0x10})), "is not a text file")
+		assert.Empty(t, options.attachments)
+	})
+
+	t.Run("/attach and a successful message", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"Looks fine"}}]}`))
+		}))
+		defer server.Close()
+
+		options := &ChatOptions{}
+		assert.False(t, options.handleCommand("/attach "+mainGo, "phi-4"))
+		assert.Len(t, options.attachments, 1)
+
+		options.Prompt = "Review this"
+		assert.NoError(t, options.sendPrompt(context.TODO(), server.URL+"/v1/chat/completions"))
+		assert.Len(t, options.history, 2)
+		assert.Contains(t, options.history[0].Content, "package main")
+	})
+}
+
+func TestChatRetries(t *testing.T) {
+	originalBackoff := chatRetryBackoff
+	chatRetryBackoff = time.Millisecond
+	defer func() { chatRetryBackoff = originalBackoff }()
+
+	newServer := func(statuses ...int) (*httptest.Server, *int) {
+		requests := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			status := http.StatusOK
+			if requests < len(statuses) {
+				status = statuses[requests]
+			}
+			requests++
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"Hello!"}}]}`))
+		}))
+		return server, &requests
+	}
+
+	t.Run("5xx responses are retried", func(t *testing.T) {
+		server, requests := newServer(http.StatusServiceUnavailable, http.StatusBadGateway)
+		defer server.Close()
+
+		options := &ChatOptions{Retries: 3}
+		_, err := options.makeHTTPRequest(context.TODO(), server.URL, []byte(`{}`))
+		assert.NoError(t, err)
+		assert.Equal(t, 3, *requests)
+	})
+
+	t.Run("4xx responses fail fast", func(t *testing.T) {
+		server, requests := newServer(http.StatusBadRequest)
+		defer server.Close()
+
+		options := &ChatOptions{Retries: 3}
+		_, err := options.makeHTTPRequest(context.TODO(), server.URL, []byte(`{}`))
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "status 400")
+		assert.Equal(t, 1, *requests)
+	})
+
+	t.Run("Cancelled context stops retrying", func(t *testing.T) {
+		server, requests := newServer(http.StatusServiceUnavailable, http.StatusServiceUnavailable)
+		defer server.Close()
+
+		ctx, cancel := context.WithCancel(context.TODO())
+		cancel()
+		options := &ChatOptions{Retries: 3}
+		_, err := options.makeHTTPRequest(ctx, server.URL, []byte(`{}`))
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, 0, *requests)
+	})
+
+	t.Run("Retries are bounded", func(t *testing.T) {
+		server, requests := newServer(http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable)
+		defer server.Close()
+
+		options := &ChatOptions{Retries: 1}
+		_, err := options.makeHTTPRequest(context.TODO(), server.URL, []byte(`{}`))
+		assert.Error(t, err)
+		assert.Equal(t, 2, *requests)
+	})
+
+	t.Run("Connection refused is retryable", func(t *testing.T) {
+		server, _ := newServer()
+		url := server.URL
+		server.Close()
+
+		_, err := postJSON(context.TODO(), nil, url, []byte(`{}`), defaultEndpointTimeout)
+		assert.True(t, isRetryableInferenceError(err))
+	})
+
+	t.Run("Negative retries are rejected", func(t *testing.T) {
+		options := &ChatOptions{
+			WorkspaceName: "test",
+			Temperature:   0.7,
+			TopP:          0.9,
+			MaxTokens:     1024,
+			Retries:       -1,
+		}
+		assert.Error(t, options.validate())
+	})
+}
+
+func TestChatRequestTimeout(t *testing.T) {
+	newOptions := func(timeout string) *ChatOptions {
+		return &ChatOptions{WorkspaceName: "test", Temperature: 0.7, TopP: 0.9, MaxTokens: 1024, RequestTimeout: timeout}
+	}
+
+	t.Run("Shadows the global flag", func(t *testing.T) {
+		configFlags := genericclioptions.NewConfigFlags(true)
+		root := NewRootCmd(configFlags, true)
+		chat, _, err := root.Find([]string{"chat"})
+		assert.NoError(t, err)
+		assert.Equal(t, defaultChatRequestTimeout, chat.Flag("request-timeout").DefValue)
+
+		assert.NoError(t, chat.ParseFlags([]string{"--request-timeout", "10m"}))
+		assert.Equal(t, "10m", chat.Flag("request-timeout").Value.String())
+		assert.Equal(t, "0", *configFlags.Timeout, "the Kubernetes API timeout is unchanged")
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		options := newOptions("300")
+		assert.NoError(t, options.validate())
+		assert.Equal(t, 5*time.Minute, options.requestTimeout)
+
+		options = newOptions("0")
+		assert.NoError(t, options.validate())
+		assert.Zero(t, options.requestTimeout)
+
+		assert.ErrorContains(t, newOptions("soon").validate(), "invalid --request-timeout")
+		assert.Error(t, newOptions("-1s").validate())
+	})
+
+	t.Run("Timed out responses suggest a longer timeout", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(200 * time.Millisecond)
+			_, _ = w.Write([]byte(`{"choices":[]}`))
+		}))
+		defer server.Close()
+
+		options := &ChatOptions{requestTimeout: 20 * time.Millisecond}
+		_, err := options.makeHTTPRequest(context.TODO(), server.URL, []byte(`{}`))
+		assert.ErrorContains(t, err, "longer than --request-timeout 20ms")
+
+		options.requestTimeout = 0
+		_, err = options.makeHTTPRequest(context.TODO(), server.URL, []byte(`{}`))
+		assert.NoError(t, err, "0 disables the timeout")
+	})
+
+	t.Run("Timed out requests are not retried", func(t *testing.T) {
+		originalBackoff := chatRetryBackoff
+		chatRetryBackoff = time.Millisecond
+		defer func() { chatRetryBackoff = originalBackoff }()
+
+		var requests int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&requests, 1)
+			time.Sleep(200 * time.Millisecond)
+			_, _ = w.Write([]byte(`{"choices":[]}`))
+		}))
+		defer server.Close()
+
+		options := &ChatOptions{requestTimeout: 20 * time.Millisecond, Retries: 3}
+		_, err := options.makeHTTPRequest(context.TODO(), server.URL, []byte(`{}`))
+		assert.ErrorContains(t, err, "longer than --request-timeout 20ms")
+		assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+	})
+}
+
+func TestChatEndpointOverride(t *testing.T) {
+	newOptions := func(endpoint string) *ChatOptions {
+		return &ChatOptions{
+			WorkspaceName: "test",
+			Temperature:   0.7,
+			TopP:          0.9,
+			MaxTokens:     1024,
+			Endpoint:      endpoint,
+		}
+	}
+
+	t.Run("Valid endpoints", func(t *testing.T) {
+		for _, endpoint := range []string{"http://localhost:8080", "https://llama.example.com/", "http://10.0.0.4"} {
+			assert.NoError(t, newOptions(endpoint).validate(), endpoint)
+		}
+	})
+
+	t.Run("Malformed endpoints are rejected", func(t *testing.T) {
+		for _, endpoint := range []string{"localhost:8080", "ftp://example.com", "http://", "://bad"} {
+			assert.Error(t, newOptions(endpoint).validate(), endpoint)
+		}
+	})
+
+	t.Run("Discovery flags conflict with endpoint", func(t *testing.T) {
+		options := newOptions("http://localhost:8080")
+		options.Port = 8080
+		assert.Error(t, options.validate())
+	})
+
+	t.Run("Chat path is appended once", func(t *testing.T) {
+		assert.Equal(t, "http://localhost:8080/v1/chat/completions", chatCompletionsURL("http://localhost:8080/"))
+		assert.Equal(t, "http://localhost:8080/v1/chat/completions", chatCompletionsURL("http://localhost:8080/v1/chat/completions"))
+	})
+
+	t.Run("Endpoint skips discovery", func(t *testing.T) {
+		endpoint, err := newOptions("http://localhost:8080").resolveEndpoint(context.TODO())
+		assert.NoError(t, err)
+		assert.Equal(t, "http://localhost:8080/v1/chat/completions", endpoint)
+	})
+}
+
+func TestChatUsage(t *testing.T) {
+	t.Run("Usage is parsed", func(t *testing.T) {
+		usage, ok := parseTokenUsage(map[string]interface{}{
+			"usage": map[string]interface{}{"prompt_tokens": 42.0, "completion_tokens": 128.0, "total_tokens": 170.0},
+		})
+		assert.True(t, ok)
+		assert.Equal(t, "[prompt: 42, completion: 128, total: 170 tokens]", usage.String())
+	})
+
+	t.Run("Missing usage is reported", func(t *testing.T) {
+		_, ok := parseTokenUsage(map[string]interface{}{})
+		assert.False(t, ok)
+	})
+
+	t.Run("Session total accumulates", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"Hi"}}],` +
+				`"usage":{"prompt_tokens":10,"completion_tokens":5,"total_tokens":15}}`))
+		}))
+		defer server.Close()
+
+		options := &ChatOptions{ShowUsage: true}
+		for i := 0; i < 2; i++ {
+			_, err := options.sendMessage(context.TODO(), server.URL, "hello")
+			assert.NoError(t, err)
+		}
+		assert.Equal(t, &tokenUsage{PromptTokens: 10, CompletionTokens: 5, TotalTokens: 15}, options.lastUsage)
+		assert.Equal(t, tokenUsage{PromptTokens: 20, CompletionTokens: 10, TotalTokens: 30}, options.sessionUsage)
+	})
+}
+
+func TestChatTiming(t *testing.T) {
+	t.Run("Formatting", func(t *testing.T) {
+		assert.Equal(t, "[2.3s, 55 tok/s]", formatTiming(2300*time.Millisecond, &tokenUsage{CompletionTokens: 127}))
+		assert.Equal(t, "[0.4s]", formatTiming(400*time.Millisecond, nil), "no rate without usage")
+		assert.Equal(t, "[1.0s]", formatTiming(time.Second, &tokenUsage{PromptTokens: 10}))
+	})
+
+	t.Run("Latency is measured", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(20 * time.Millisecond)
+			_, _ = w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"Hi"}}],` +
+				`"usage":{"prompt_tokens":10,"completion_tokens":5,"total_tokens":15}}`))
+		}))
+		defer server.Close()
+
+		options := &ChatOptions{ShowTiming: true}
+		_, err := options.sendMessage(context.TODO(), server.URL, "hello")
+		assert.NoError(t, err)
+		assert.GreaterOrEqual(t, options.lastElapsed, 20*time.Millisecond)
+		assert.Contains(t, formatTiming(options.lastElapsed, options.lastUsage), "tok/s]")
+	})
+}
+
+func TestChatContextWindow(t *testing.T) {
+	newConfigMap := func(name, config string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Data:       map[string]string{inferenceConfigKey: config},
+		}
+	}
+	newOptions := func(configName string, configMaps ...*corev1.ConfigMap) *ChatOptions {
+		workspace := newTestWorkspace("my-ws", "default", nil)
+		if configName != "" {
+			assert.NoError(t, unstructured.SetNestedField(workspace.Object, configName, "inference", "config"))
+		}
+		clientset := fake.NewSimpleClientset()
+		for _, configMap := range configMaps {
+			_, err := clientset.CoreV1().ConfigMaps("default").Create(context.TODO(), configMap, metav1.CreateOptions{})
+			assert.NoError(t, err)
+		}
+		return &ChatOptions{
+			clients:       &clientFactory{dynamicClient: newFakeDynamicClient(workspace), clientset: clientset},
+			WorkspaceName: "my-ws",
+			Namespace:     "default",
+			MaxTokens:     1024,
+		}
+	}
+
+	t.Run("Reads max-model-len from the workspace inference config", func(t *testing.T) {
+		options := newOptions("my-ws-inference-config", newConfigMap("my-ws-inference-config", "vllm:\n  max-model-len: 8192\n"))
+		options.loadInferenceConfig(context.TODO())
+		assert.Equal(t, 8192, options.contextWindow)
+		assert.Equal(t, "ConfigMap my-ws-inference-config", options.contextWindowSource)
+	})
+
+	t.Run("Falls back to the default inference config", func(t *testing.T) {
+		options := newOptions("", newConfigMap(defaultInferenceConfigName, "vllm:\n  gpu-memory-utilization: 0.95\n"))
+		options.loadInferenceConfig(context.TODO())
+		assert.Equal(t, 0, options.contextWindow)
+		assert.Contains(t, options.contextWindowSource, "does not set vllm.max-model-len")
+	})
+
+	t.Run("Missing ConfigMap leaves the context window unknown", func(t *testing.T) {
+		options := newOptions("")
+		options.loadInferenceConfig(context.TODO())
+		assert.Equal(t, 0, options.contextWindow)
+
+		var out bytes.Buffer
+		options.printContext(&out)
+		assert.Contains(t, out.String(), "Max model length: unknown")
+		assert.NotContains(t, out.String(), "Remaining")
+	})
+
+	t.Run("Server config is shown for /params", func(t *testing.T) {
+		config := "vllm:\n  max-model-len: 131072\n  gpu-memory-utilization: 0.95\n  cpu-offload-gb: 0\nmax_probe_steps: 6\n"
+		options := newOptions("my-ws-inference-config", newConfigMap("my-ws-inference-config", config))
+		options.loadInferenceConfig(context.TODO())
+
+		var out bytes.Buffer
+		options.printServerConfig(&out)
+		assert.Equal(t, "\nServer inference config (from ConfigMap my-ws-inference-config):\n"+
+			"  max_probe_steps: 6\n"+
+			"  vllm.cpu-offload-gb: 0\n"+
+			"  vllm.gpu-memory-utilization: 0.95\n"+
+			"  vllm.max-model-len: 131072\n", out.String())
+	})
+
+	t.Run("Unreadable server config", func(t *testing.T) {
+		options := newOptions("")
+		options.loadInferenceConfig(context.TODO())
+
+		var out bytes.Buffer
+		options.printServerConfig(&out)
+		assert.Contains(t, out.String(), "Server inference config: unknown (the workspace inference config could not be read)")
+
+		options = newOptions("", newConfigMap(defaultInferenceConfigName, "vllm: [unterminated\n"))
+		options.loadInferenceConfig(context.TODO())
+		out.Reset()
+		options.printServerConfig(&out)
+		assert.Contains(t, out.String(), "unknown (ConfigMap inference-params-template is not valid YAML)")
+		assert.Equal(t, 0, options.contextWindow)
+	})
+
+	t.Run("Invalid max-model-len", func(t *testing.T) {
+		_, err := parseMaxModelLen([]byte("vllm:\n  max-model-len: auto\n"))
+		assert.ErrorContains(t, err, "must be a positive integer")
+
+		length, err := parseMaxModelLen([]byte("max_probe_steps: 6\n"))
+		assert.NoError(t, err)
+		assert.Equal(t, 0, length)
+	})
+
+	t.Run("Usage and remaining budget", func(t *testing.T) {
+		options := &ChatOptions{MaxTokens: 1024, contextWindow: 8192, contextWindowSource: "ConfigMap my-ws-inference-config"}
+		options.history = []chatMessage{{Role: "user", Content: strings.Repeat("a", 400)}}
+		assert.Equal(t, 100, options.contextTokens())
+
+		options.lastUsage = &tokenUsage{TotalTokens: 2000}
+		assert.Equal(t, 2000, options.contextTokens())
+
+		var out bytes.Buffer
+		options.printContext(&out)
+		assert.Contains(t, out.String(), "Max model length: 8192 tokens (from ConfigMap my-ws-inference-config)")
+		assert.Contains(t, out.String(), "Remaining for the next prompt: ~5168 tokens")
+	})
+
+	t.Run("Warns close to the context window", func(t *testing.T) {
+		options := &ChatOptions{MaxTokens: 1024, contextWindow: 8192, lastUsage: &tokenUsage{TotalTokens: 6000}}
+		var out bytes.Buffer
+		options.printContextWarning(&out)
+		assert.Empty(t, out.String())
+
+		options.lastUsage.TotalTokens = 7000
+		options.printContextWarning(&out)
+		assert.Contains(t, out.String(), "close to the 8192 token context window")
+	})
+}
+
+func TestChatConvertToString(t *testing.T) {
+	o := &ChatOptions{}
+	name := "phi-4"
+
+	assert.Equal(t, "phi-4", o.convertToString("phi-4"))
+	assert.Equal(t, "phi-4", o.convertToString(&name))
+	assert.Equal(t, "", o.convertToString((*string)(nil)))
+	assert.Equal(t, "42", o.convertToString(json.Number("42")))
+	assert.Equal(t, "1000000", o.convertToString(float64(1000000)))
+	assert.Equal(t, "0.5", o.convertToString(0.5))
+	assert.Equal(t, "7", o.convertToString(int64(7)))
+	assert.Equal(t, "true", o.convertToString(true))
+	assert.Equal(t, "", o.convertToString(nil))
+	assert.Equal(t, "", o.convertToString(map[string]interface{}{"name": "phi-4"}))
+	assert.Equal(t, "", o.convertToString([]interface{}{"phi-4"}))
+}
+
+func TestChatModelNameWithUnexpectedShapes(t *testing.T) {
+	newOptions := func(fields map[string]interface{}) *ChatOptions {
+		workspace := newTestWorkspace("my-ws", "default", nil)
+		for key, value := range fields {
+			workspace.Object[key] = value
+		}
+		return &ChatOptions{
+			clients:       &clientFactory{dynamicClient: newFakeDynamicClient(workspace)},
+			WorkspaceName: "my-ws",
+			Namespace:     "default",
+		}
+	}
+
+	t.Run("Structured preset name falls through to the next path", func(t *testing.T) {
+		options := newOptions(map[string]interface{}{
+			"inference": map[string]interface{}{
+				"preset": map[string]interface{}{"name": map[string]interface{}{"value": "phi-4"}},
+				"model":  "phi-4",
+			},
+		})
+		name, err := options.getModelName(context.TODO())
+		assert.NoError(t, err)
+		assert.Equal(t, "phi-4", name)
+	})
+
+	t.Run("Only structured values means the model is unknown", func(t *testing.T) {
+		options := newOptions(map[string]interface{}{
+			"inference": map[string]interface{}{
+				"preset": map[string]interface{}{"name": []interface{}{"phi-4"}},
+				"model":  map[string]interface{}{"name": "phi-4"},
+			},
+		})
+		name, err := options.getModelName(context.TODO())
+		assert.NoError(t, err)
+		assert.Equal(t, "Unknown", name)
+	})
+
+	t.Run("Preset is not a mapping", func(t *testing.T) {
+		options := newOptions(map[string]interface{}{
+			"inference": map[string]interface{}{"preset": "phi-4"},
+		})
+		name, err := options.getModelName(context.TODO())
+		assert.NoError(t, err)
+		assert.Equal(t, "Unknown", name)
+	})
+}
+
+func TestCheckEndpointHealth(t *testing.T) {
+	originalBackoff := chatRetryBackoff
+	chatRetryBackoff = time.Millisecond
+	defer func() { chatRetryBackoff = originalBackoff }()
+
+	newServer := func(handler func(w http.ResponseWriter, r *http.Request)) (*httptest.Server, *[]string) {
+		var paths []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodGet, r.Method)
+			paths = append(paths, r.URL.Path)
+			handler(w, r)
+		}))
+		return server, &paths
+	}
+
+	t.Run("Healthy endpoint", func(t *testing.T) {
+		server, paths := newServer(func(w http.ResponseWriter, r *http.Request) {})
+		defer server.Close()
+
+		options := &ChatOptions{WorkspaceName: "my-ws", Retries: 3}
+		assert.NoError(t, options.checkEndpointHealth(context.TODO(), chatCompletionsURL(server.URL)))
+		assert.Equal(t, []string{"/health"}, *paths)
+	})
+
+	t.Run("Falls back to /v1/models", func(t *testing.T) {
+		server, paths := newServer(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/health" {
+				w.WriteHeader(http.StatusNotFound)
+			}
+		})
+		defer server.Close()
+
+		options := &ChatOptions{WorkspaceName: "my-ws"}
+		assert.NoError(t, options.checkEndpointHealth(context.TODO(), chatCompletionsURL(server.URL)))
+		assert.Equal(t, []string{"/health", "/v1/models"}, *paths)
+	})
+
+	t.Run("Loading model is retried", func(t *testing.T) {
+		requests := 0
+		server, _ := newServer(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			if requests < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+		})
+		defer server.Close()
+
+		options := &ChatOptions{WorkspaceName: "my-ws", Retries: 3}
+		assert.NoError(t, options.checkEndpointHealth(context.TODO(), chatCompletionsURL(server.URL)))
+		assert.Equal(t, 3, requests)
+	})
+
+	t.Run("Unreachable endpoint", func(t *testing.T) {
+		server, _ := newServer(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		})
+		defer server.Close()
+
+		options := &ChatOptions{WorkspaceName: "my-ws", Namespace: "default", Retries: 1}
+		err := options.checkEndpointHealth(context.TODO(), chatCompletionsURL(server.URL))
+		assert.ErrorContains(t, err, "health check failed")
+		assert.ErrorContains(t, err, "status 503")
+	})
+
+	t.Run("Client errors are not retried", func(t *testing.T) {
+		server, paths := newServer(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		})
+		defer server.Close()
+
+		options := &ChatOptions{WorkspaceName: "my-ws", Retries: 3}
+		assert.Error(t, options.checkEndpointHealth(context.TODO(), chatCompletionsURL(server.URL)))
+		assert.Len(t, *paths, 1)
+	})
+}
@@ -0,0 +1,123 @@
+/*
+Copyright (c) 2024 Kaito Project
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+)
+
+// clientFactory builds the Kubernetes clients of a command invocation. The REST
+// config and each client are created on first use and then reused, so a command
+// parses the kubeconfig once. Tests set the fields directly to inject fakes.
+type clientFactory struct {
+	configFlags *genericclioptions.ConfigFlags
+
+	restConfig    *rest.Config
+	dynamicClient dynamic.Interface
+	clientset     kubernetes.Interface
+	transport     http.RoundTripper
+}
+
+// newClientFactory returns a factory that builds clients from the kubeconfig flags
+func newClientFactory(configFlags *genericclioptions.ConfigFlags) *clientFactory {
+	return &clientFactory{configFlags: configFlags}
+}
+
+// RESTConfig returns the REST config of the current kubeconfig context
+func (f *clientFactory) RESTConfig() (*rest.Config, error) {
+	if f == nil {
+		return nil, fmt.Errorf("failed to get REST config: no Kubernetes configuration")
+	}
+	if f.restConfig != nil {
+		return f.restConfig, nil
+	}
+	if f.configFlags == nil {
+		return nil, fmt.Errorf("failed to get REST config: no Kubernetes configuration")
+	}
+
+	config, err := f.configFlags.ToRESTConfig()
+	if err != nil {
+		klog.Errorf("Failed to get REST config: %v", err)
+		return nil, fmt.Errorf("failed to get REST config: %w", err)
+	}
+	f.restConfig = config
+	return config, nil
+}
+
+// DynamicClient returns the dynamic client used for Kaito custom resources
+func (f *clientFactory) DynamicClient() (dynamic.Interface, error) {
+	if f != nil && f.dynamicClient != nil {
+		return f.dynamicClient, nil
+	}
+	config, err := f.RESTConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		klog.Errorf("Failed to create dynamic client: %v", err)
+		return nil, fmt.Errorf("failed to create dynamic client: %w", err)
+	}
+	f.dynamicClient = dynamicClient
+	return dynamicClient, nil
+}
+
+// KubernetesClient returns the typed client used for core resources such as services and pods
+func (f *clientFactory) KubernetesClient() (kubernetes.Interface, error) {
+	if f != nil && f.clientset != nil {
+		return f.clientset, nil
+	}
+	config, err := f.RESTConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		klog.Errorf("Failed to create Kubernetes client: %v", err)
+		return nil, fmt.Errorf("failed to create Kubernetes client: %w", err)
+	}
+	f.clientset = clientset
+	return clientset, nil
+}
+
+// APITransport returns a transport authenticated with the kubeconfig credentials,
+// for requests sent through the API server proxy
+func (f *clientFactory) APITransport() (http.RoundTripper, error) {
+	if f != nil && f.transport != nil {
+		return f.transport, nil
+	}
+	config, err := f.RESTConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	transport, err := rest.TransportFor(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create authenticated transport: %w", err)
+	}
+	f.transport = transport
+	return transport, nil
+}
@@ -0,0 +1,102 @@
+/*
+Copyright (c) 2024 Kaito Project
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+)
+
+func TestClientFactoryCachesClients(t *testing.T) {
+	kubeconfig := `apiVersion: v1
+kind: Config
+clusters:
+- name: test
+  cluster:
+    server: https://api.test.example.com
+contexts:
+- name: test
+  context:
+    cluster: test
+    user: test
+current-context: test
+users:
+- name: test
+  user:
+    token: secret
+`
+	path := filepath.Join(t.TempDir(), "kubeconfig")
+	assert.NoError(t, os.WriteFile(path, []byte(kubeconfig), 0o600))
+
+	configFlags := genericclioptions.NewConfigFlags(true)
+	configFlags.KubeConfig = &path
+	clients := newClientFactory(configFlags)
+
+	config, err := clients.RESTConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, "https://api.test.example.com", config.Host)
+	again, err := clients.RESTConfig()
+	assert.NoError(t, err)
+	assert.Same(t, config, again, "the REST config should be built once")
+
+	dynamicClient, err := clients.DynamicClient()
+	assert.NoError(t, err)
+	dynamicAgain, _ := clients.DynamicClient()
+	assert.Equal(t, dynamicClient, dynamicAgain)
+
+	clientset, err := clients.KubernetesClient()
+	assert.NoError(t, err)
+	clientsetAgain, _ := clients.KubernetesClient()
+	assert.Same(t, clientset, clientsetAgain)
+}
+
+func TestClientFactoryInjectedClients(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	clients := &clientFactory{
+		restConfig: &rest.Config{Host: "https://api.example.com"},
+		clientset:  clientset,
+	}
+
+	got, err := clients.KubernetesClient()
+	assert.NoError(t, err)
+	assert.Same(t, clientset, got)
+
+	var missing *clientFactory
+	_, err = missing.DynamicClient()
+	assert.ErrorContains(t, err, "no Kubernetes configuration")
+}
+
+func TestDescribeRunWithFakeClients(t *testing.T) {
+	o := &DescribeOptions{
+		clients: &clientFactory{
+			dynamicClient: newFakeDynamicClient(newTestWorkspace("my-ws", "default", nil)),
+			clientset:     fake.NewSimpleClientset(),
+		},
+		WorkspaceName: "my-ws",
+		Namespace:     "default",
+	}
+	assert.NoError(t, o.run())
+
+	o.WorkspaceName = "missing"
+	assert.ErrorContains(t, o.run(), "failed to get workspace missing")
+}
@@ -0,0 +1,87 @@
+/*
+Copyright (c) 2024 Kaito Project
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// ANSI SGR sequences used for terminal output
+const (
+	ansiReset = "\033[0m"
+	ansiBold  = "\033[1m"
+	ansiDim   = "\033[2m"
+	ansiRed   = "\033[31m"
+	ansiGreen = "\033[32m"
+	ansiCyan  = "\033[36m"
+)
+
+// noColor mirrors the global --no-color flag
+var noColor bool
+
+// statusMarks are the emoji that start status lines. They are left out when the
+// output is not a terminal so that logs stay plain text.
+var statusMarks = []string{"✓", "✗", "❌", "ℹ️", "⚠️", "💡", "⏳", "🔍"}
+
+// colorEnabled reports whether styled output may be written to f: f must be a
+// terminal and neither --no-color nor the NO_COLOR environment variable is set
+func colorEnabled(f *os.File) bool {
+	if noColor || os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	return isTerminal(f)
+}
+
+// colorText wraps s in the ANSI sequence code when colors are enabled for f
+func colorText(f *os.File, code, s string) string {
+	if !colorEnabled(f) {
+		return s
+	}
+	return code + s + ansiReset
+}
+
+// dimText wraps s in the ANSI dim attribute when colors are enabled for f
+func dimText(f *os.File, s string) string {
+	return colorText(f, ansiDim, s)
+}
+
+// printStatus prints a status line such as "✓ Workspace created" to w, without
+// its leading emoji when colors are disabled for w. Writers other than files, such
+// as buffers, are never terminals.
+func printStatus(w io.Writer, format string, args ...interface{}) {
+	line := fmt.Sprintf(format, args...)
+	if f, ok := w.(*os.File); !ok || !colorEnabled(f) {
+		line = stripStatusMark(line)
+	}
+	fmt.Fprint(w, line)
+}
+
+// stripStatusMark removes a leading status emoji and the spaces after it, keeping
+// the indentation before it
+func stripStatusMark(line string) string {
+	rest := strings.TrimLeft(line, " ")
+	indent := line[:len(line)-len(rest)]
+	for _, mark := range statusMarks {
+		if text, found := strings.CutPrefix(rest, mark); found {
+			return indent + strings.TrimLeft(text, " ")
+		}
+	}
+	return line
+}
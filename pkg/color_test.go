@@ -0,0 +1,84 @@
+/*
+Copyright (c) 2024 Kaito Project
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func TestColorText(t *testing.T) {
+	// The null device is a character device, so it is treated like a terminal
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	assert.NoError(t, err)
+	defer devNull.Close()
+
+	file, err := os.Create(filepath.Join(t.TempDir(), "out.log"))
+	assert.NoError(t, err)
+	defer file.Close()
+
+	t.Setenv("NO_COLOR", "")
+
+	t.Run("Terminal output is colored", func(t *testing.T) {
+		assert.Equal(t, ansiRed+"Error"+ansiReset, colorText(devNull, ansiRed, "Error"))
+		assert.Equal(t, ansiDim+"note"+ansiReset, dimText(devNull, "note"))
+	})
+
+	t.Run("Redirected output stays plain", func(t *testing.T) {
+		assert.Equal(t, "Error", colorText(file, ansiRed, "Error"))
+	})
+
+	t.Run("NO_COLOR disables colors", func(t *testing.T) {
+		t.Setenv("NO_COLOR", "1")
+		assert.Equal(t, "Error", colorText(devNull, ansiRed, "Error"))
+	})
+
+	t.Run("--no-color disables colors", func(t *testing.T) {
+		cmd := NewRootCmd(genericclioptions.NewConfigFlags(true), false)
+		assert.NoError(t, cmd.PersistentFlags().Set("no-color", "true"))
+		defer func() { noColor = false }()
+		assert.Equal(t, "Error", colorText(devNull, ansiRed, "Error"))
+	})
+}
+
+func TestPrintStatus(t *testing.T) {
+	assert.Equal(t, "Workspace my-ws created", stripStatusMark("✓ Workspace my-ws created"))
+	assert.Equal(t, "Use 'kubectl kaito status' to check status", stripStatusMark("ℹ️  Use 'kubectl kaito status' to check status"))
+	assert.Equal(t, "Warning: low memory", stripStatusMark("⚠️  Warning: low memory"))
+	assert.Equal(t, "Plain line", stripStatusMark("Plain line"))
+	assert.Equal(t, "  GPU requirements are not available", stripStatusMark("  💡 GPU requirements are not available"))
+
+	var buf bytes.Buffer
+	printStatus(&buf, "✗ %s: not found\n", "notes.txt")
+	assert.Equal(t, "notes.txt: not found\n", buf.String(), "buffers are never terminals")
+
+	path := filepath.Join(t.TempDir(), "deploy.log")
+	file, err := os.Create(path)
+	assert.NoError(t, err)
+	printStatus(file, "✓ Workspace %s created\n", "my-ws")
+	printStatus(file, "💡 Use --update to apply the new configuration\n")
+	assert.NoError(t, file.Close())
+
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "Workspace my-ws created\nUse --update to apply the new configuration\n", string(data))
+}
@@ -0,0 +1,156 @@
+/*
+Copyright (c) 2024 Kaito Project
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"k8s.io/klog/v2"
+)
+
+// completionShells are the shells the completion command generates scripts for
+var completionShells = []string{"bash", "zsh", "fish", "powershell"}
+
+// CompletionOptions holds the options for the completion command
+type CompletionOptions struct {
+	// program is the executable the generated scripts complete: "kaito", or
+	// "kubectl-kaito" for the plugin, since a command name cannot contain a space
+	program string
+
+	Shell          string
+	NoDescriptions bool
+}
+
+// NewCompletionCmd creates the completion command
+func NewCompletionCmd(isPlugin bool) *cobra.Command {
+	o := &CompletionOptions{program: "kaito"}
+	cmdName := "kaito"
+	if isPlugin {
+		o.program = "kubectl-kaito"
+		cmdName = "kubectl kaito"
+	}
+
+	cmd := &cobra.Command{
+		Use:       "completion <bash|zsh|fish|powershell>",
+		Short:     "Generate shell completion scripts",
+		Long:      completionLong(cmdName, o.program, isPlugin),
+		ValidArgs: completionShells,
+		Args:      cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			o.Shell = args[0]
+			return o.run(cmd.Root(), cmd.OutOrStdout())
+		},
+	}
+
+	cmd.Flags().BoolVar(&o.NoDescriptions, "no-descriptions", false, "Generate completions without command and flag descriptions")
+
+	return cmd
+}
+
+// completionLong is the help of the completion command with the install
+// instructions for every shell
+func completionLong(cmdName, program string, isPlugin bool) string {
+	long := fmt.Sprintf(`Completion generates a script that completes the commands, flags and arguments
+of %[2]s for bash, zsh, fish or powershell.
+
+Bash (requires the bash-completion package):
+
+  # Load completions in the current shell
+  source <(%[1]s completion bash)
+
+  # Load completions for every new shell, on Linux
+  %[1]s completion bash > /etc/bash_completion.d/%[2]s
+  # or on macOS
+  %[1]s completion bash > $(brew --prefix)/etc/bash_completion.d/%[2]s
+
+Zsh:
+
+  # Enable completion once if it is not already enabled
+  echo "autoload -U compinit; compinit" >> ~/.zshrc
+
+  # Load completions for every new shell
+  %[1]s completion zsh > "${fpath[1]}/_%[2]s"
+
+Fish:
+
+  %[1]s completion fish > ~/.config/fish/completions/%[2]s.fish
+
+PowerShell:
+
+  # Load completions in the current shell
+  %[1]s completion powershell | Out-String | Invoke-Expression
+
+  # Load completions for every new shell by adding the line above to $PROFILE
+
+Start a new shell for the settings to take effect.`, cmdName, program)
+
+	if !isPlugin {
+		return long
+	}
+	return long + `
+
+The scripts complete the kubectl-kaito executable. 'kubectl kaito' is completed
+by kubectl's own completion (kubectl 1.26 or later), which runs a
+kubectl_complete-kaito executable from PATH for the plugin arguments. Install it
+next to kubectl-kaito:
+
+  cat > kubectl_complete-kaito <<'EOF'
+  #!/usr/bin/env sh
+  kubectl kaito __complete "$@"
+  EOF
+  chmod +x kubectl_complete-kaito
+  sudo mv kubectl_complete-kaito /usr/local/bin/`
+}
+
+func (o *CompletionOptions) run(root *cobra.Command, out io.Writer) error {
+	klog.V(2).Infof("Generating %s completion for %s", o.Shell, o.program)
+
+	// The scripts are named after the root command, and 'kubectl kaito' would
+	// register them for kubectl itself
+	use := root.Use
+	root.Use = o.program
+	defer func() { root.Use = use }()
+
+	includeDescriptions := !o.NoDescriptions
+	var err error
+	switch o.Shell {
+	case "bash":
+		err = root.GenBashCompletionV2(out, includeDescriptions)
+	case "zsh":
+		if includeDescriptions {
+			err = root.GenZshCompletion(out)
+		} else {
+			err = root.GenZshCompletionNoDesc(out)
+		}
+	case "fish":
+		err = root.GenFishCompletion(out, includeDescriptions)
+	case "powershell":
+		if includeDescriptions {
+			err = root.GenPowerShellCompletionWithDesc(out)
+		} else {
+			err = root.GenPowerShellCompletion(out)
+		}
+	default:
+		return fmt.Errorf("unsupported shell %q; must be one of %v", o.Shell, completionShells)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to generate %s completion: %w", o.Shell, err)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+/*
+Copyright (c) 2024 Kaito Project
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func TestCompletionCmd(t *testing.T) {
+	generate := func(isPlugin bool, args ...string) (string, error) {
+		root := NewRootCmd(genericclioptions.NewConfigFlags(true), isPlugin)
+		var out bytes.Buffer
+		root.SetOut(&out)
+		root.SetErr(&bytes.Buffer{})
+		root.SetArgs(append([]string{"completion"}, args...))
+		err := root.Execute()
+		assert.Equal(t, map[bool]string{false: "kaito", true: "kubectl kaito"}[isPlugin], root.Use)
+		return out.String(), err
+	}
+
+	t.Run("Standalone scripts complete kaito", func(t *testing.T) {
+		for _, shell := range completionShells {
+			script, err := generate(false, shell)
+			assert.NoError(t, err, shell)
+			assert.Contains(t, script, "__kaito_debug", shell)
+			assert.NotContains(t, script, "kubectl", shell)
+		}
+	})
+
+	t.Run("Plugin scripts complete kubectl-kaito", func(t *testing.T) {
+		for _, shell := range completionShells {
+			script, err := generate(true, shell)
+			assert.NoError(t, err, shell)
+			assert.Regexp(t, `__kubectl[-_]kaito_debug`, script, shell)
+			assert.NotContains(t, script, "kubectl kaito", shell)
+		}
+	})
+
+	t.Run("Without descriptions", func(t *testing.T) {
+		script, err := generate(false, "zsh", "--no-descriptions")
+		assert.NoError(t, err)
+		assert.Contains(t, script, "__completeNoDesc")
+	})
+
+	t.Run("Invalid shell", func(t *testing.T) {
+		_, err := generate(false, "tcsh")
+		assert.ErrorContains(t, err, `invalid argument "tcsh"`)
+		_, err = generate(false)
+		assert.Error(t, err)
+	})
+
+	t.Run("Install instructions", func(t *testing.T) {
+		standalone := NewCompletionCmd(false)
+		assert.Contains(t, standalone.Long, "source <(kaito completion bash)")
+		assert.NotContains(t, standalone.Long, "kubectl_complete-kaito")
+
+		plugin := NewCompletionCmd(true)
+		assert.Contains(t, plugin.Long, "source <(kubectl kaito completion bash)")
+		assert.Contains(t, plugin.Long, `"${fpath[1]}/_kubectl-kaito"`)
+		assert.Contains(t, plugin.Long, "kubectl kaito __complete \"$@\"")
+	})
+}
@@ -19,14 +19,25 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
@@ -34,10 +45,38 @@ import (
 	"sigs.k8s.io/yaml"
 )
 
+// inferenceConfigKey is the ConfigMap key holding an inference config file
+const inferenceConfigKey = "inference_config.yaml"
+
+// tuningConfigKey is the ConfigMap key holding a tuning config file
+const tuningConfigKey = "training_config.yaml"
+
+// hfTokenKey is the key Kaito reads the Hugging Face token from in a model access secret
+const hfTokenKey = "HF_TOKEN"
+
+// Dry-run strategies accepted by --dry-run, matching kubectl
+const (
+	dryRunNone   = "none"
+	dryRunClient = "client"
+	dryRunServer = "server"
+)
+
+// inputURLCheckTimeout bounds each --validate-inputs request
+const inputURLCheckTimeout = 10 * time.Second
+
+// supportedTuningMethods are the fine-tuning methods accepted by Kaito; add new
+// methods here as Kaito supports them
+var supportedTuningMethods = []string{"qlora", "lora"}
+
 // DeployOptions holds the options for the deploy command
 type DeployOptions struct {
 	configFlags        *genericclioptions.ConfigFlags
+	clients            *clientFactory
 	Adapters           []string
+	AdaptersFile       string
+	Env                []string
+	Labels             []string
+	Annotations        []string
 	InputURLs          []string
 	PreferredNodes     []string
 	LabelSelector      map[string]string
@@ -46,6 +85,9 @@ type DeployOptions struct {
 	Model              string
 	InstanceType       string
 	ModelAccessSecret  string
+	HFToken            string
+	HFTokenFile        string
+	InferenceImage     string
 	InferenceConfig    string
 	TuningMethod       string
 	OutputImage        string
@@ -53,18 +95,59 @@ type DeployOptions struct {
 	TuningConfig       string
 	InputPVC           string
 	OutputPVC          string
-	ModelAccessMode    string
 	ModelImage         string
+	ModelImageSecret   string
+	FromFile           string
+	WorkspaceFile      string
+	OutputYAML         string
 	Count              int
-	DryRun             bool
+	Timeout            time.Duration
+	DryRun             string
+	CheckCapacity      bool
 	EnableLoadBalancer bool
+	Force              bool
+	FromModelDefaults  bool
+	Interactive        bool
+	Strict             bool
 	Tuning             bool
+	Update             bool
+	ValidateInputs     bool
+	Wait               bool
+	Yes                bool
+
+	// adapters holds --adapters with their sources resolved by Validate
+	adapters []adapterSpec
+	// hfToken is the --hf-token or --hf-token-file token read by Validate
+	hfToken string
+	// workspaceManifest is the --workspace-file manifest loaded by Validate
+	workspaceManifest *unstructured.Unstructured
+}
+
+// adapterSpec is a parsed --adapters entry of the form name[=image[:weight]]
+type adapterSpec struct {
+	Name   string
+	Image  string
+	Weight string
+}
+
+// adaptersFile is the --adapters-file document
+type adaptersFile struct {
+	Adapters []adaptersFileEntry `json:"adapters"`
+}
+
+// adaptersFileEntry is one adapter of an --adapters-file. Strength may be written
+// as a number or a string, as in the workspace inference.adapters field.
+type adaptersFileEntry struct {
+	Name     string      `json:"name"`
+	Image    string      `json:"image,omitempty"`
+	Strength interface{} `json:"strength,omitempty"`
 }
 
 // NewDeployCmd creates the deploy command
 func NewDeployCmd(configFlags *genericclioptions.ConfigFlags) *cobra.Command {
 	o := &DeployOptions{
 		configFlags: configFlags,
+		clients:     newClientFactory(configFlags),
 	}
 
 	cmd := &cobra.Command{
@@ -84,76 +167,295 @@ the specified model according to Kaito's preset configurations.`,
   # Deploy with specific instance type, count, and private model access
   kubectl kaito deploy --workspace-name phi-workspace --model phi-3.5-mini-instruct --instance-type Standard_NC6s_v3 --count 2 --model-access-secret my-secret
 
+  # Deploy a gated model, storing the Hugging Face token in a secret owned by the workspace
+  kubectl kaito deploy --workspace-name llama-workspace --model llama-3.1-8b-instruct --hf-token-file ~/.cache/huggingface/token
+
   # Deploy for fine-tuning with QLoRA (tuning mode)
   kubectl kaito deploy --workspace-name tune-phi --model phi-3.5-mini-instruct --tuning --tuning-method qlora --input-urls "https://example.com/data.parquet" --output-image myregistry/phi-finetuned:latest
 
   # Deploy for fine-tuning with PVC storage
   kubectl kaito deploy --workspace-name tune-llama --model llama-3.1-8b-instruct --tuning --input-pvc training-data --output-pvc model-output
 
+  # Pass environment variables to the inference container
+  kubectl kaito deploy --workspace-name phi-workspace --model phi-3.5-mini-instruct --env VLLM_LOGGING_LEVEL=DEBUG --env HF_HUB_OFFLINE=1
+
+  # Label and annotate the workspace for team ownership and cost allocation
+  kubectl kaito deploy --workspace-name phi-workspace --model phi-3.5-mini-instruct --label team=research --annotation cost-center=ml-platform
+
   # Deploy with load balancer for external access (inference mode)
-  kubectl kaito deploy --workspace-name public-llama --model llama-3.1-8b-instruct --enable-load-balancer`,
+  kubectl kaito deploy --workspace-name public-llama --model llama-3.1-8b-instruct --enable-load-balancer
+
+  # Scale an existing workspace in place
+  kubectl kaito deploy --workspace-name llama-workspace --model llama-3.1-8b-instruct --count 2 --update
+
+  # Check that the cluster has enough matching nodes before deploying
+  kubectl kaito deploy --workspace-name llama-workspace --model llama-3.1-8b-instruct --instance-type Standard_NC24ads_A100_v4 --count 2 --check-capacity
+
+  # Deploy and block until the workspace is ready (useful in CI)
+  kubectl kaito deploy --workspace-name llama-workspace --model llama-3.1-8b-instruct --wait --timeout 30m
+
+  # Deploy from a checked-in spec, overriding the node count
+  kubectl kaito deploy --from-file llama-workspace.yaml --count 2
+
+  # Apply a complete Workspace manifest for fields without a dedicated flag
+  kubectl kaito deploy --workspace-file workspace.yaml --wait`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if o.FromFile != "" {
+				if err := applyDeployFile(cmd.Flags(), o.FromFile); err != nil {
+					return err
+				}
+			}
+			if o.Model == "" && o.Interactive && o.WorkspaceFile == "" {
+				model, err := pickModel(os.Stdin, os.Stdout, getSupportedModels())
+				if err != nil {
+					return err
+				}
+				o.Model = model
+			}
 			if err := o.Validate(); err != nil {
 				klog.Errorf("Validation failed: %v", err)
 				return err
 			}
-			return o.Run()
+			ctx, stop := withInterrupt(cmd.Context())
+			defer stop()
+			return interruptedError(ctx, o.Run(ctx))
 		},
 	}
 
 	// Required flags
 	cmd.Flags().StringVar(&o.WorkspaceName, "workspace-name", "", "Name of the workspace to create (required)")
-	cmd.Flags().StringVar(&o.Model, "model", "", "Model name to deploy (required)")
+	cmd.Flags().StringVar(&o.Model, "model", "", "Model name to deploy (required unless picked interactively)")
+	cmd.Flags().StringVarP(&o.Namespace, "namespace", "n", "", "Kubernetes namespace to create the workspace in")
+	cmd.Flags().StringArrayVar(&o.Labels, "label", nil, "Label key=value to add to the workspace, e.g. team=research (repeatable)")
+	cmd.Flags().StringArrayVar(&o.Annotations, "annotation", nil, "Annotation key=value to add to the workspace, e.g. for cost allocation or GitOps tracking (repeatable)")
+	cmd.Flags().BoolVar(&o.Interactive, "interactive", stdinIsTerminal(), "Offer a searchable model picker when --model is omitted; on by default only when stdin is a terminal")
 
 	// Resource configuration
 	cmd.Flags().StringVar(&o.InstanceType, "instance-type", "", "GPU instance type (e.g., Standard_NC6s_v3)")
+	cmd.Flags().BoolVar(&o.FromModelDefaults, "from-model-defaults", false, "Use the recommended instance type of the model from the supported models list when --instance-type is not set")
+	cmd.Flags().BoolVar(&o.Strict, "strict", false, "Fail instead of warning when the instance type has too little GPU memory for the model")
 	cmd.Flags().IntVar(&o.Count, "count", 1, "Number of GPU nodes")
+	cmd.Flags().BoolVar(&o.CheckCapacity, "check-capacity", false, "Warn before deploying when fewer than --count ready nodes match the instance type and node selector")
 	cmd.Flags().StringToStringVar(&o.LabelSelector, "node-selector", nil, "Node selector labels")
+	cmd.Flags().StringSliceVar(&o.PreferredNodes, "preferred-nodes", nil, "Existing nodes to prefer for the workspace (must match the node selector)")
 
 	// Inference specific flags
 	cmd.Flags().StringVar(&o.ModelAccessSecret, "model-access-secret", "", "Secret for private model access")
-	cmd.Flags().StringSliceVar(&o.Adapters, "adapters", nil, "Model adapters to load")
+	cmd.Flags().StringVar(&o.HFToken, "hf-token", "", "Hugging Face token for gated models; stored in the secret <workspace-name>-hf-token, which is used as the model access secret")
+	cmd.Flags().StringVar(&o.HFTokenFile, "hf-token-file", "", "File holding the Hugging Face token, or '-' for stdin; keeps the token out of the shell history")
+	cmd.Flags().StringVar(&o.InferenceImage, "inference-image", "", "Custom runtime image for the inference preset")
+	cmd.Flags().StringSliceVar(&o.Adapters, "adapters", nil, "Model adapters to load as name=image[:weight]; a bare name uses the source listed in the supported models catalog")
+	cmd.Flags().StringVar(&o.AdaptersFile, "adapters-file", "", "YAML file listing the adapters to load, each with a name, image and strength")
+	cmd.Flags().StringArrayVar(&o.Env, "env", nil, "Environment variable KEY=VALUE for the inference container (repeatable)")
 	cmd.Flags().StringVar(&o.InferenceConfig, "inference-config", "", "Custom inference configuration (either a ConfigMap name or path to a YAML file)")
 
 	// Tuning specific flags
 	cmd.Flags().BoolVar(&o.Tuning, "tuning", false, "Enable fine-tuning mode")
-	cmd.Flags().StringVar(&o.TuningMethod, "tuning-method", "qlora", "Fine-tuning method (qlora, lora)")
+	cmd.Flags().StringVar(&o.TuningMethod, "tuning-method", "qlora", fmt.Sprintf("Fine-tuning method (%s)", strings.Join(supportedTuningMethods, ", ")))
 	cmd.Flags().StringVar(&o.ModelImage, "model-image", "", "Custom image for the model preset")
+	cmd.Flags().StringVar(&o.ModelImageSecret, "model-image-secret", "", "Secret for pulling --model-image from a private registry")
 	cmd.Flags().StringSliceVar(&o.InputURLs, "input-urls", nil, "URLs to training data")
+	cmd.Flags().BoolVar(&o.ValidateInputs, "validate-inputs", false, "Check with a HEAD request that each --input-urls URL is reachable before deploying")
 	cmd.Flags().StringVar(&o.OutputImage, "output-image", "", "Output image for fine-tuned model")
 	cmd.Flags().StringVar(&o.OutputImageSecret, "output-image-secret", "", "Secret for pushing output image")
-	cmd.Flags().StringVar(&o.TuningConfig, "tuning-config", "", "Custom tuning configuration")
+	cmd.Flags().StringVar(&o.TuningConfig, "tuning-config", "", "Custom tuning configuration (either a ConfigMap name or path to a YAML file)")
 	cmd.Flags().StringVar(&o.InputPVC, "input-pvc", "", "PVC containing training data")
 	cmd.Flags().StringVar(&o.OutputPVC, "output-pvc", "", "PVC for output storage")
 
 	// Special options
-	cmd.Flags().BoolVar(&o.DryRun, "dry-run", false, "Show what would be created without actually creating")
+	cmd.Flags().StringVar(&o.DryRun, "dry-run", dryRunNone, `Must be "none", "client", or "server". "client" prints what would be created, "server" submits the workspace for API server validation without persisting it`)
+	// A bare --dry-run keeps its original client-side meaning
+	cmd.Flags().Lookup("dry-run").NoOptDefVal = dryRunClient
 	cmd.Flags().BoolVar(&o.EnableLoadBalancer, "enable-load-balancer", false, "Create LoadBalancer service for external access")
+	cmd.Flags().StringVar(&o.OutputYAML, "output-yaml", "", "Write the workspace YAML to this file as well as deploying it; '-' writes it to stdout only and creates nothing")
+	cmd.Flags().StringVar(&o.FromFile, "from-file", "", "YAML or JSON file with deploy options keyed by flag name or DeployOptions field name; command-line flags override file values")
+	cmd.Flags().StringVar(&o.WorkspaceFile, "workspace-file", "", "Complete kaito.sh/v1beta1 Workspace manifest (YAML or JSON) to apply as-is instead of building one from flags")
+	cmd.Flags().BoolVar(&o.Update, "update", false, "Update the workspace in place if it already exists")
+	cmd.Flags().BoolVarP(&o.Yes, "yes", "y", false, "Update an existing workspace with --update without asking for confirmation")
+	cmd.Flags().BoolVar(&o.Force, "force", false, "Overwrite an existing config ConfigMap whose content differs from --inference-config or --tuning-config, or a token secret whose token differs from --hf-token")
+	cmd.Flags().BoolVar(&o.Wait, "wait", false, "Wait for the workspace to become ready after creating it")
+	cmd.Flags().DurationVar(&o.Timeout, "timeout", 15*time.Minute, "Maximum time to wait for the workspace to become ready (used with --wait)")
+
+	// --workspace-name and --model are not marked required because they may come
+	// from --from-file; Validate reports them when missing
+
+	return cmd
+}
+
+// deployFileAliases maps the kebab-case form of DeployOptions fields that are not
+// named like their flag to the flag, so that a file may use either name
+var deployFileAliases = map[string]string{
+	"label-selector": "node-selector",
+	"labels":         "label",
+	"annotations":    "annotation",
+}
+
+// deployFileFlagName returns the flag a --from-file key sets: keys are flag names
+// (workspace-name), their camelCase form (workspaceName) or DeployOptions field
+// names (labelSelector for --node-selector)
+func deployFileFlagName(key string) string {
+	name := camelToKebab(key)
+	if alias, ok := deployFileAliases[name]; ok {
+		return alias
+	}
+	return name
+}
+
+// applyDeployFile sets the deploy flags from a YAML or JSON file, with keys resolved
+// by deployFileFlagName. Flags given on the command line take precedence over the file.
+func applyDeployFile(flags *pflag.FlagSet, path string) error {
+	klog.V(3).Infof("Reading deploy options from %s", path)
 
-	// Mark required flags
-	if err := cmd.MarkFlagRequired("workspace-name"); err != nil {
-		klog.Errorf("Failed to mark workspace-name flag as required: %v", err)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read --from-file: %w", err)
 	}
-	if err := cmd.MarkFlagRequired("model"); err != nil {
-		klog.Errorf("Failed to mark model flag as required: %v", err)
+
+	var values map[string]interface{}
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
 	}
 
-	return cmd
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		name := deployFileFlagName(key)
+		flag := flags.Lookup(name)
+		// YAML 1.1 reads an unquoted yes key as a boolean
+		if key == "true" {
+			return fmt.Errorf(`key %q in %s was read as a boolean; quote it, e.g. "yes": true`, key, path)
+		}
+		if flag == nil || name == "from-file" {
+			return fmt.Errorf("unknown deploy option %q in %s", key, path)
+		}
+		if flag.Changed {
+			klog.V(3).Infof("--%s from the command line overrides %s", name, path)
+			continue
+		}
+		if err := setFlagFromFile(flags, flag, values[key]); err != nil {
+			return fmt.Errorf("invalid value for %q in %s: %w", key, path, err)
+		}
+	}
+
+	return nil
+}
+
+// setFlagFromFile sets a flag from a decoded YAML/JSON value
+func setFlagFromFile(flags *pflag.FlagSet, flag *pflag.Flag, value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []interface{}:
+		sliceValue, ok := flag.Value.(pflag.SliceValue)
+		if !ok {
+			return fmt.Errorf("expected a single value, got a list")
+		}
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			items = append(items, fmt.Sprint(item))
+		}
+		return sliceValue.Replace(items)
+	case map[string]interface{}:
+		if flag.Value.Type() != "stringToString" {
+			return fmt.Errorf("expected a single value, got a map")
+		}
+		pairs := make([]string, 0, len(v))
+		for key, item := range v {
+			pairs = append(pairs, fmt.Sprintf("%s=%v", key, item))
+		}
+		sort.Strings(pairs)
+		return flags.Set(flag.Name, strings.Join(pairs, ","))
+	default:
+		return flags.Set(flag.Name, fmt.Sprint(v))
+	}
+}
+
+// camelToKebab converts a camelCase key such as inputURLs to its flag name (input-urls).
+// Keys that are already flag names are returned unchanged.
+func camelToKebab(key string) string {
+	var b strings.Builder
+	runes := []rune(key)
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1])) {
+				b.WriteByte('-')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
 }
 
 // Validate validates the deploy options
 func (o *DeployOptions) Validate() error {
 	klog.V(4).Info("Validating deploy options")
 
+	if o.WorkspaceFile != "" {
+		if err := o.loadWorkspaceFile(); err != nil {
+			return err
+		}
+	}
+
 	if o.WorkspaceName == "" {
 		return fmt.Errorf("workspace name is required")
 	}
-	if o.Model == "" {
-		return fmt.Errorf("model name is required")
+
+	// Each catalog load may fetch it, so the checks below share one load, done
+	// only when one of them needs it
+	var models []Model
+	catalog := func() []Model {
+		if models == nil {
+			models = getSupportedModels()
+		}
+		return models
+	}
+
+	// A --workspace-file manifest may use any preset or a custom template
+	if o.workspaceManifest == nil {
+		if o.Model == "" {
+			return fmt.Errorf("model name is required")
+		}
+
+		// Validate model name against official Kaito supported models
+		if err := validateModelName(catalog(), o.Model); err != nil {
+			return err
+		}
+	}
+
+	if o.Wait && o.Timeout <= 0 {
+		return fmt.Errorf("--timeout must be greater than 0 when --wait is set")
+	}
+
+	switch o.DryRun {
+	case "", dryRunNone:
+	case dryRunClient, dryRunServer:
+		if o.Wait {
+			return fmt.Errorf("--wait cannot be used with --dry-run")
+		}
+	default:
+		return fmt.Errorf("invalid --dry-run value %q; must be %q, %q, or %q", o.DryRun, dryRunNone, dryRunClient, dryRunServer)
+	}
+
+	if o.OutputYAML == "-" && (o.Wait || o.DryRun == dryRunServer) {
+		return fmt.Errorf("--output-yaml - only prints the workspace and cannot be used with --wait or --dry-run=server")
 	}
 
-	// Validate model name against official Kaito supported models
-	if err := ValidateModelName(o.Model); err != nil {
+	if o.FromModelDefaults && o.InstanceType == "" {
+		o.applyModelDefaults(catalog())
+	}
+
+	if o.InstanceType != "" {
+		if err := o.checkInstanceType(catalog()); err != nil {
+			return err
+		}
+	}
+
+	if err := o.validatePreferredNodes(); err != nil {
 		return err
 	}
 
@@ -162,8 +464,76 @@ func (o *DeployOptions) Validate() error {
 		return err
 	}
 
+	if o.ModelImageSecret != "" && o.ModelImage == "" {
+		return fmt.Errorf("--model-image-secret requires --model-image")
+	}
+
+	if err := o.readHFToken(); err != nil {
+		return err
+	}
+
+	// A bad output image only fails when it is pushed, after training completes
+	for _, image := range []struct{ flag, ref, untagged string }{
+		{"output-image", o.OutputImage, "the fine-tuned model will be pushed as :latest, which later runs overwrite"},
+		{"model-image", o.ModelImage, "the :latest image is pulled, which can change between runs"},
+		{"inference-image", o.InferenceImage, "the :latest image is pulled, which can change between runs"},
+	} {
+		if image.ref == "" {
+			continue
+		}
+		ref, err := parseImageReference(image.ref)
+		if err != nil {
+			return fmt.Errorf("invalid --%s: %w", image.flag, err)
+		}
+		if ref.Tag == "" && ref.Digest == "" {
+			printStatus(os.Stderr, "⚠️  Warning: --%s %s has no tag; %s\n", image.flag, image.ref, image.untagged)
+		}
+	}
+
+	// A local inference config file becomes a ConfigMap after the workspace is
+	// created, so catch mistakes in it before anything is created
+	if !o.Tuning && o.InferenceConfig != "" {
+		if _, statErr := os.Stat(o.InferenceConfig); statErr == nil {
+			if err := validateInferenceConfigFile(o.InferenceConfig); err != nil {
+				return err
+			}
+		}
+	}
+
+	if _, err := parseEnvVars(o.Env); err != nil {
+		return err
+	}
+	if _, err := parseMetadataPairs("label", o.Labels); err != nil {
+		return err
+	}
+	if _, err := parseMetadataPairs("annotation", o.Annotations); err != nil {
+		return err
+	}
+
+	if len(o.Adapters) > 0 || o.AdaptersFile != "" {
+		specs, err := parseAdapterSpecs(o.Adapters)
+		if err != nil {
+			return err
+		}
+		if o.AdaptersFile != "" {
+			fileSpecs, err := readAdaptersFile(o.AdaptersFile)
+			if err != nil {
+				return err
+			}
+			specs = append(specs, fileSpecs...)
+		}
+		adapters, err := resolveAdapterSpecs(specs, o.Model, catalog())
+		if err != nil {
+			return err
+		}
+		o.adapters = adapters
+	}
+
 	// Validate tuning specific requirements
-	if o.Tuning {
+	if o.Tuning && o.workspaceManifest == nil {
+		if err := validateTuningMethod(o.TuningMethod); err != nil {
+			return err
+		}
 		if len(o.InputURLs) == 0 && o.InputPVC == "" {
 			return fmt.Errorf("tuning mode requires either --input-urls or --input-pvc")
 		}
@@ -172,10 +542,510 @@ func (o *DeployOptions) Validate() error {
 		}
 	}
 
+	if o.ValidateInputs && len(o.InputURLs) == 0 {
+		return fmt.Errorf("--validate-inputs requires --input-urls")
+	}
+
 	klog.V(4).Info("Deploy options validation completed successfully")
 	return nil
 }
 
+// loadWorkspaceFile reads the --workspace-file manifest and takes the workspace name,
+// namespace, mode, model and node requirements from it. Server-populated fields such
+// as status and resourceVersion are dropped so that a manifest exported from a
+// cluster can be applied.
+func (o *DeployOptions) loadWorkspaceFile() error {
+	if err := o.validateWorkspaceFileFlags(); err != nil {
+		return err
+	}
+
+	workspace, err := readWorkspaceManifest(o.WorkspaceFile)
+	if err != nil {
+		return err
+	}
+
+	switch name := workspace.GetName(); {
+	case name == "" && o.WorkspaceName == "":
+		return fmt.Errorf("%s has no metadata.name; set it or pass --workspace-name", o.WorkspaceFile)
+	case name == "":
+		workspace.SetName(o.WorkspaceName)
+	case o.WorkspaceName != "" && o.WorkspaceName != name:
+		return fmt.Errorf("--workspace-name %s does not match metadata.name %s in %s", o.WorkspaceName, name, o.WorkspaceFile)
+	}
+	o.WorkspaceName = workspace.GetName()
+	switch namespace := workspace.GetNamespace(); {
+	case namespace == "":
+	case o.Namespace != "" && o.Namespace != namespace:
+		return fmt.Errorf("--namespace %s does not match metadata.namespace %s in %s", o.Namespace, namespace, o.WorkspaceFile)
+	default:
+		o.Namespace = namespace
+	}
+
+	for _, field := range [][]string{
+		{"metadata", "resourceVersion"},
+		{"metadata", "uid"},
+		{"metadata", "generation"},
+		{"metadata", "creationTimestamp"},
+		{"metadata", "managedFields"},
+		{"status"},
+	} {
+		unstructured.RemoveNestedField(workspace.Object, field...)
+	}
+
+	mode, model := workspacePreset(workspace)
+	o.Tuning = mode == "tuning"
+	o.Model = model
+	o.InstanceType, _, _ = unstructured.NestedString(workspace.Object, "resource", "instanceType")
+	if count, found, _ := unstructured.NestedInt64(workspace.Object, "resource", "count"); found {
+		o.Count = int(count)
+	}
+	o.LabelSelector, _, _ = unstructured.NestedStringMap(workspace.Object, "resource", "labelSelector", "matchLabels")
+
+	o.workspaceManifest = workspace
+	return nil
+}
+
+// validateWorkspaceFileFlags rejects flags that build the workspace spec, which a
+// --workspace-file manifest replaces
+func (o *DeployOptions) validateWorkspaceFileFlags() error {
+	specFlags := []struct {
+		name string
+		set  bool
+	}{
+		{"model", o.Model != ""},
+		{"instance-type", o.InstanceType != ""},
+		{"from-model-defaults", o.FromModelDefaults},
+		{"count", o.Count != 1},
+		{"node-selector", len(o.LabelSelector) > 0},
+		{"preferred-nodes", len(o.PreferredNodes) > 0},
+		{"tuning", o.Tuning},
+		{"model-access-secret", o.ModelAccessSecret != ""},
+		{"hf-token", o.HFToken != ""},
+		{"hf-token-file", o.HFTokenFile != ""},
+		{"inference-image", o.InferenceImage != ""},
+		{"adapters", len(o.Adapters) > 0},
+		{"adapters-file", o.AdaptersFile != ""},
+		{"env", len(o.Env) > 0},
+		{"label", len(o.Labels) > 0},
+		{"annotation", len(o.Annotations) > 0},
+		{"inference-config", o.InferenceConfig != ""},
+		{"enable-load-balancer", o.EnableLoadBalancer},
+		{"input-urls", len(o.InputURLs) > 0},
+		{"output-image", o.OutputImage != ""},
+		{"output-image-secret", o.OutputImageSecret != ""},
+		{"tuning-config", o.TuningConfig != ""},
+		{"input-pvc", o.InputPVC != ""},
+		{"output-pvc", o.OutputPVC != ""},
+		{"model-image", o.ModelImage != ""},
+		{"model-image-secret", o.ModelImageSecret != ""},
+	}
+
+	for _, flag := range specFlags {
+		if flag.set {
+			return fmt.Errorf("--%s cannot be used with --workspace-file; set the field in the manifest instead", flag.name)
+		}
+	}
+	return nil
+}
+
+// readWorkspaceManifest reads a YAML or JSON Workspace manifest and checks that it
+// is a single kaito.sh/v1beta1 Workspace
+func readWorkspaceManifest(path string) (*unstructured.Unstructured, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read --workspace-file: %w", err)
+	}
+
+	if strings.TrimSpace(string(data)) == "" {
+		return nil, fmt.Errorf("%s is empty; expected a kaito.sh/v1beta1 Workspace manifest", path)
+	}
+
+	var typeMeta metav1.TypeMeta
+	if err := yaml.Unmarshal(data, &typeMeta); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if typeMeta.APIVersion != "kaito.sh/v1beta1" || typeMeta.Kind != "Workspace" {
+		return nil, fmt.Errorf("%s must contain a kaito.sh/v1beta1 Workspace, got apiVersion %q and kind %q", path, typeMeta.APIVersion, typeMeta.Kind)
+	}
+
+	// Decoding through the unstructured JSON scheme keeps integers such as resource.count as int64
+	jsonData, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	workspace := &unstructured.Unstructured{}
+	if err := workspace.UnmarshalJSON(jsonData); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return workspace, nil
+}
+
+// checkInstanceType warns, or fails with --strict, when the instance type is known to have
+// less GPU memory than the model needs. It is best effort: unknown instance types and
+// models without GPU memory metadata are not checked.
+func (o *DeployOptions) checkInstanceType(models []Model) error {
+	if o.InstanceType == "" {
+		return nil
+	}
+
+	var model *Model
+	for i := range models {
+		if models[i].Name == o.Model {
+			model = &models[i]
+			break
+		}
+	}
+	if model == nil {
+		return nil
+	}
+
+	required, ok := parseGPUMemoryGiB(model.GPUMemory)
+	if !ok {
+		klog.V(3).Infof("No GPU memory requirement known for model %s, skipping instance type check", o.Model)
+		return nil
+	}
+	perNode, ok := instanceGPUMemoryGiB(o.InstanceType)
+	if !ok {
+		klog.V(3).Infof("GPU memory of instance type %s is unknown, skipping instance type check", o.InstanceType)
+		return nil
+	}
+
+	nodes := o.Count
+	if nodes < 1 {
+		nodes = 1
+	}
+	if perNode*float64(nodes) >= required {
+		return nil
+	}
+
+	msg := fmt.Sprintf("instance type %s provides %.0f GiB of GPU memory on %d node(s), but model %s needs %s",
+		o.InstanceType, perNode*float64(nodes), nodes, o.Model, model.GPUMemory)
+	if model.InstanceType != "" {
+		msg += fmt.Sprintf("; the recommended instance type is %s", model.InstanceType)
+	}
+
+	if o.Strict {
+		return fmt.Errorf("%s", msg)
+	}
+	printStatus(os.Stderr, "⚠️  Warning: %s\n", msg)
+	printStatus(os.Stderr, "💡 Use --strict to fail instead of warning\n")
+	return nil
+}
+
+// applyModelDefaults sets the instance type to the one recommended for the model in
+// the supported models list. Without a recommendation it is left empty, so the
+// Kaito operator picks the nodes as usual.
+func (o *DeployOptions) applyModelDefaults(models []Model) {
+	for _, model := range models {
+		if model.Name == o.Model && model.InstanceType != "" {
+			o.InstanceType = model.InstanceType
+			printStatus(os.Stderr, "ℹ️  Using instance type %s, recommended for model %s\n", o.InstanceType, o.Model)
+			return
+		}
+	}
+	printStatus(os.Stderr, "ℹ️  No recommended instance type is known for model %s; leaving it to the Kaito operator\n", o.Model)
+}
+
+// validatePreferredNodes rejects empty or duplicate node names and a hostname
+// node selector that would exclude the preferred nodes
+func (o *DeployOptions) validatePreferredNodes() error {
+	seen := make(map[string]bool, len(o.PreferredNodes))
+	for _, node := range o.PreferredNodes {
+		if node == "" {
+			return fmt.Errorf("--preferred-nodes must not contain empty node names")
+		}
+		if seen[node] {
+			return fmt.Errorf("--preferred-nodes contains duplicate node %s", node)
+		}
+		seen[node] = true
+	}
+
+	if len(o.PreferredNodes) == 0 {
+		return nil
+	}
+
+	if hostname, found := o.LabelSelector[corev1.LabelHostname]; found {
+		if !seen[hostname] {
+			return fmt.Errorf("--node-selector %s=%s excludes every node in --preferred-nodes; the preferred nodes must match the node selector",
+				corev1.LabelHostname, hostname)
+		}
+		if len(o.PreferredNodes) > 1 {
+			return fmt.Errorf("--node-selector %s=%s only matches one of the %d --preferred-nodes",
+				corev1.LabelHostname, hostname, len(o.PreferredNodes))
+		}
+	}
+
+	return nil
+}
+
+// validateTuningMethod checks a --tuning-method value against supportedTuningMethods,
+// suggesting the closest method for a likely typo. Empty uses the Kaito default.
+func validateTuningMethod(method string) error {
+	if method == "" {
+		return nil
+	}
+
+	suggestion := ""
+	bestDistance := 3 // suggest methods at most two edits away
+	for _, supported := range supportedTuningMethods {
+		if method == supported {
+			return nil
+		}
+		if distance := levenshteinDistance(strings.ToLower(method), supported); distance < bestDistance {
+			suggestion, bestDistance = supported, distance
+		}
+	}
+
+	if suggestion != "" {
+		return fmt.Errorf("unsupported tuning method %q; did you mean %q? Supported methods: %s",
+			method, suggestion, strings.Join(supportedTuningMethods, ", "))
+	}
+	return fmt.Errorf("unsupported tuning method %q; supported methods: %s", method, strings.Join(supportedTuningMethods, ", "))
+}
+
+// parseEnvVars parses --env KEY=VALUE values in order, rejecting invalid names and duplicate keys
+func parseEnvVars(values []string) ([]corev1.EnvVar, error) {
+	envVars := make([]corev1.EnvVar, 0, len(values))
+	seen := make(map[string]bool, len(values))
+	for _, value := range values {
+		name, envValue, found := strings.Cut(value, "=")
+		if !found || name == "" {
+			return nil, fmt.Errorf("invalid --env %q: expected KEY=VALUE", value)
+		}
+		if problems := validation.IsEnvVarName(name); len(problems) > 0 {
+			return nil, fmt.Errorf("invalid --env name %q: %s", name, strings.Join(problems, "; "))
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("duplicate --env key %q", name)
+		}
+		seen[name] = true
+		envVars = append(envVars, corev1.EnvVar{Name: name, Value: envValue})
+	}
+	return envVars, nil
+}
+
+// parseMetadataPairs parses --label or --annotation key=value values, rejecting invalid
+// keys, duplicate keys and keys under the kaito.sh/ prefix that Kaito reserves for itself
+func parseMetadataPairs(flag string, values []string) (map[string]string, error) {
+	pairs := make(map[string]string, len(values))
+	for _, value := range values {
+		key, pairValue, found := strings.Cut(value, "=")
+		if !found || key == "" {
+			return nil, fmt.Errorf("invalid --%s %q: expected key=value", flag, value)
+		}
+		if problems := validation.IsQualifiedName(key); len(problems) > 0 {
+			return nil, fmt.Errorf("invalid --%s key %q: %s", flag, key, strings.Join(problems, "; "))
+		}
+		if strings.HasPrefix(key, "kaito.sh/") {
+			return nil, fmt.Errorf("--%s key %q uses the kaito.sh/ prefix, which is reserved for Kaito", flag, key)
+		}
+		if flag == "label" {
+			if problems := validation.IsValidLabelValue(pairValue); len(problems) > 0 {
+				return nil, fmt.Errorf("invalid --label value %q for %s: %s", pairValue, key, strings.Join(problems, "; "))
+			}
+		}
+		if _, seen := pairs[key]; seen {
+			return nil, fmt.Errorf("duplicate --%s key %q", flag, key)
+		}
+		pairs[key] = pairValue
+	}
+	return pairs, nil
+}
+
+// parseAdapterSpec parses name[=image[:weight]]. The last ':' segment is read as the
+// weight only if it is a number, so image tags such as ':v1' are kept in the image.
+func parseAdapterSpec(value string) (adapterSpec, error) {
+	name, source, hasSource := strings.Cut(strings.TrimSpace(value), "=")
+	spec := adapterSpec{Name: strings.TrimSpace(name)}
+	if spec.Name == "" {
+		return spec, fmt.Errorf("invalid adapter %q: missing name", value)
+	}
+	if !hasSource {
+		return spec, nil
+	}
+
+	spec.Image = strings.TrimSpace(source)
+	if i := strings.LastIndex(spec.Image, ":"); i >= 0 {
+		if weight, err := strconv.ParseFloat(spec.Image[i+1:], 64); err == nil {
+			if weight <= 0 || weight > 1 {
+				return spec, fmt.Errorf("invalid adapter %q: weight must be greater than 0 and at most 1, got %s", value, spec.Image[i+1:])
+			}
+			spec.Weight = strconv.FormatFloat(weight, 'f', -1, 64)
+			spec.Image = spec.Image[:i]
+		}
+	}
+	if spec.Image == "" {
+		return spec, fmt.Errorf("invalid adapter %q: missing image after '='", value)
+	}
+	return spec, nil
+}
+
+// parseAdapterSpecs parses the --adapters values in order
+func parseAdapterSpecs(values []string) ([]adapterSpec, error) {
+	specs := make([]adapterSpec, 0, len(values))
+	for _, value := range values {
+		spec, err := parseAdapterSpec(value)
+		if err != nil {
+			return nil, err
+		}
+		specs = append(specs, spec)
+	}
+	return specs, nil
+}
+
+// readAdaptersFile reads the adapters listed in an --adapters-file. Unknown fields
+// are rejected so that a misspelled strength is not silently dropped.
+func readAdaptersFile(path string) ([]adapterSpec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read --adapters-file: %w", err)
+	}
+
+	var file adaptersFile
+	if err := yaml.UnmarshalStrict(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if len(file.Adapters) == 0 {
+		return nil, fmt.Errorf("%s lists no adapters; expected an 'adapters' list with a name for each", path)
+	}
+
+	specs := make([]adapterSpec, 0, len(file.Adapters))
+	for i, entry := range file.Adapters {
+		spec := adapterSpec{Name: strings.TrimSpace(entry.Name), Image: strings.TrimSpace(entry.Image)}
+		if spec.Name == "" {
+			return nil, fmt.Errorf("adapter %d in %s: name is required", i+1, path)
+		}
+
+		var strength string
+		switch value := entry.Strength.(type) {
+		case nil:
+		case float64:
+			strength = strconv.FormatFloat(value, 'f', -1, 64)
+		case string:
+			strength = strings.TrimSpace(value)
+		default:
+			return nil, fmt.Errorf("adapter %s in %s: strength must be a number, got %v", spec.Name, path, value)
+		}
+		if strength != "" {
+			weight, err := strconv.ParseFloat(strength, 64)
+			if err != nil || weight <= 0 || weight > 1 {
+				return nil, fmt.Errorf("adapter %s in %s: strength must be greater than 0 and at most 1, got %s", spec.Name, path, strength)
+			}
+			spec.Weight = strconv.FormatFloat(weight, 'f', -1, 64)
+		}
+		specs = append(specs, spec)
+	}
+	return specs, nil
+}
+
+// resolveAdapters parses the --adapters values. Adapters given by name only take their
+// source image from the model's entry in the supported models catalog.
+func resolveAdapters(values []string, modelName string, models []Model) ([]adapterSpec, error) {
+	specs, err := parseAdapterSpecs(values)
+	if err != nil {
+		return nil, err
+	}
+	return resolveAdapterSpecs(specs, modelName, models)
+}
+
+// resolveAdapterSpecs rejects duplicate adapters and fills in the source image of
+// adapters given by name only from the supported models catalog
+func resolveAdapterSpecs(specs []adapterSpec, modelName string, models []Model) ([]adapterSpec, error) {
+	var catalog []ModelAdapter
+	for _, model := range models {
+		if model.Name == modelName {
+			catalog = model.Adapters
+			break
+		}
+	}
+
+	seen := map[string]bool{}
+	resolved := make([]adapterSpec, 0, len(specs))
+	for _, spec := range specs {
+		if seen[spec.Name] {
+			return nil, fmt.Errorf("adapter %s is specified more than once", spec.Name)
+		}
+		seen[spec.Name] = true
+
+		if spec.Image == "" {
+			for _, adapter := range catalog {
+				if adapter.Name == spec.Name {
+					spec.Image = adapter.Source
+					break
+				}
+			}
+			if spec.Image == "" {
+				return nil, fmt.Errorf("no source image known for adapter %s; use --adapters %s=<image>[:weight] (see 'kubectl kaito models adapters %s')",
+					spec.Name, spec.Name, modelName)
+			}
+		}
+		resolved = append(resolved, spec)
+	}
+	return resolved, nil
+}
+
+// toWorkspaceAdapter converts the spec to a workspace inference.adapters entry
+func (a adapterSpec) toWorkspaceAdapter() map[string]interface{} {
+	adapter := map[string]interface{}{
+		"source": map[string]interface{}{
+			"name":  a.Name,
+			"image": a.Image,
+		},
+	}
+	if a.Weight != "" {
+		adapter["strength"] = a.Weight
+	}
+	return adapter
+}
+
+// readHFToken reads the --hf-token or --hf-token-file token into o.hfToken
+func (o *DeployOptions) readHFToken() error {
+	if o.HFToken == "" && o.HFTokenFile == "" {
+		return nil
+	}
+	if o.HFToken != "" && o.HFTokenFile != "" {
+		return fmt.Errorf("--hf-token and --hf-token-file cannot be used together")
+	}
+	if o.ModelAccessSecret != "" {
+		return fmt.Errorf("--hf-token creates the model access secret %s and cannot be used with --model-access-secret", hfTokenSecretName(o.WorkspaceName))
+	}
+
+	token := o.HFToken
+	if o.HFTokenFile != "" {
+		var data []byte
+		var err error
+		if o.HFTokenFile == "-" {
+			data, err = io.ReadAll(os.Stdin)
+		} else {
+			data, err = os.ReadFile(o.HFTokenFile)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read --hf-token-file: %w", err)
+		}
+		token = string(data)
+	}
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return fmt.Errorf("the Hugging Face token is empty")
+	}
+	o.hfToken = token
+	return nil
+}
+
+// hfTokenSecretName is the name of the secret --hf-token stores the token in
+func hfTokenSecretName(workspaceName string) string {
+	return workspaceName + "-hf-token"
+}
+
+// modelAccessSecret returns the secret the inference preset reads the model token
+// from: --model-access-secret, or the secret created for --hf-token
+func (o *DeployOptions) modelAccessSecret() string {
+	if o.HFToken != "" || o.HFTokenFile != "" {
+		return hfTokenSecretName(o.WorkspaceName)
+	}
+	return o.ModelAccessSecret
+}
+
 // validateModeFlags ensures users don't mix inference and tuning parameters
 func (o *DeployOptions) validateModeFlags() error {
 	// Define inference-specific flags
@@ -185,7 +1055,12 @@ func (o *DeployOptions) validateModeFlags() error {
 		empty bool
 	}{
 		{"model-access-secret", o.ModelAccessSecret, o.ModelAccessSecret == ""},
+		{"hf-token", o.HFToken, o.HFToken == ""},
+		{"hf-token-file", o.HFTokenFile, o.HFTokenFile == ""},
+		{"inference-image", o.InferenceImage, o.InferenceImage == ""},
 		{"adapters", o.Adapters, len(o.Adapters) == 0},
+		{"adapters-file", o.AdaptersFile, o.AdaptersFile == ""},
+		{"env", o.Env, len(o.Env) == 0},
 		{"inference-config", o.InferenceConfig, o.InferenceConfig == ""},
 		{"enable-load-balancer", o.EnableLoadBalancer, !o.EnableLoadBalancer},
 	}
@@ -197,119 +1072,494 @@ func (o *DeployOptions) validateModeFlags() error {
 		empty bool
 	}{
 		{"input-urls", o.InputURLs, len(o.InputURLs) == 0},
+		{"validate-inputs", o.ValidateInputs, !o.ValidateInputs},
 		{"output-image", o.OutputImage, o.OutputImage == ""},
 		{"output-image-secret", o.OutputImageSecret, o.OutputImageSecret == ""},
 		{"tuning-config", o.TuningConfig, o.TuningConfig == ""},
 		{"input-pvc", o.InputPVC, o.InputPVC == ""},
 		{"output-pvc", o.OutputPVC, o.OutputPVC == ""},
 		{"model-image", o.ModelImage, o.ModelImage == ""},
+		{"model-image-secret", o.ModelImageSecret, o.ModelImageSecret == ""},
+	}
+
+	// Check if tuning mode is explicitly enabled
+	if o.Tuning {
+		// In tuning mode, check if any inference-specific flags are set
+		for _, flag := range inferenceFlags {
+			if !flag.empty {
+				return fmt.Errorf("cannot use inference flag --%s when --tuning is enabled", flag.name)
+			}
+		}
+	} else {
+		// In inference mode (default), check if any tuning-specific flags are set
+		for _, flag := range tuningFlags {
+			if !flag.empty {
+				return fmt.Errorf("tuning flag --%s can only be used with --tuning enabled", flag.name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Run executes the deploy command
+func (o *DeployOptions) Run(ctx context.Context) error {
+	klog.V(2).Infof("Starting deploy command for workspace: %s", o.WorkspaceName)
+
+	// Get namespace from config flags if not set
+	o.Namespace = resolveNamespace(o.configFlags, o.Namespace)
+
+	// Catch typos in the training data URLs before nodes are provisioned for them
+	if o.ValidateInputs {
+		client := &http.Client{Transport: newHTTPTransport(), Timeout: inputURLCheckTimeout}
+		if err := checkInputURLs(ctx, client, o.InputURLs); err != nil {
+			return err
+		}
+		printStatus(os.Stdout, "✓ All %d input URL(s) are reachable\n", len(o.InputURLs))
+	}
+
+	if o.OutputYAML != "" {
+		if err := o.writeWorkspaceYAML(); err != nil {
+			return err
+		}
+		if o.OutputYAML == "-" {
+			return nil
+		}
+	}
+
+	if o.DryRun == dryRunClient {
+		return o.showDryRun()
+	}
+
+	dynamicClient, err := o.clients.DynamicClient()
+	if err != nil {
+		return err
+	}
+
+	clientset, err := o.clients.KubernetesClient()
+	if err != nil {
+		return err
+	}
+
+	// Fail before creating anything if the pull secret is missing
+	if o.ModelImageSecret != "" {
+		if err := checkSecretExists(ctx, clientset, o.Namespace, o.ModelImageSecret); err != nil {
+			return err
+		}
+	}
+
+	if o.CheckCapacity {
+		request := capacityRequest{
+			InstanceType:   o.InstanceType,
+			LabelSelector:  o.LabelSelector,
+			PreferredNodes: o.PreferredNodes,
+			Count:          o.Count,
+		}
+		report, err := checkNodeCapacity(ctx, clientset, request)
+		if err != nil {
+			return err
+		}
+		printCapacityReport(os.Stdout, request, report)
+	}
+
+	// Create workspace
+	workspace, applied, err := o.applyWorkspace(ctx, dynamicClient, o.buildWorkspace())
+	if err != nil {
+		return err
+	}
+
+	// An existing workspace left untouched keeps its token and config as well
+	if applied {
+		if err := o.createWorkspaceResources(ctx, clientset, workspace); err != nil {
+			return err
+		}
+	}
+
+	if o.DryRun == dryRunServer {
+		return nil
+	}
+
+	if o.Wait {
+		return o.waitForWorkspaceReady(ctx, dynamicClient)
+	}
+
+	printStatus(os.Stdout, "ℹ️  Use 'kubectl kaito status --workspace-name %s' to check status\n", o.WorkspaceName)
+	return nil
+}
+
+// createWorkspaceResources creates the Hugging Face token secret and the inference or
+// tuning ConfigMap that go with a created or updated workspace, owned by it
+func (o *DeployOptions) createWorkspaceResources(ctx context.Context, clientset kubernetes.Interface, workspace *unstructured.Unstructured) error {
+	// Like the ConfigMaps below, the token secret is created after the workspace so
+	// that it is owned by it; the inference pod waits for the secret until then
+	if o.hfToken != "" {
+		if err := createHFTokenSecret(ctx, clientset, o.hfToken, o.WorkspaceName, o.Namespace,
+			workspaceOwnerReference(workspace), o.Force, o.serverDryRun()); err != nil {
+			klog.Errorf("Failed to create Hugging Face token secret: %v", err)
+			return fmt.Errorf("failed to create Hugging Face token secret: %w", err)
+		}
+		if o.DryRun != dryRunServer {
+			printStatus(os.Stdout, "✓ Hugging Face token stored in secret %s\n", hfTokenSecretName(o.WorkspaceName))
+		}
+	}
+
+	// Create a ConfigMap if the inference or tuning config is a file path. It is created
+	// after the workspace so that it can be owned by it and garbage-collected with it.
+	if !o.Tuning && o.InferenceConfig != "" {
+		// Check if it's a file path
+		if _, statErr := os.Stat(o.InferenceConfig); statErr == nil {
+			if createErr := createInferenceConfigMap(ctx, clientset, o.InferenceConfig, o.WorkspaceName, o.Namespace,
+				workspaceOwnerReference(workspace), o.Force, o.serverDryRun()); createErr != nil {
+				klog.Errorf("Failed to create inference ConfigMap: %v", createErr)
+				return fmt.Errorf("failed to create inference ConfigMap: %w", createErr)
+			}
+		}
+	}
+	if o.Tuning && o.TuningConfig != "" {
+		if _, statErr := os.Stat(o.TuningConfig); statErr == nil {
+			if createErr := createTuningConfigMap(ctx, clientset, o.TuningConfig, o.WorkspaceName, o.Namespace,
+				workspaceOwnerReference(workspace), o.Force, o.serverDryRun()); createErr != nil {
+				klog.Errorf("Failed to create tuning ConfigMap: %v", createErr)
+				return fmt.Errorf("failed to create tuning ConfigMap: %w", createErr)
+			}
+		}
+	}
+	return nil
+}
+
+// serverDryRun returns the DryRun request option for --dry-run=server, nil otherwise
+func (o *DeployOptions) serverDryRun() []string {
+	if o.DryRun == dryRunServer {
+		return []string{metav1.DryRunAll}
+	}
+	return nil
+}
+
+// applyWorkspace creates the workspace, or updates it in place with --update if it
+// already exists. With --dry-run=server the API server validates the request
+// (including admission webhooks) without persisting it.
+// It returns the workspace as stored by the API server, and whether it was created or
+// updated rather than left as it was.
+func (o *DeployOptions) applyWorkspace(ctx context.Context, dynamicClient dynamic.Interface, workspace *unstructured.Unstructured) (*unstructured.Unstructured, bool, error) {
+	klog.V(2).Infof("Creating workspace %s in namespace %s", o.WorkspaceName, o.Namespace)
+
+	gvr := schema.GroupVersionResource{
+		Group:    "kaito.sh",
+		Version:  "v1beta1",
+		Resource: "workspaces",
+	}
+
+	suffix := ""
+	if o.DryRun == dryRunServer {
+		suffix = " (server dry run, nothing was persisted)"
 	}
 
-	// Check if tuning mode is explicitly enabled
-	if o.Tuning {
-		// In tuning mode, check if any inference-specific flags are set
-		for _, flag := range inferenceFlags {
-			if !flag.empty {
-				return fmt.Errorf("cannot use inference flag --%s when --tuning is enabled", flag.name)
+	created, err := dynamicClient.Resource(gvr).Namespace(o.Namespace).Create(
+		ctx,
+		workspace,
+		metav1.CreateOptions{DryRun: o.serverDryRun()},
+	)
+
+	if err != nil {
+		if !errors.IsAlreadyExists(err) {
+			klog.Errorf("Failed to create workspace: %v", err)
+			return nil, false, fmt.Errorf("failed to create workspace: %w", err)
+		}
+		if !o.Update {
+			printStatus(os.Stdout, "✓ Workspace %s already exists\n", o.WorkspaceName)
+			printStatus(os.Stdout, "💡 Use --update to apply the new configuration to the existing workspace\n")
+			existing, err := dynamicClient.Resource(gvr).Namespace(o.Namespace).Get(ctx, o.WorkspaceName, metav1.GetOptions{})
+			if err != nil {
+				return nil, false, fmt.Errorf("failed to get workspace %s: %w", o.WorkspaceName, err)
 			}
+			return existing, false, nil
 		}
-	} else {
-		// In inference mode (default), check if any tuning-specific flags are set
-		for _, flag := range tuningFlags {
-			if !flag.empty {
-				return fmt.Errorf("tuning flag --%s can only be used with --tuning enabled", flag.name)
+		// A server dry run changes nothing, so it needs no confirmation
+		if o.DryRun != dryRunServer {
+			if err := confirmAction(fmt.Sprintf("update existing workspace %s in namespace %s", o.WorkspaceName, o.Namespace), o.Yes); err != nil {
+				return nil, false, err
 			}
 		}
+		updated, err := o.updateWorkspace(ctx, dynamicClient, workspace)
+		if err != nil {
+			return nil, false, err
+		}
+		printStatus(os.Stdout, "✓ Workspace %s updated successfully%s\n", o.WorkspaceName, suffix)
+		return updated, true, nil
 	}
 
-	return nil
+	printStatus(os.Stdout, "✓ Workspace %s created successfully%s\n", o.WorkspaceName, suffix)
+	return created, true, nil
 }
 
-// Run executes the deploy command
-func (o *DeployOptions) Run() error {
-	klog.V(2).Infof("Starting deploy command for workspace: %s", o.WorkspaceName)
-
-	// Get namespace from config flags if not set
-	if o.Namespace == "" {
-		if ns, _, err := o.configFlags.ToRawKubeConfigLoader().Namespace(); err == nil && ns != "" {
-			o.Namespace = ns
-		} else {
-			klog.V(4).Info("No namespace specified, using 'default'")
-			o.Namespace = "default"
-		}
+// workspaceOwnerReference returns an owner reference to the workspace, or nil when it
+// has no UID yet (e.g. in a server dry run)
+func workspaceOwnerReference(workspace *unstructured.Unstructured) *metav1.OwnerReference {
+	if workspace == nil || workspace.GetUID() == "" {
+		return nil
 	}
+	return &metav1.OwnerReference{
+		APIVersion: "kaito.sh/v1beta1",
+		Kind:       "Workspace",
+		Name:       workspace.GetName(),
+		UID:        workspace.GetUID(),
+	}
+}
 
-	if o.DryRun {
-		return o.showDryRun()
+// updateWorkspace applies the desired configuration to an existing workspace,
+// keeping its metadata and status
+func (o *DeployOptions) updateWorkspace(ctx context.Context, dynamicClient dynamic.Interface, desired *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	klog.V(2).Infof("Updating workspace %s in namespace %s", o.WorkspaceName, o.Namespace)
+
+	gvr := schema.GroupVersionResource{
+		Group:    "kaito.sh",
+		Version:  "v1beta1",
+		Resource: "workspaces",
 	}
 
-	// Get REST config
-	config, err := o.configFlags.ToRESTConfig()
+	existing, err := dynamicClient.Resource(gvr).Namespace(o.Namespace).Get(
+		ctx,
+		o.WorkspaceName,
+		metav1.GetOptions{},
+	)
 	if err != nil {
-		klog.Errorf("Failed to get REST config: %v", err)
-		return fmt.Errorf("failed to get REST config: %w", err)
+		return nil, fmt.Errorf("failed to get workspace %s: %w", o.WorkspaceName, err)
+	}
+
+	// The node selection is immutable in Kaito, so it is only replaced when asked for
+	var keep []string
+	if len(o.LabelSelector) == 0 {
+		keep = append(keep, "labelSelector")
+	}
+	if len(o.PreferredNodes) == 0 {
+		keep = append(keep, "preferredNodes")
 	}
 
-	// Create dynamic client
-	dynamicClient, err := dynamic.NewForConfig(config)
+	merged, err := mergeWorkspaceUpdate(existing, desired, keep...)
 	if err != nil {
-		klog.Errorf("Failed to create dynamic client: %v", err)
-		return fmt.Errorf("failed to create dynamic client: %w", err)
+		return nil, err
 	}
 
-	// Create Kubernetes client
-	clientset, err := kubernetes.NewForConfig(config)
+	updated, err := dynamicClient.Resource(gvr).Namespace(o.Namespace).Update(
+		ctx,
+		merged,
+		metav1.UpdateOptions{DryRun: o.serverDryRun()},
+	)
 	if err != nil {
-		klog.Errorf("Failed to create Kubernetes client: %v", err)
-		return fmt.Errorf("failed to create Kubernetes client: %w", err)
+		klog.Errorf("Failed to update workspace: %v", err)
+		return nil, fmt.Errorf("failed to update workspace: %w", err)
+	}
+	return updated, nil
+}
+
+// mergeWorkspaceUpdate returns a copy of existing with the resource, inference and
+// tuning sections replaced by those of desired, and desired labels and annotations
+// added. The resource fields named in keep, and the instance type when desired has
+// none, keep their existing values. The preset model and the inference/tuning mode
+// cannot be changed.
+func mergeWorkspaceUpdate(existing, desired *unstructured.Unstructured, keep ...string) (*unstructured.Unstructured, error) {
+	existingMode, existingModel := workspacePreset(existing)
+	desiredMode, desiredModel := workspacePreset(desired)
+
+	if existingMode != "" && existingMode != desiredMode {
+		return nil, fmt.Errorf("cannot change workspace %s from %s to %s; delete and recreate it instead",
+			existing.GetName(), existingMode, desiredMode)
+	}
+	if existingModel != "" && existingModel != desiredModel {
+		return nil, fmt.Errorf("cannot change the model preset of workspace %s from %s to %s; the preset name is immutable",
+			existing.GetName(), existingModel, desiredModel)
 	}
 
-	// Create ConfigMap if inference config is a file path
-	if !o.Tuning && o.InferenceConfig != "" {
-		// Check if it's a file path
-		if _, statErr := os.Stat(o.InferenceConfig); statErr == nil {
-			if createErr := createInferenceConfigMap(clientset, o.InferenceConfig, o.WorkspaceName, o.Namespace); createErr != nil {
-				klog.Errorf("Failed to create inference ConfigMap: %v", createErr)
-				return fmt.Errorf("failed to create inference ConfigMap: %w", createErr)
+	updated := existing.DeepCopy()
+
+	for _, field := range []string{"resource", "inference", "tuning"} {
+		delete(updated.Object, field)
+		if value, found := desired.Object[field]; found {
+			updated.Object[field] = value
+		}
+	}
+
+	// Keep the existing instance type when none was requested
+	if instanceType, _, _ := unstructured.NestedString(desired.Object, "resource", "instanceType"); instanceType == "" {
+		if current, found, _ := unstructured.NestedString(existing.Object, "resource", "instanceType"); found && current != "" {
+			if err := unstructured.SetNestedField(updated.Object, current, "resource", "instanceType"); err != nil {
+				return nil, fmt.Errorf("failed to preserve instance type: %w", err)
 			}
 		}
 	}
 
-	// Create workspace
-	workspace := o.buildWorkspace()
+	for _, field := range keep {
+		current, found, _ := unstructured.NestedFieldCopy(existing.Object, "resource", field)
+		if !found {
+			continue
+		}
+		if err := unstructured.SetNestedField(updated.Object, current, "resource", field); err != nil {
+			return nil, fmt.Errorf("failed to preserve resource.%s: %w", field, err)
+		}
+	}
 
-	klog.V(2).Infof("Creating workspace %s in namespace %s", o.WorkspaceName, o.Namespace)
+	labels := updated.GetLabels()
+	for key, value := range desired.GetLabels() {
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		labels[key] = value
+	}
+	updated.SetLabels(labels)
 
-	gvr := schema.GroupVersionResource{
-		Group:    "kaito.sh",
-		Version:  "v1beta1",
-		Resource: "workspaces",
+	annotations := updated.GetAnnotations()
+	for key, value := range desired.GetAnnotations() {
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[key] = value
 	}
+	updated.SetAnnotations(annotations)
 
-	_, err = dynamicClient.Resource(gvr).Namespace(o.Namespace).Create(
-		context.TODO(),
-		workspace,
-		metav1.CreateOptions{},
-	)
+	return updated, nil
+}
 
-	if err != nil {
-		if errors.IsAlreadyExists(err) {
-			fmt.Printf("✓ Workspace %s already exists\n", o.WorkspaceName)
-			return nil
+// workspacePreset returns the workspace mode (inference or tuning) and its preset model name
+func workspacePreset(workspace *unstructured.Unstructured) (string, string) {
+	for _, mode := range []string{"inference", "tuning"} {
+		if _, found := workspace.Object[mode]; found {
+			name, _, _ := unstructured.NestedString(workspace.Object, mode, "preset", "name")
+			return mode, name
 		}
-		klog.Errorf("Failed to create workspace: %v", err)
-		return fmt.Errorf("failed to create workspace: %w", err)
 	}
+	return "", ""
+}
 
-	fmt.Printf("✓ Workspace %s created successfully\n", o.WorkspaceName)
-	fmt.Printf("ℹ️  Use 'kubectl kaito status --workspace-name %s' to check status\n", o.WorkspaceName)
-	return nil
+// waitForWorkspaceReady watches the workspace until it is ready or the timeout elapses.
+// Inference workspaces need ResourceReady and InferenceReady, tuning workspaces need
+// ResourceReady and JobStarted.
+func (o *DeployOptions) waitForWorkspaceReady(ctx context.Context, dynamicClient dynamic.Interface) error {
+	return waitForResourceReady(ctx, dynamicClient, readinessTarget{
+		GVR: schema.GroupVersionResource{
+			Group:    "kaito.sh",
+			Version:  "v1beta1",
+			Resource: "workspaces",
+		},
+		Namespace:     o.Namespace,
+		Name:          o.WorkspaceName,
+		Kind:          "workspace",
+		StatusCommand: fmt.Sprintf("kubectl kaito status --workspace-name %s", o.WorkspaceName),
+		Timeout:       o.Timeout,
+		Phase:         o.readinessPhase,
+	})
+}
+
+// readinessTarget describes a Kaito resource to wait for
+type readinessTarget struct {
+	GVR       schema.GroupVersionResource
+	Namespace string
+	Name      string
+	// Kind names the resource in progress messages, e.g. "workspace" or "RAGEngine"
+	Kind string
+	// StatusCommand is suggested to the user when the wait times out
+	StatusCommand string
+	Timeout       time.Duration
+	// Phase describes what the resource is still waiting for, or "" when it is ready
+	Phase func(obj *unstructured.Unstructured) string
+}
+
+// waitForResourceReady watches the target resource until its Phase reports it
+// ready, the timeout elapses or ctx is cancelled, printing a line each time the
+// phase changes.
+func waitForResourceReady(ctx context.Context, dynamicClient dynamic.Interface, target readinessTarget) error {
+	klog.V(2).Infof("Waiting up to %s for %s %s to become ready", target.Timeout, target.Kind, target.Name)
+	printStatus(os.Stdout, "⏳ Waiting up to %s for %s %s to become ready...\n", target.Timeout, target.Kind, target.Name)
+
+	start := time.Now()
+	var mu sync.Mutex
+	lastPhase := ""
+
+	// Remind the user every 30s what the resource is still waiting for
+	progress := time.NewTicker(30 * time.Second)
+	defer progress.Stop()
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case <-progress.C:
+				mu.Lock()
+				fmt.Printf("   Still %s (%s elapsed)\n", lastPhase, time.Since(start).Round(time.Second))
+				mu.Unlock()
+			}
+		}
+	}()
+
+	ready := func(obj *unstructured.Unstructured) bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return reportReadiness(target, obj, &lastPhase, start)
+	}
+
+	_, err := waitForCondition(ctx, dynamicClient, target.GVR, target.Name, target.Namespace, ready, target.Timeout)
+	switch {
+	case err == nil:
+		return nil
+	case ctx.Err() != nil:
+		// Only the wait stops; the operator keeps reconciling the resource
+		printStatus(os.Stderr, "ℹ️  Stopped waiting; %s %s is still being deployed. Use '%s' to check on it\n",
+			target.Kind, target.Name, target.StatusCommand)
+		return fmt.Errorf("stopped waiting for %s %s: %w", target.Kind, target.Name, ctx.Err())
+	case isWaitTimeout(err):
+		return fmt.Errorf("timed out after %s waiting for %s %s to become ready; use '%s' to investigate",
+			target.Timeout, target.Kind, target.Name, target.StatusCommand)
+	default:
+		return fmt.Errorf("failed waiting for %s %s: %w", target.Kind, target.Name, err)
+	}
+}
+
+// reportReadiness prints a progress line when the readiness phase changes and
+// reports whether the resource is ready
+func reportReadiness(target readinessTarget, obj *unstructured.Unstructured, lastPhase *string, start time.Time) bool {
+	phase := target.Phase(obj)
+	if phase == "" {
+		printStatus(os.Stdout, "✓ %s %s is ready (took %s)\n", capitalizeFirst(target.Kind), target.Name, time.Since(start).Round(time.Second))
+		return true
+	}
+	if phase != *lastPhase {
+		printStatus(os.Stdout, "⏳ %s...\n", capitalizeFirst(phase))
+		*lastPhase = phase
+	}
+	return false
+}
+
+// readinessPhase describes what the workspace is still waiting for, or "" when it is ready
+func (o *DeployOptions) readinessPhase(workspace *unstructured.Unstructured) string {
+	conditions, _, _ := unstructured.NestedSlice(workspace.Object, "status", "conditions")
+	resourceReady, inferenceReady, _ := extractConditionStatuses(conditions)
+
+	switch {
+	case resourceReady != "True":
+		return "waiting for GPU nodes to be provisioned"
+	case o.Tuning && conditionStatus(conditions, "JobStarted") != "True":
+		return "waiting for the tuning job to start"
+	case !o.Tuning && inferenceReady != "True":
+		return "waiting for the model to be ready"
+	default:
+		return ""
+	}
 }
 
 // buildWorkspace creates a new Workspace object with the specified configuration
 func (o *DeployOptions) buildWorkspace() *unstructured.Unstructured {
 	klog.V(4).Info("Building workspace configuration")
 
+	// A --workspace-file manifest is applied as-is
+	if o.workspaceManifest != nil {
+		workspace := o.workspaceManifest.DeepCopy()
+		if workspace.GetNamespace() == "" {
+			workspace.SetNamespace(o.Namespace)
+		}
+		return workspace
+	}
+
 	// Create and initialize the workspace object
 	workspace := o.initWorkspaceObject()
 
@@ -342,9 +1592,44 @@ func (o *DeployOptions) initWorkspaceObject() *unstructured.Unstructured {
 	workspace.SetName(o.WorkspaceName)
 	workspace.SetNamespace(o.Namespace)
 
+	// The pairs were validated already
+	if labels, _ := parseMetadataPairs("label", o.Labels); len(labels) > 0 {
+		workspace.SetLabels(labels)
+	}
+	if annotations, _ := parseMetadataPairs("annotation", o.Annotations); len(annotations) > 0 {
+		workspace.SetAnnotations(annotations)
+	}
+
 	return workspace
 }
 
+// resourceSummary returns the dry-run summary lines for the workspace's resource
+// spec, named after the fields they show: resource.count, resource.instanceType,
+// resource.labelSelector.matchLabels and resource.preferredNodes
+func resourceSummary(workspace *unstructured.Unstructured) [][2]string {
+	var fields [][2]string
+
+	if count, found, _ := unstructured.NestedInt64(workspace.Object, "resource", "count"); found {
+		fields = append(fields, [2]string{"Count", strconv.FormatInt(count, 10)})
+	}
+	if instanceType, _, _ := unstructured.NestedString(workspace.Object, "resource", "instanceType"); instanceType != "" {
+		fields = append(fields, [2]string{"Instance Type", instanceType})
+	}
+	if matchLabels, _, _ := unstructured.NestedStringMap(workspace.Object, "resource", "labelSelector", "matchLabels"); len(matchLabels) > 0 {
+		labels := make([]string, 0, len(matchLabels))
+		for key, value := range matchLabels {
+			labels = append(labels, key+"="+value)
+		}
+		sort.Strings(labels)
+		fields = append(fields, [2]string{"Label Selector (matchLabels)", strings.Join(labels, ",")})
+	}
+	if nodes, _, _ := unstructured.NestedStringSlice(workspace.Object, "resource", "preferredNodes"); len(nodes) > 0 {
+		fields = append(fields, [2]string{"Preferred Nodes", strings.Join(nodes, ",")})
+	}
+
+	return fields
+}
+
 // setResourceConfig sets the resource configuration at the root level
 func (o *DeployOptions) setResourceConfig(workspace *unstructured.Unstructured) {
 	resource := map[string]interface{}{
@@ -361,7 +1646,20 @@ func (o *DeployOptions) setResourceConfig(workspace *unstructured.Unstructured)
 	}
 
 	if len(o.LabelSelector) > 0 {
-		resource["labelSelector"].(map[string]interface{})["matchLabels"] = o.LabelSelector
+		// Unstructured content must be JSON-compatible, so copy into map[string]interface{}
+		matchLabels := make(map[string]interface{}, len(o.LabelSelector))
+		for key, value := range o.LabelSelector {
+			matchLabels[key] = value
+		}
+		resource["labelSelector"].(map[string]interface{})["matchLabels"] = matchLabels
+	}
+
+	if len(o.PreferredNodes) > 0 {
+		nodes := make([]interface{}, len(o.PreferredNodes))
+		for i, node := range o.PreferredNodes {
+			nodes[i] = node
+		}
+		resource["preferredNodes"] = nodes
 	}
 
 	if err := unstructured.SetNestedField(workspace.Object, resource, "resource"); err != nil {
@@ -384,9 +1682,13 @@ func (o *DeployOptions) setTuningConfig(workspace *unstructured.Unstructured) {
 		}
 
 		if o.ModelImage != "" {
-			preset["presetOptions"] = map[string]interface{}{
+			presetOptions := map[string]interface{}{
 				"image": o.ModelImage,
 			}
+			if o.ModelImageSecret != "" {
+				presetOptions["imagePullSecrets"] = []interface{}{o.ModelImageSecret}
+			}
+			preset["presetOptions"] = presetOptions
 		}
 
 		tuning["preset"] = preset
@@ -428,7 +1730,14 @@ func (o *DeployOptions) setTuningConfig(workspace *unstructured.Unstructured) {
 
 	// Add tuning config if specified
 	if o.TuningConfig != "" {
-		tuning["config"] = o.TuningConfig
+		// Check if it's a file path
+		if _, statErr := os.Stat(o.TuningConfig); statErr == nil {
+			// Use the ConfigMap name that will be created
+			tuning["config"] = fmt.Sprintf("%s-tuning-config", o.WorkspaceName)
+		} else {
+			// Use the provided ConfigMap name directly
+			tuning["config"] = o.TuningConfig
+		}
 	}
 
 	if err := unstructured.SetNestedField(workspace.Object, tuning, "tuning"); err != nil {
@@ -446,20 +1755,46 @@ func (o *DeployOptions) setInferenceConfig(workspace *unstructured.Unstructured)
 			"name": o.Model,
 		}
 
-		if o.ModelAccessSecret != "" {
-			preset["presetOptions"] = map[string]interface{}{
-				"modelAccessSecret": o.ModelAccessSecret,
+		presetOptions := map[string]interface{}{}
+		if o.InferenceImage != "" {
+			presetOptions["image"] = o.InferenceImage
+		}
+		if secret := o.modelAccessSecret(); secret != "" {
+			presetOptions["modelAccessSecret"] = secret
+		}
+		// Validate rejects malformed --env values, so errors are not expected here
+		if envVars, _ := parseEnvVars(o.Env); len(envVars) > 0 {
+			env := make([]interface{}, len(envVars))
+			for i, envVar := range envVars {
+				env[i] = map[string]interface{}{"name": envVar.Name, "value": envVar.Value}
 			}
+			presetOptions["env"] = env
+		}
+		if len(presetOptions) > 0 {
+			preset["presetOptions"] = presetOptions
 		}
 
 		inference["preset"] = preset
 	}
 
 	// Add adapters if specified
-	if len(o.Adapters) > 0 {
-		adapters := make([]interface{}, len(o.Adapters))
-		for i, adapter := range o.Adapters {
-			adapters[i] = adapter
+	if len(o.Adapters) > 0 || o.AdaptersFile != "" {
+		specs := o.adapters
+		if specs == nil {
+			// Not validated (e.g. a direct buildWorkspace call), use the sources as given
+			for _, value := range o.Adapters {
+				if spec, err := parseAdapterSpec(value); err == nil {
+					specs = append(specs, spec)
+				}
+			}
+			if o.AdaptersFile != "" {
+				fileSpecs, _ := readAdaptersFile(o.AdaptersFile)
+				specs = append(specs, fileSpecs...)
+			}
+		}
+		adapters := make([]interface{}, 0, len(specs))
+		for _, spec := range specs {
+			adapters = append(adapters, spec.toWorkspaceAdapter())
 		}
 		inference["adapters"] = adapters
 	}
@@ -493,15 +1828,189 @@ func (o *DeployOptions) setLoadBalancerAnnotation(workspace *unstructured.Unstru
 	klog.V(4).Info("Added LoadBalancer annotation to workspace")
 }
 
-func createInferenceConfigMap(clientset kubernetes.Interface, configFile, workspaceName, namespace string) error {
+// checkSecretExists returns an error with a hint to create the secret when it is missing
+func checkSecretExists(ctx context.Context, clientset kubernetes.Interface, namespace, name string) error {
+	_, err := clientset.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err == nil {
+		return nil
+	}
+	if errors.IsNotFound(err) {
+		return fmt.Errorf("secret %s not found in namespace %s; create it with 'kubectl create secret docker-registry %s -n %s ...'",
+			name, namespace, name, namespace)
+	}
+	return fmt.Errorf("failed to get secret %s: %w", name, err)
+}
+
+// inferenceConfigSections are the top-level keys Kaito reads from an inference config file
+var inferenceConfigSections = []string{"max_probe_steps", "transformers", "vllm"}
+
+// checkInputURLs sends a HEAD request to each URL and returns an error listing
+// those that are unreachable or do not answer with a 2xx status
+func checkInputURLs(ctx context.Context, client *http.Client, urls []string) error {
+	var failures []string
+	for _, inputURL := range urls {
+		klog.V(3).Infof("Checking input URL %s", inputURL)
+		if err := checkInputURL(ctx, client, inputURL); err != nil {
+			failures = append(failures, fmt.Sprintf("  %s: %v", inputURL, err))
+		}
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("%d of %d input URL(s) failed the --validate-inputs check:\n%s",
+			len(failures), len(urls), strings.Join(failures, "\n"))
+	}
+	return nil
+}
+
+func checkInputURL(ctx context.Context, client *http.Client, inputURL string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, inputURL, nil)
+	if err != nil {
+		return fmt.Errorf("invalid URL: %w", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("unreachable: %w", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("HEAD returned %s", resp.Status)
+	}
+	return nil
+}
+
+// validateInferenceConfigFile reads an inference config file and checks it with
+// validateInferenceConfig, naming the file in the error
+func validateInferenceConfigFile(configFile string) error {
+	data, err := os.ReadFile(configFile)
+	if err != nil {
+		return fmt.Errorf("failed to read inference config file: %w", err)
+	}
+	if err := validateInferenceConfig(data); err != nil {
+		return fmt.Errorf("invalid inference config %s: %w", configFile, err)
+	}
+	return nil
+}
+
+// validateInferenceConfig checks that an inference config is a YAML mapping of
+// known sections and that the vllm options with well-known types have sensible
+// values. Errors name the line of the offending key where it can be found.
+func validateInferenceConfig(data []byte) error {
+	var config map[string]interface{}
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		// The parser reports the line, e.g. "yaml: line 3: mapping values are not allowed in this context"
+		return fmt.Errorf("not valid YAML: %s", strings.TrimPrefix(err.Error(), "error converting YAML to JSON: "))
+	}
+	if len(config) == 0 {
+		return fmt.Errorf("file is empty; expected a mapping with one of: %s", strings.Join(inferenceConfigSections, ", "))
+	}
+
+	keys := make([]string, 0, len(config))
+	for key := range config {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		at := yamlKeyPosition(data, key, false)
+		known := false
+		for _, section := range inferenceConfigSections {
+			if key == section {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return fmt.Errorf("%sunknown top-level key %q; expected one of: %s", at, key, strings.Join(inferenceConfigSections, ", "))
+		}
+
+		switch key {
+		case "max_probe_steps":
+			if value, ok := config[key].(float64); !ok || value < 1 || value != float64(int64(value)) {
+				return fmt.Errorf("%smax_probe_steps must be a positive integer", at)
+			}
+		case "vllm", "transformers":
+			options, ok := config[key].(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("%s%s must be a mapping of runtime options", at, key)
+			}
+			if key == "vllm" {
+				if err := validateVLLMOptions(data, options); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateVLLMOptions checks the types and ranges of common vllm options
+func validateVLLMOptions(data []byte, options map[string]interface{}) error {
+	checks := []struct {
+		key     string
+		valid   func(float64) bool
+		message string
+	}{
+		{"gpu-memory-utilization", func(v float64) bool { return v > 0 && v <= 1 }, "a number in (0, 1]"},
+		{"cpu-offload-gb", func(v float64) bool { return v >= 0 }, "a number of 0 or greater"},
+		{"swap-space", func(v float64) bool { return v >= 0 }, "a number of 0 or greater"},
+		{"max-model-len", func(v float64) bool { return v >= 1 && v == float64(int64(v)) }, "a positive integer"},
+	}
+
+	for _, check := range checks {
+		raw, found := options[check.key]
+		if !found {
+			continue
+		}
+		if value, ok := raw.(float64); !ok || !check.valid(value) {
+			return fmt.Errorf("%svllm.%s must be %s, got %v", yamlKeyPosition(data, check.key, true), check.key, check.message, raw)
+		}
+	}
+	return nil
+}
+
+// yamlKeyPosition returns "line N: " for the first line defining key, either at the
+// top level or indented below it, or "" when it cannot be found (e.g. flow style)
+func yamlKeyPosition(data []byte, key string, nested bool) string {
+	for i, line := range strings.Split(string(data), "\n") {
+		trimmed := strings.TrimLeft(line, " ")
+		indented := len(trimmed) < len(line)
+		if nested != indented {
+			continue
+		}
+		for _, prefix := range []string{key + ":", `"` + key + `":`, "'" + key + "':"} {
+			if strings.HasPrefix(trimmed, prefix) {
+				return fmt.Sprintf("line %d: ", i+1)
+			}
+		}
+	}
+	return ""
+}
+
+// createInferenceConfigMap creates or updates the <workspace>-inference-config ConfigMap
+// from a file. A non-nil owner is added to its owner references. An existing ConfigMap
+// with different content is only overwritten with force.
+func createInferenceConfigMap(ctx context.Context, clientset kubernetes.Interface, configFile, workspaceName, namespace string, owner *metav1.OwnerReference, force bool, dryRun []string) error {
+	return createConfigFileConfigMap(ctx, clientset, "inference", inferenceConfigKey, configFile, workspaceName, namespace, owner, force, dryRun)
+}
+
+// createTuningConfigMap creates or updates the <workspace>-tuning-config ConfigMap
+// from a file, the same way createInferenceConfigMap does for inference configs
+func createTuningConfigMap(ctx context.Context, clientset kubernetes.Interface, configFile, workspaceName, namespace string, owner *metav1.OwnerReference, force bool, dryRun []string) error {
+	return createConfigFileConfigMap(ctx, clientset, "tuning", tuningConfigKey, configFile, workspaceName, namespace, owner, force, dryRun)
+}
+
+// createConfigFileConfigMap creates or updates the <workspace>-<kind>-config ConfigMap
+// holding configFile under key
+func createConfigFileConfigMap(ctx context.Context, clientset kubernetes.Interface, kind, key, configFile, workspaceName, namespace string,
+	owner *metav1.OwnerReference, force bool, dryRun []string) error {
 	// Read the YAML file
 	yamlData, err := os.ReadFile(configFile)
 	if err != nil {
-		return fmt.Errorf("failed to read inference config file: %w", err)
+		return fmt.Errorf("failed to read %s config file: %w", kind, err)
 	}
 
 	// Create a ConfigMap name from the workspace name
-	configMapName := fmt.Sprintf("%s-inference-config", workspaceName)
+	configMapName := fmt.Sprintf("%s-%s-config", workspaceName, kind)
 
 	// Create the ConfigMap
 	configMap := &corev1.ConfigMap{
@@ -510,18 +2019,41 @@ func createInferenceConfigMap(clientset kubernetes.Interface, configFile, worksp
 			Namespace: namespace,
 		},
 		Data: map[string]string{
-			"inference_config.yaml": string(yamlData),
+			key: string(yamlData),
 		},
 	}
+	if owner != nil {
+		configMap.OwnerReferences = []metav1.OwnerReference{*owner}
+	}
 
 	// Create the ConfigMap
-	_, err = clientset.CoreV1().ConfigMaps(namespace).Create(context.TODO(), configMap, metav1.CreateOptions{})
+	_, err = clientset.CoreV1().ConfigMaps(namespace).Create(ctx, configMap, metav1.CreateOptions{DryRun: dryRun})
 	if err != nil {
 		if !errors.IsAlreadyExists(err) {
 			return fmt.Errorf("failed to create ConfigMap: %w", err)
 		}
-		// If it already exists, update it
-		_, err = clientset.CoreV1().ConfigMaps(namespace).Update(context.TODO(), configMap, metav1.UpdateOptions{})
+		// If it already exists, update its data and make sure the workspace owns it
+		existing, err := clientset.CoreV1().ConfigMaps(namespace).Get(ctx, configMapName, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to get ConfigMap: %w", err)
+		}
+		needsOwner := owner != nil && !hasOwnerReference(existing.OwnerReferences, owner.UID)
+		current, hasConfig := existing.Data[key]
+		if hasConfig && current == string(yamlData) {
+			if !needsOwner {
+				klog.V(2).Infof("ConfigMap %s is up to date", configMapName)
+				return nil
+			}
+		} else if hasConfig && !force {
+			return fmt.Errorf("ConfigMap %s already exists with a different %s:\n%s\nuse --force to overwrite it",
+				configMapName, key, lineDiff(current, string(yamlData)))
+		}
+
+		existing.Data = configMap.Data
+		if needsOwner {
+			existing.OwnerReferences = append(existing.OwnerReferences, *owner)
+		}
+		_, err = clientset.CoreV1().ConfigMaps(namespace).Update(ctx, existing, metav1.UpdateOptions{DryRun: dryRun})
 		if err != nil {
 			return fmt.Errorf("failed to update ConfigMap: %w", err)
 		}
@@ -530,23 +2062,174 @@ func createInferenceConfigMap(clientset kubernetes.Interface, configFile, worksp
 	return nil
 }
 
+// createHFTokenSecret creates or updates the <workspace>-hf-token secret holding token.
+// A non-nil owner is added to its owner references. An existing secret with a
+// different token is only overwritten with force.
+func createHFTokenSecret(ctx context.Context, clientset kubernetes.Interface, token, workspaceName, namespace string,
+	owner *metav1.OwnerReference, force bool, dryRun []string) error {
+	name := hfTokenSecretName(workspaceName)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{hfTokenKey: []byte(token)},
+	}
+	if owner != nil {
+		secret.OwnerReferences = []metav1.OwnerReference{*owner}
+	}
+
+	_, err := clientset.CoreV1().Secrets(namespace).Create(ctx, secret, metav1.CreateOptions{DryRun: dryRun})
+	if err == nil {
+		return nil
+	}
+	if !errors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create secret: %w", err)
+	}
+
+	// If it already exists, update its token and make sure the workspace owns it
+	existing, err := clientset.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get secret: %w", err)
+	}
+	needsOwner := owner != nil && !hasOwnerReference(existing.OwnerReferences, owner.UID)
+	current, hasToken := existing.Data[hfTokenKey]
+	if hasToken && string(current) == token {
+		if !needsOwner {
+			klog.V(2).Infof("Secret %s is up to date", name)
+			return nil
+		}
+	} else if !force {
+		return fmt.Errorf("secret %s already exists with a different %s; use --force to overwrite it", name, hfTokenKey)
+	}
+
+	if existing.Data == nil {
+		existing.Data = map[string][]byte{}
+	}
+	existing.Data[hfTokenKey] = []byte(token)
+	if needsOwner {
+		existing.OwnerReferences = append(existing.OwnerReferences, *owner)
+	}
+	if _, err := clientset.CoreV1().Secrets(namespace).Update(ctx, existing, metav1.UpdateOptions{DryRun: dryRun}); err != nil {
+		return fmt.Errorf("failed to update secret: %w", err)
+	}
+	return nil
+}
+
+// lineDiff returns the lines removed from old ("- ") and added in new ("+ "),
+// with unchanged lines indented for context
+func lineDiff(old, new string) string {
+	a := strings.Split(strings.TrimRight(old, "\n"), "\n")
+	b := strings.Split(strings.TrimRight(new, "\n"), "\n")
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var out strings.Builder
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			out.WriteString("  " + a[i] + "\n")
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			out.WriteString("- " + a[i] + "\n")
+			i++
+		default:
+			out.WriteString("+ " + b[j] + "\n")
+			j++
+		}
+	}
+	return strings.TrimRight(out.String(), "\n")
+}
+
+func hasOwnerReference(refs []metav1.OwnerReference, uid types.UID) bool {
+	for _, ref := range refs {
+		if ref.UID == uid {
+			return true
+		}
+	}
+	return false
+}
+
+// writeWorkspaceYAML writes the workspace manifest to --output-yaml, or to stdout for '-'
+func (o *DeployOptions) writeWorkspaceYAML() error {
+	yamlData, err := yaml.Marshal(o.buildWorkspace().Object)
+	if err != nil {
+		return fmt.Errorf("failed to marshal workspace to YAML: %w", err)
+	}
+
+	if o.OutputYAML == "-" {
+		fmt.Printf("%s", string(yamlData))
+	} else {
+		if err := os.WriteFile(o.OutputYAML, yamlData, 0o644); err != nil {
+			return fmt.Errorf("failed to write workspace YAML: %w", err)
+		}
+		printStatus(os.Stdout, "✓ Workspace YAML written to %s\n", o.OutputYAML)
+	}
+
+	// A file passed to --inference-config or --tuning-config becomes a ConfigMap that
+	// is not part of the manifest
+	if !o.Tuning && o.InferenceConfig != "" {
+		if _, statErr := os.Stat(o.InferenceConfig); statErr == nil {
+			printStatus(os.Stderr, "💡 The workspace references ConfigMap %s-inference-config, which is not included; create it from %s\n",
+				o.WorkspaceName, o.InferenceConfig)
+		}
+	}
+	if o.Tuning && o.TuningConfig != "" {
+		if _, statErr := os.Stat(o.TuningConfig); statErr == nil {
+			printStatus(os.Stderr, "💡 The workspace references ConfigMap %s-tuning-config, which is not included; create it from %s\n",
+				o.WorkspaceName, o.TuningConfig)
+		}
+	}
+	return nil
+}
+
 func (o *DeployOptions) showDryRun() error {
 	klog.V(2).Info("Running in dry-run mode")
 
-	fmt.Println("🔍 Dry-run mode: Showing what would be created")
+	// The summary is read back from the built workspace so it always matches the YAML below
+	workspace := o.buildWorkspace()
+
+	printStatus(os.Stdout, "🔍 Dry-run mode: Showing what would be created\n")
 	fmt.Println()
 	fmt.Println("Workspace Configuration:")
 	fmt.Println("========================")
 	fmt.Printf("Name: %s\n", o.WorkspaceName)
 	fmt.Printf("Namespace: %s\n", o.Namespace)
 	fmt.Printf("Model: %s\n", o.Model)
-	fmt.Printf("Count: %d\n", o.Count)
-
-	if o.InstanceType != "" {
-		fmt.Printf("Instance Type: %s\n", o.InstanceType)
+	for _, field := range resourceSummary(workspace) {
+		fmt.Printf("%s: %s\n", field[0], field[1])
+	}
+	if len(o.Labels) > 0 {
+		fmt.Printf("Labels: %s\n", strings.Join(o.Labels, ", "))
+	}
+	if len(o.Annotations) > 0 {
+		fmt.Printf("Annotations: %s\n", strings.Join(o.Annotations, ", "))
 	}
 
-	if o.Tuning {
+	switch {
+	case o.workspaceManifest != nil:
+		mode := "Inference"
+		if o.Tuning {
+			mode = "Fine-tuning"
+		}
+		fmt.Printf("Mode: %s (from %s)\n", mode, o.WorkspaceFile)
+	case o.Tuning:
 		fmt.Printf("Mode: Fine-tuning (%s)\n", o.TuningMethod)
 		if len(o.InputURLs) > 0 {
 			fmt.Printf("Input URLs: %v\n", o.InputURLs)
@@ -566,14 +2249,22 @@ func (o *DeployOptions) showDryRun() error {
 		if o.TuningConfig != "" {
 			fmt.Printf("Tuning Config: %s\n", o.TuningConfig)
 		}
-	} else {
+	default:
 		fmt.Println("Mode: Inference")
 		if len(o.Adapters) > 0 {
 			fmt.Printf("Adapters: %v\n", o.Adapters)
 		}
-		if o.ModelAccessSecret != "" {
+		if o.AdaptersFile != "" {
+			fmt.Printf("Adapters File: %s\n", o.AdaptersFile)
+		}
+		if o.hfToken != "" {
+			fmt.Printf("Model Access Secret: %s (created from the Hugging Face token)\n", o.modelAccessSecret())
+		} else if o.ModelAccessSecret != "" {
 			fmt.Printf("Model Access Secret: %s\n", o.ModelAccessSecret)
 		}
+		if len(o.Env) > 0 {
+			fmt.Printf("Environment: %s\n", strings.Join(o.Env, ", "))
+		}
 		if o.InferenceConfig != "" {
 			fmt.Printf("Inference Config: %s\n", o.InferenceConfig)
 		}
@@ -582,15 +2273,12 @@ func (o *DeployOptions) showDryRun() error {
 		}
 	}
 
-	if len(o.LabelSelector) > 0 {
-		fmt.Printf("Label Selector: %v\n", o.LabelSelector)
+	if len(o.PreferredNodes) > 0 && len(o.LabelSelector) == 0 {
+		printStatus(os.Stdout, "💡 Preferred nodes must carry the label kaito.sh/workspace=%s, or use --node-selector to match them\n", o.WorkspaceName)
 	}
 
 	fmt.Println()
-	fmt.Println("✓ Workspace definition is valid")
-
-	// Also show the actual workspace YAML that would be created
-	workspace := o.buildWorkspace()
+	printStatus(os.Stdout, "✓ Workspace definition is valid\n")
 
 	// Convert to YAML for display
 	yamlData, err := yaml.Marshal(workspace.Object)
@@ -603,7 +2291,7 @@ func (o *DeployOptions) showDryRun() error {
 		fmt.Printf("%s", string(yamlData))
 	}
 
-	fmt.Println("ℹ️  Run without --dry-run to create the workspace")
+	printStatus(os.Stdout, "ℹ️  Run without --dry-run to create the workspace\n")
 
 	return nil
 }
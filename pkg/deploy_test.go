@@ -17,16 +17,31 @@ limitations under the License.
 package cmd
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
+	"unicode"
 
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
 	"k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/yaml"
 )
 
 func TestDeployCmd(t *testing.T) {
@@ -57,6 +72,21 @@ func TestDeployCmd(t *testing.T) {
 
 		modelFlag := flags.Lookup("model")
 		assert.NotNil(t, modelFlag)
+
+		namespaceFlag := flags.Lookup("namespace")
+		assert.NotNil(t, namespaceFlag)
+		assert.Equal(t, "n", namespaceFlag.Shorthand)
+	})
+
+	t.Run("Namespace flag takes precedence over the context", func(t *testing.T) {
+		namespace := "from-context"
+		configFlags := genericclioptions.NewConfigFlags(true)
+		configFlags.Namespace = &namespace
+		cmd := NewDeployCmd(configFlags)
+		assert.NoError(t, cmd.Flags().Parse([]string{"-n", "team-a"}))
+
+		o := &DeployOptions{configFlags: configFlags, Namespace: cmd.Flags().Lookup("namespace").Value.String()}
+		assert.Equal(t, "team-a", resolveNamespace(o.configFlags, o.Namespace))
 	})
 }
 
@@ -81,6 +111,34 @@ func TestDeployOptionsValidation(t *testing.T) {
 			},
 			expectError: true,
 		},
+		{
+			name: "Preferred nodes",
+			options: DeployOptions{
+				WorkspaceName:  "test-workspace",
+				Model:          "phi-3.5-mini-instruct",
+				PreferredNodes: []string{"node-1", "node-2"},
+			},
+			expectError: false,
+		},
+		{
+			name: "Duplicate preferred nodes",
+			options: DeployOptions{
+				WorkspaceName:  "test-workspace",
+				Model:          "phi-3.5-mini-instruct",
+				PreferredNodes: []string{"node-1", "node-1"},
+			},
+			expectError: true,
+		},
+		{
+			name: "Preferred nodes excluded by hostname node selector",
+			options: DeployOptions{
+				WorkspaceName:  "test-workspace",
+				Model:          "phi-3.5-mini-instruct",
+				PreferredNodes: []string{"node-1"},
+				LabelSelector:  map[string]string{"kubernetes.io/hostname": "node-2"},
+			},
+			expectError: true,
+		},
 		{
 			name: "Missing model",
 			options: DeployOptions{
@@ -100,6 +158,18 @@ func TestDeployOptionsValidation(t *testing.T) {
 			},
 			expectError: false,
 		},
+		{
+			name: "Tuning mode with misspelled tuning method",
+			options: DeployOptions{
+				WorkspaceName: "test-workspace",
+				Model:         "phi-3.5-mini-instruct",
+				Tuning:        true,
+				TuningMethod:  "qlova",
+				InputURLs:     []string{"https://example.com/data.parquet"},
+				OutputImage:   "myregistry/model:latest",
+			},
+			expectError: true,
+		},
 		{
 			name: "Tuning mode with model image",
 			options: DeployOptions{
@@ -163,13 +233,34 @@ func TestDeployOptionsValidation(t *testing.T) {
 			},
 			expectError: false,
 		},
+		{
+			name: "Inference mode with inference image",
+			options: DeployOptions{
+				WorkspaceName:  "test-workspace",
+				Model:          "phi-3.5-mini-instruct",
+				InferenceImage: "myregistry/runtime:0.2.1",
+			},
+			expectError: false,
+		},
+		{
+			name: "Tuning mode with inference image - should fail",
+			options: DeployOptions{
+				WorkspaceName:  "test-workspace",
+				Model:          "phi-3.5-mini-instruct",
+				Tuning:         true,
+				InferenceImage: "myregistry/runtime:0.2.1",
+				InputURLs:      []string{"https://example.com/data.parquet"},
+				OutputImage:    "myregistry/model:latest",
+			},
+			expectError: true,
+		},
 		{
 			name: "Inference mode with valid inference flags",
 			options: DeployOptions{
 				WorkspaceName:     "test-workspace",
 				Model:             "phi-3.5-mini-instruct",
 				ModelAccessSecret: "my-secret",
-				Adapters:          []string{"adapter1", "adapter2"},
+				Adapters:          []string{"adapter1=myregistry/adapter1:v1", "adapter2=myregistry/adapter2:v1:0.5"},
 			},
 			expectError: false,
 		},
@@ -320,7 +411,7 @@ func TestCreateInferenceConfigMap(t *testing.T) {
 			clientset := fake.NewSimpleClientset()
 
 			// Create the ConfigMap
-			err = createInferenceConfigMap(clientset, tt.options.InferenceConfig, tt.options.WorkspaceName, tt.options.Namespace)
+			err = createInferenceConfigMap(context.TODO(), clientset, tt.options.InferenceConfig, tt.options.WorkspaceName, tt.options.Namespace, nil, false, nil)
 
 			if tt.expectError {
 				assert.Error(t, err)
@@ -336,6 +427,77 @@ func TestCreateInferenceConfigMap(t *testing.T) {
 	}
 }
 
+func TestCreateTuningConfigMap(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "training_config.yaml")
+	config := "training_config:\n  TrainingArguments:\n    num_train_epochs: 2\n"
+	assert.NoError(t, os.WriteFile(configFile, []byte(config), 0o644))
+
+	t.Run("Creates the ConfigMap", func(t *testing.T) {
+		clientset := fake.NewSimpleClientset()
+		owner := &metav1.OwnerReference{APIVersion: "kaito.sh/v1beta1", Kind: "Workspace", Name: "tune-ws", UID: "ws-uid"}
+		assert.NoError(t, createTuningConfigMap(context.TODO(), clientset, configFile, "tune-ws", "default", owner, false, nil))
+
+		configMap, err := clientset.CoreV1().ConfigMaps("default").Get(context.TODO(), "tune-ws-tuning-config", metav1.GetOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, config, configMap.Data[tuningConfigKey])
+		assert.Equal(t, []metav1.OwnerReference{*owner}, configMap.OwnerReferences)
+	})
+
+	t.Run("Different content needs force", func(t *testing.T) {
+		clientset := fake.NewSimpleClientset(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "tune-ws-tuning-config", Namespace: "default"},
+			Data:       map[string]string{tuningConfigKey: "old: true\n"},
+		})
+		err := createTuningConfigMap(context.TODO(), clientset, configFile, "tune-ws", "default", nil, false, nil)
+		assert.ErrorContains(t, err, "ConfigMap tune-ws-tuning-config already exists with a different training_config.yaml")
+		assert.ErrorContains(t, err, "- old: true")
+
+		assert.NoError(t, createTuningConfigMap(context.TODO(), clientset, configFile, "tune-ws", "default", nil, true, nil))
+	})
+
+	t.Run("Non-existent file", func(t *testing.T) {
+		err := createTuningConfigMap(context.TODO(), fake.NewSimpleClientset(), "testdata/nonexistent.yaml", "tune-ws", "default", nil, false, nil)
+		assert.ErrorContains(t, err, "failed to read tuning config file")
+	})
+}
+
+func TestBuildWorkspaceWithTuningConfig(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "training_config.yaml")
+	assert.NoError(t, os.WriteFile(configFile, []byte("training_config: {}\n"), 0o644))
+
+	for _, tt := range []struct {
+		tuningConfig string
+		expected     string
+	}{
+		{tuningConfig: configFile, expected: "tune-ws-tuning-config"},
+		{tuningConfig: "my-tuning-config", expected: "my-tuning-config"},
+	} {
+		o := &DeployOptions{
+			WorkspaceName: "tune-ws",
+			Model:         "phi-3.5-mini-instruct",
+			Tuning:        true,
+			TuningConfig:  tt.tuningConfig,
+		}
+		config, _, _ := unstructured.NestedString(o.buildWorkspace().Object, "tuning", "config")
+		assert.Equal(t, tt.expected, config)
+	}
+}
+
+func TestBuildWorkspaceWithInferenceImage(t *testing.T) {
+	o := &DeployOptions{
+		WorkspaceName:     "test-workspace",
+		Model:             "phi-3.5-mini-instruct",
+		InferenceImage:    "myregistry/runtime:0.2.1",
+		ModelAccessSecret: "hf-token",
+	}
+	workspace := o.buildWorkspace()
+
+	image, _, _ := unstructured.NestedString(workspace.Object, "inference", "preset", "presetOptions", "image")
+	assert.Equal(t, "myregistry/runtime:0.2.1", image)
+	secret, _, _ := unstructured.NestedString(workspace.Object, "inference", "preset", "presetOptions", "modelAccessSecret")
+	assert.Equal(t, "hf-token", secret, "other preset options are kept")
+}
+
 func TestBuildWorkspaceWithModelImage(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -728,3 +890,1316 @@ func TestBuildWorkspaceStructure(t *testing.T) {
 		})
 	}
 }
+
+func newTestWorkspace(name, namespace string, conditions map[string]string) *unstructured.Unstructured {
+	conditionList := []interface{}{}
+	for condType, status := range conditions {
+		conditionList = append(conditionList, map[string]interface{}{
+			"type":   condType,
+			"status": status,
+		})
+	}
+
+	workspace := &unstructured.Unstructured{Object: map[string]interface{}{
+		"status": map[string]interface{}{
+			"conditions": conditionList,
+		},
+	}}
+	workspace.SetAPIVersion("kaito.sh/v1beta1")
+	workspace.SetKind("Workspace")
+	workspace.SetName(name)
+	workspace.SetNamespace(namespace)
+	return workspace
+}
+
+func newFakeDynamicClient(objects ...runtime.Object) *dynamicfake.FakeDynamicClient {
+	return dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			{Group: "kaito.sh", Version: "v1beta1", Resource: "workspaces"}: "WorkspaceList",
+		}, objects...)
+}
+
+func TestWaitForWorkspaceReady(t *testing.T) {
+	t.Run("Ready workspace returns immediately", func(t *testing.T) {
+		client := newFakeDynamicClient(newTestWorkspace("ready-ws", "default", map[string]string{
+			"ResourceReady":  "True",
+			"InferenceReady": "True",
+		}))
+		o := &DeployOptions{WorkspaceName: "ready-ws", Namespace: "default", Timeout: time.Second}
+
+		assert.NoError(t, o.waitForWorkspaceReady(context.TODO(), client))
+	})
+
+	t.Run("Not ready workspace times out", func(t *testing.T) {
+		client := newFakeDynamicClient(newTestWorkspace("pending-ws", "default", map[string]string{
+			"ResourceReady": "False",
+		}))
+		o := &DeployOptions{WorkspaceName: "pending-ws", Namespace: "default", Timeout: 100 * time.Millisecond}
+
+		err := o.waitForWorkspaceReady(context.TODO(), client)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "timed out")
+	})
+
+	t.Run("Missing workspace fails", func(t *testing.T) {
+		o := &DeployOptions{WorkspaceName: "missing", Namespace: "default", Timeout: time.Second}
+		assert.Error(t, o.waitForWorkspaceReady(context.TODO(), newFakeDynamicClient()))
+	})
+
+	t.Run("Cancelled context stops waiting", func(t *testing.T) {
+		client := newFakeDynamicClient(newTestWorkspace("pending-ws", "default", map[string]string{
+			"ResourceReady": "False",
+		}))
+		o := &DeployOptions{WorkspaceName: "pending-ws", Namespace: "default", Timeout: time.Minute}
+
+		ctx, cancel := context.WithCancel(context.TODO())
+		cancel()
+		err := o.waitForWorkspaceReady(ctx, client)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.ErrorContains(t, err, "stopped waiting for workspace pending-ws")
+	})
+}
+
+func TestReadinessPhase(t *testing.T) {
+	tests := []struct {
+		name       string
+		tuning     bool
+		conditions map[string]string
+		expected   string
+	}{
+		{"No conditions", false, map[string]string{}, "waiting for GPU nodes to be provisioned"},
+		{"Resource ready only", false, map[string]string{"ResourceReady": "True"}, "waiting for the model to be ready"},
+		{"Inference ready", false, map[string]string{"ResourceReady": "True", "InferenceReady": "True"}, ""},
+		{"Tuning job pending", true, map[string]string{"ResourceReady": "True"}, "waiting for the tuning job to start"},
+		{"Tuning job started", true, map[string]string{"ResourceReady": "True", "JobStarted": "True"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &DeployOptions{Tuning: tt.tuning}
+			assert.Equal(t, tt.expected, o.readinessPhase(newTestWorkspace("ws", "default", tt.conditions)))
+		})
+	}
+}
+
+func TestUpdateWorkspace(t *testing.T) {
+	gvr := schema.GroupVersionResource{Group: "kaito.sh", Version: "v1beta1", Resource: "workspaces"}
+
+	newExisting := func() *unstructured.Unstructured {
+		existing := newTestWorkspace("my-ws", "default", map[string]string{"ResourceReady": "True"})
+		existing.Object["resource"] = map[string]interface{}{
+			"instanceType": "Standard_NC12s_v3",
+			"count":        int64(1),
+		}
+		existing.Object["inference"] = map[string]interface{}{
+			"preset": map[string]interface{}{"name": "phi-4"},
+		}
+		return existing
+	}
+
+	t.Run("Applies new spec and keeps status", func(t *testing.T) {
+		client := newFakeDynamicClient(newExisting())
+		o := &DeployOptions{WorkspaceName: "my-ws", Namespace: "default", Model: "phi-4", Count: 3}
+
+		_, err := o.updateWorkspace(context.TODO(), client, o.buildWorkspace())
+		assert.NoError(t, err)
+
+		updated, err := client.Resource(gvr).Namespace("default").Get(context.TODO(), "my-ws", metav1.GetOptions{})
+		assert.NoError(t, err)
+
+		count, _, _ := unstructured.NestedInt64(updated.Object, "resource", "count")
+		assert.Equal(t, int64(3), count)
+		instanceType, _, _ := unstructured.NestedString(updated.Object, "resource", "instanceType")
+		assert.Equal(t, "Standard_NC12s_v3", instanceType, "instance type should be preserved when not set")
+		_, hasStatus := updated.Object["status"]
+		assert.True(t, hasStatus, "status should be preserved")
+	})
+
+	t.Run("--update asks before changing an existing workspace", func(t *testing.T) {
+		o := &DeployOptions{WorkspaceName: "my-ws", Namespace: "default", Model: "phi-4", Count: 3, Update: true}
+
+		restore := stubConfirm(t, "", false)
+		_, _, err := o.applyWorkspace(context.TODO(), newFakeDynamicClient(newExisting()), o.buildWorkspace())
+		restore()
+		assert.ErrorContains(t, err, "refusing to update existing workspace my-ws in namespace default without confirmation")
+
+		restore = stubConfirm(t, "y\n", true)
+		client := newFakeDynamicClient(newExisting())
+		_, _, err = o.applyWorkspace(context.TODO(), client, o.buildWorkspace())
+		restore()
+		assert.NoError(t, err)
+		updated, err := client.Resource(gvr).Namespace("default").Get(context.TODO(), "my-ws", metav1.GetOptions{})
+		assert.NoError(t, err)
+		count, _, _ := unstructured.NestedInt64(updated.Object, "resource", "count")
+		assert.Equal(t, int64(3), count)
+
+		o.Yes = true
+		defer stubConfirm(t, "", false)()
+		_, _, err = o.applyWorkspace(context.TODO(), newFakeDynamicClient(newExisting()), o.buildWorkspace())
+		assert.NoError(t, err)
+	})
+
+	t.Run("Keeps the node selection unless it is given", func(t *testing.T) {
+		newSelected := func() *unstructured.Unstructured {
+			existing := newExisting()
+			resource := existing.Object["resource"].(map[string]interface{})
+			resource["labelSelector"] = map[string]interface{}{"matchLabels": map[string]interface{}{"apps": "llm"}}
+			resource["preferredNodes"] = []interface{}{"gpu-node-1"}
+			return existing
+		}
+		getResource := func(client *dynamicfake.FakeDynamicClient) map[string]interface{} {
+			updated, err := client.Resource(gvr).Namespace("default").Get(context.TODO(), "my-ws", metav1.GetOptions{})
+			assert.NoError(t, err)
+			return updated.Object["resource"].(map[string]interface{})
+		}
+
+		client := newFakeDynamicClient(newSelected())
+		o := &DeployOptions{WorkspaceName: "my-ws", Namespace: "default", Model: "phi-4", Count: 2}
+		_, err := o.updateWorkspace(context.TODO(), client, o.buildWorkspace())
+		assert.NoError(t, err)
+		resource := getResource(client)
+		assert.Equal(t, int64(2), resource["count"])
+		assert.Equal(t, map[string]interface{}{"matchLabels": map[string]interface{}{"apps": "llm"}}, resource["labelSelector"])
+		assert.Equal(t, []interface{}{"gpu-node-1"}, resource["preferredNodes"])
+
+		client = newFakeDynamicClient(newSelected())
+		o = &DeployOptions{WorkspaceName: "my-ws", Namespace: "default", Model: "phi-4", Count: 2,
+			LabelSelector: map[string]string{"pool": "a100"}, PreferredNodes: []string{"gpu-node-2"}}
+		_, err = o.updateWorkspace(context.TODO(), client, o.buildWorkspace())
+		assert.NoError(t, err)
+		resource = getResource(client)
+		assert.Equal(t, map[string]interface{}{"matchLabels": map[string]interface{}{"pool": "a100"}}, resource["labelSelector"])
+		assert.Equal(t, []interface{}{"gpu-node-2"}, resource["preferredNodes"])
+	})
+
+	t.Run("Rejects model preset change", func(t *testing.T) {
+		o := &DeployOptions{WorkspaceName: "my-ws", Namespace: "default", Model: "phi-3.5-mini-instruct", Count: 1}
+
+		_, err := o.updateWorkspace(context.TODO(), newFakeDynamicClient(newExisting()), o.buildWorkspace())
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "immutable")
+	})
+
+	t.Run("Rejects mode change", func(t *testing.T) {
+		o := &DeployOptions{WorkspaceName: "my-ws", Namespace: "default", Model: "phi-4", Tuning: true, InputPVC: "data", OutputPVC: "out"}
+
+		_, err := o.updateWorkspace(context.TODO(), newFakeDynamicClient(newExisting()), o.buildWorkspace())
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "from inference to tuning")
+	})
+
+	t.Run("Existing workspace without --update keeps its config", func(t *testing.T) {
+		configFile := filepath.Join(t.TempDir(), "inference.yaml")
+		assert.NoError(t, os.WriteFile(configFile, []byte("vllm:\n  max-model-len: 4096\n"), 0o600))
+		clientset := fake.NewSimpleClientset(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-ws-inference-config", Namespace: "default"},
+			Data:       map[string]string{inferenceConfigKey: "original"},
+		})
+		o := &DeployOptions{
+			configFlags:     genericclioptions.NewConfigFlags(true),
+			clients:         &clientFactory{dynamicClient: newFakeDynamicClient(newExisting()), clientset: clientset},
+			WorkspaceName:   "my-ws",
+			Namespace:       "default",
+			Model:           "phi-4",
+			Count:           1,
+			InferenceConfig: configFile,
+			Force:           true,
+		}
+		assert.NoError(t, o.Run(context.TODO()))
+
+		configMap, err := clientset.CoreV1().ConfigMaps("default").Get(context.TODO(), "my-ws-inference-config", metav1.GetOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, "original", configMap.Data[inferenceConfigKey])
+		assert.Empty(t, configMap.OwnerReferences)
+	})
+}
+
+func TestBuildWorkspaceWithPreferredNodes(t *testing.T) {
+	o := &DeployOptions{
+		WorkspaceName:  "test-workspace",
+		Namespace:      "default",
+		Model:          "phi-3.5-mini-instruct",
+		Count:          2,
+		PreferredNodes: []string{"node-1", "node-2"},
+	}
+
+	workspace := o.buildWorkspace()
+	nodes, found, err := unstructured.NestedStringSlice(workspace.Object, "resource", "preferredNodes")
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, []string{"node-1", "node-2"}, nodes)
+
+	o.PreferredNodes = nil
+	_, found, _ = unstructured.NestedStringSlice(o.buildWorkspace().Object, "resource", "preferredNodes")
+	assert.False(t, found, "preferredNodes should be omitted when not set")
+}
+
+func TestResourceSummaryMatchesWorkspace(t *testing.T) {
+	o := &DeployOptions{
+		WorkspaceName:  "test-workspace",
+		Namespace:      "default",
+		Model:          "phi-3.5-mini-instruct",
+		InstanceType:   "Standard_NC6s_v3",
+		Count:          2,
+		LabelSelector:  map[string]string{"pool": "gpu", "apps": "llm"},
+		PreferredNodes: []string{"node-1", "node-2"},
+	}
+
+	workspace := o.buildWorkspace()
+	matchLabels, found, err := unstructured.NestedStringMap(workspace.Object, "resource", "labelSelector", "matchLabels")
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, o.LabelSelector, matchLabels)
+
+	assert.Equal(t, [][2]string{
+		{"Count", "2"},
+		{"Instance Type", "Standard_NC6s_v3"},
+		{"Label Selector (matchLabels)", "apps=llm,pool=gpu"},
+		{"Preferred Nodes", "node-1,node-2"},
+	}, resourceSummary(workspace))
+
+	// Without --node-selector the summary shows the default selector the workspace gets
+	o.LabelSelector = nil
+	o.PreferredNodes = nil
+	o.InstanceType = ""
+	assert.Equal(t, [][2]string{
+		{"Count", "2"},
+		{"Label Selector (matchLabels)", "kaito.sh/workspace=test-workspace"},
+	}, resourceSummary(o.buildWorkspace()))
+}
+
+func TestCheckInstanceType(t *testing.T) {
+	models := []Model{
+		{Name: "big-model", GPUMemory: "80Gi", InstanceType: "Standard_NC24ads_A100_v4"},
+		{Name: "no-metadata"},
+	}
+
+	tests := []struct {
+		name         string
+		model        string
+		instanceType string
+		count        int
+		strict       bool
+		expectError  bool
+	}{
+		{"Sufficient memory", "big-model", "Standard_NC24ads_A100_v4", 1, true, false},
+		{"Insufficient memory warns", "big-model", "Standard_NC6s_v3", 1, false, false},
+		{"Insufficient memory fails with strict", "big-model", "Standard_NC6s_v3", 1, true, true},
+		{"Enough memory across nodes", "big-model", "Standard_NC24s_v3", 2, true, false},
+		{"Unknown instance type is skipped", "big-model", "Standard_Custom_GPU", 1, true, false},
+		{"Missing GPU memory is skipped", "no-metadata", "Standard_NC6s_v3", 1, true, false},
+		{"Unknown model is skipped", "other-model", "Standard_NC6s_v3", 1, true, false},
+		{"No instance type is skipped", "big-model", "", 1, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &DeployOptions{Model: tt.model, InstanceType: tt.instanceType, Count: tt.count, Strict: tt.strict}
+			err := o.checkInstanceType(models)
+			if tt.expectError {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), "Standard_NC24ads_A100_v4")
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestApplyModelDefaults(t *testing.T) {
+	models := []Model{
+		{Name: "big-model", GPUMemory: "80Gi", InstanceType: "Standard_NC24ads_A100_v4"},
+		{Name: "no-metadata"},
+	}
+
+	o := &DeployOptions{Model: "big-model"}
+	o.applyModelDefaults(models)
+	assert.Equal(t, "Standard_NC24ads_A100_v4", o.InstanceType)
+
+	for _, name := range []string{"no-metadata", "other-model"} {
+		o := &DeployOptions{Model: name}
+		o.applyModelDefaults(models)
+		assert.Empty(t, o.InstanceType, name)
+	}
+
+	t.Run("An explicit instance type is kept", func(t *testing.T) {
+		o := &DeployOptions{WorkspaceName: "ws", Model: "phi-3.5-mini-instruct", Count: 1,
+			InstanceType: "Standard_NC6s_v3", FromModelDefaults: true}
+		assert.NoError(t, o.Validate())
+		assert.Equal(t, "Standard_NC6s_v3", o.InstanceType)
+	})
+}
+
+func TestDeployValidateLoadsCatalogOnce(t *testing.T) {
+	origBackoff, origURL := modelsFetchBackoff, modelsURL
+	defer func() { modelsFetchBackoff, modelsURL = origBackoff, origURL }()
+	modelsFetchBackoff = time.Millisecond
+	t.Setenv("HOME", t.TempDir())
+
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	modelsURL = server.URL
+
+	o := &DeployOptions{WorkspaceName: "ws", Model: "phi-3.5-mini-instruct", Count: 1,
+		FromModelDefaults: true, Adapters: []string{"custom=myregistry/custom:v1"}}
+	assert.NoError(t, o.Validate())
+	assert.Equal(t, int32(maxModelsFetchAttempts), atomic.LoadInt32(&calls), "the unreachable catalog is fetched once")
+}
+
+func TestParseGPUMemoryGiB(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected float64
+		ok       bool
+	}{
+		{"16Gi", 16, true},
+		{"16GB", 16, true},
+		{"24", 24, true},
+		{"", 0, false},
+		{"B", 0, false},
+		{"lots", 0, false},
+	}
+
+	for _, tt := range tests {
+		memory, ok := parseGPUMemoryGiB(tt.value)
+		assert.Equal(t, tt.ok, ok, tt.value)
+		assert.InDelta(t, tt.expected, memory, 0.01, tt.value)
+	}
+}
+
+func TestDeployDryRunModes(t *testing.T) {
+	t.Run("Bare --dry-run means client", func(t *testing.T) {
+		cmd := NewDeployCmd(genericclioptions.NewConfigFlags(true))
+		assert.NoError(t, cmd.Flags().Parse([]string{"--dry-run"}))
+		assert.Equal(t, dryRunClient, cmd.Flags().Lookup("dry-run").Value.String())
+
+		assert.NoError(t, cmd.Flags().Parse([]string{"--dry-run=server"}))
+		assert.Equal(t, dryRunServer, cmd.Flags().Lookup("dry-run").Value.String())
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		base := DeployOptions{WorkspaceName: "test-workspace", Model: "phi-3.5-mini-instruct", Count: 1}
+
+		for _, mode := range []string{"", dryRunNone, dryRunClient, dryRunServer} {
+			o := base
+			o.DryRun = mode
+			assert.NoError(t, o.Validate(), mode)
+		}
+
+		invalid := base
+		invalid.DryRun = "true"
+		assert.Error(t, invalid.Validate())
+
+		withWait := base
+		withWait.DryRun = dryRunServer
+		withWait.Wait = true
+		withWait.Timeout = time.Minute
+		assert.Error(t, withWait.Validate())
+	})
+
+	t.Run("Server dry run requests DryRun=All", func(t *testing.T) {
+		o := &DeployOptions{DryRun: dryRunServer}
+		assert.Equal(t, []string{metav1.DryRunAll}, o.serverDryRun())
+
+		for _, mode := range []string{"", dryRunNone, dryRunClient} {
+			o.DryRun = mode
+			assert.Nil(t, o.serverDryRun(), mode)
+		}
+	})
+
+	t.Run("Server dry run create succeeds", func(t *testing.T) {
+		o := &DeployOptions{WorkspaceName: "my-ws", Namespace: "default", Model: "phi-4", Count: 1, DryRun: dryRunServer}
+		_, _, err := o.applyWorkspace(context.TODO(), newFakeDynamicClient(), o.buildWorkspace())
+		assert.NoError(t, err)
+	})
+}
+
+func TestApplyDeployFile(t *testing.T) {
+	writeFile := func(t *testing.T, name, content string) string {
+		path := filepath.Join(t.TempDir(), name)
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+
+	t.Run("YAML file with command-line override", func(t *testing.T) {
+		path := writeFile(t, "deploy.yaml", `
+workspace-name: llama-ws
+model: llama-3.1-8b-instruct
+instanceType: Standard_NC24ads_A100_v4
+count: 1
+node-selector:
+  apps: llm
+adapters: [adapter-a, adapter-b]
+enableLoadBalancer: true
+`)
+		cmd := NewDeployCmd(genericclioptions.NewConfigFlags(true))
+		assert.NoError(t, cmd.Flags().Parse([]string{"--from-file", path, "--count", "3"}))
+		assert.NoError(t, applyDeployFile(cmd.Flags(), path))
+
+		value := func(name string) string { return cmd.Flags().Lookup(name).Value.String() }
+		assert.Equal(t, "llama-ws", value("workspace-name"))
+		assert.Equal(t, "llama-3.1-8b-instruct", value("model"))
+		assert.Equal(t, "Standard_NC24ads_A100_v4", value("instance-type"))
+		assert.Equal(t, "3", value("count"), "command-line flags override the file")
+		assert.Equal(t, "[apps=llm]", value("node-selector"))
+		assert.Equal(t, "[adapter-a,adapter-b]", value("adapters"))
+		assert.Equal(t, "true", value("enable-load-balancer"))
+	})
+
+	t.Run("JSON file", func(t *testing.T) {
+		path := writeFile(t, "deploy.json", `{"workspaceName": "phi-ws", "model": "phi-4", "inputURLs": ["https://example.com/a"]}`)
+		cmd := NewDeployCmd(genericclioptions.NewConfigFlags(true))
+		assert.NoError(t, applyDeployFile(cmd.Flags(), path))
+		assert.Equal(t, "phi-ws", cmd.Flags().Lookup("workspace-name").Value.String())
+		assert.Equal(t, "[https://example.com/a]", cmd.Flags().Lookup("input-urls").Value.String())
+	})
+
+	t.Run("Every exported DeployOptions field can be set", func(t *testing.T) {
+		// fileKey turns a field name into its camelCase key, e.g. HFToken to hfToken
+		fileKey := func(field string) string {
+			runes := []rune(field)
+			i := 0
+			for i < len(runes) && unicode.IsUpper(runes[i]) {
+				i++
+			}
+			if i > 1 && i < len(runes) {
+				i--
+			}
+			return strings.ToLower(string(runes[:i])) + string(runes[i:])
+		}
+
+		optionsType := reflect.TypeOf(DeployOptions{})
+		for i := 0; i < optionsType.NumField(); i++ {
+			field := optionsType.Field(i)
+			if !field.IsExported() || field.Name == "FromFile" {
+				continue
+			}
+
+			cmd := NewDeployCmd(genericclioptions.NewConfigFlags(true))
+			var value string
+			switch field.Type.Kind() {
+			case reflect.Bool:
+				// --interactive defaults to true on a terminal
+				value = "true"
+				if flag := cmd.Flags().Lookup(deployFileFlagName(fileKey(field.Name))); flag != nil && flag.DefValue == "true" {
+					value = "false"
+				}
+			case reflect.Int:
+				value = "2"
+			case reflect.Int64:
+				value = "90s"
+			case reflect.Slice:
+				value = "[a=b]"
+			case reflect.Map:
+				value = "{a: b}"
+			default:
+				value = "value"
+			}
+			// Keys are quoted so that YAML does not read yes as a boolean
+			key := fileKey(field.Name)
+			path := writeFile(t, "deploy.yaml", fmt.Sprintf("%q: %s\n", key, value))
+
+			if !assert.NoError(t, applyDeployFile(cmd.Flags(), path), field.Name) {
+				continue
+			}
+			flag := cmd.Flags().Lookup(deployFileFlagName(key))
+			assert.NotEqual(t, flag.DefValue, flag.Value.String(), "%s sets --%s", field.Name, flag.Name)
+		}
+	})
+
+	t.Run("Field names that differ from the flag", func(t *testing.T) {
+		path := writeFile(t, "deploy.yaml", "labelSelector:\n  apps: llm\nlabels: [team=ml]\nannotations: [cost-center=42]\n")
+		cmd := NewDeployCmd(genericclioptions.NewConfigFlags(true))
+		assert.NoError(t, applyDeployFile(cmd.Flags(), path))
+		assert.Equal(t, "[apps=llm]", cmd.Flags().Lookup("node-selector").Value.String())
+		assert.Equal(t, "[team=ml]", cmd.Flags().Lookup("label").Value.String())
+		assert.Equal(t, "[cost-center=42]", cmd.Flags().Lookup("annotation").Value.String())
+	})
+
+	t.Run("Unquoted yes key", func(t *testing.T) {
+		path := writeFile(t, "deploy.yaml", "yes: true\n")
+		err := applyDeployFile(NewDeployCmd(genericclioptions.NewConfigFlags(true)).Flags(), path)
+		assert.ErrorContains(t, err, `quote it, e.g. "yes": true`)
+	})
+
+	t.Run("Unknown key is rejected", func(t *testing.T) {
+		path := writeFile(t, "deploy.yaml", "workspace-name: x\nreplicas: 2\n")
+		err := applyDeployFile(NewDeployCmd(genericclioptions.NewConfigFlags(true)).Flags(), path)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "replicas")
+	})
+
+	t.Run("Invalid value is rejected", func(t *testing.T) {
+		path := writeFile(t, "deploy.yaml", "count: many\n")
+		assert.Error(t, applyDeployFile(NewDeployCmd(genericclioptions.NewConfigFlags(true)).Flags(), path))
+	})
+
+	t.Run("Missing file", func(t *testing.T) {
+		assert.Error(t, applyDeployFile(NewDeployCmd(genericclioptions.NewConfigFlags(true)).Flags(), "/nonexistent/deploy.yaml"))
+	})
+}
+
+func TestCamelToKebab(t *testing.T) {
+	for key, expected := range map[string]string{
+		"workspaceName":      "workspace-name",
+		"workspace-name":     "workspace-name",
+		"inputURLs":          "input-urls",
+		"outputPVC":          "output-pvc",
+		"enableLoadBalancer": "enable-load-balancer",
+	} {
+		assert.Equal(t, expected, camelToKebab(key))
+	}
+}
+
+func TestParseAdapterSpec(t *testing.T) {
+	tests := []struct {
+		value       string
+		expected    adapterSpec
+		expectError bool
+	}{
+		{value: "phi-3-adapter", expected: adapterSpec{Name: "phi-3-adapter"}},
+		{value: "a=myregistry/a:v1", expected: adapterSpec{Name: "a", Image: "myregistry/a:v1"}},
+		{value: "a=myregistry/a:v1:0.5", expected: adapterSpec{Name: "a", Image: "myregistry/a:v1", Weight: "0.5"}},
+		{value: "a=myregistry/a:1", expected: adapterSpec{Name: "a", Image: "myregistry/a", Weight: "1"}},
+		{value: "a=localhost:5000/a", expected: adapterSpec{Name: "a", Image: "localhost:5000/a"}},
+		{value: "a=myregistry/a:v1:1.5", expectError: true},
+		{value: "a=myregistry/a:v1:0", expectError: true},
+		{value: "=myregistry/a", expectError: true},
+		{value: "a=", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			spec, err := parseAdapterSpec(tt.value)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, spec)
+		})
+	}
+}
+
+func TestResolveAdapters(t *testing.T) {
+	models := []Model{{
+		Name:     "phi-3",
+		Adapters: []ModelAdapter{{Name: "phi-3-adapter", Source: "mcr.microsoft.com/aks/kaito/adapter-phi-3:0.0.1"}},
+	}}
+
+	specs, err := resolveAdapters([]string{"phi-3-adapter", "custom=myregistry/custom:v1:0.2"}, "phi-3", models)
+	assert.NoError(t, err)
+	assert.Equal(t, []adapterSpec{
+		{Name: "phi-3-adapter", Image: "mcr.microsoft.com/aks/kaito/adapter-phi-3:0.0.1"},
+		{Name: "custom", Image: "myregistry/custom:v1", Weight: "0.2"},
+	}, specs)
+
+	_, err = resolveAdapters([]string{"unknown"}, "phi-3", models)
+	assert.ErrorContains(t, err, "no source image known for adapter unknown")
+
+	_, err = resolveAdapters([]string{"a=img:v1", "a=img:v2"}, "phi-3", models)
+	assert.ErrorContains(t, err, "more than once")
+}
+
+func TestReadAdaptersFile(t *testing.T) {
+	writeFile := func(t *testing.T, content string) string {
+		path := filepath.Join(t.TempDir(), "adapters.yaml")
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+
+	specs, err := readAdaptersFile(writeFile(t, `adapters:
+- name: phi-3-adapter
+- name: custom
+  image: myregistry/custom:2
+  strength: 0.5
+- name: legal
+  image: myregistry/legal:v1
+  strength: "1"
+`))
+	assert.NoError(t, err)
+	assert.Equal(t, []adapterSpec{
+		{Name: "phi-3-adapter"},
+		{Name: "custom", Image: "myregistry/custom:2", Weight: "0.5"},
+		{Name: "legal", Image: "myregistry/legal:v1", Weight: "1"},
+	}, specs)
+
+	_, err = readAdaptersFile(writeFile(t, "adapters:\n- image: myregistry/custom:v1\n"))
+	assert.ErrorContains(t, err, "adapter 1 in")
+	assert.ErrorContains(t, err, "name is required")
+
+	_, err = readAdaptersFile(writeFile(t, "adapters:\n- name: custom\n  image: img:v1\n  strength: 1.5\n"))
+	assert.ErrorContains(t, err, "strength must be greater than 0 and at most 1")
+
+	_, err = readAdaptersFile(writeFile(t, "adapters:\n- name: custom\n  strenght: 0.5\n"))
+	assert.ErrorContains(t, err, "strenght", "unknown fields are rejected")
+
+	_, err = readAdaptersFile(writeFile(t, "adapters: []\n"))
+	assert.ErrorContains(t, err, "lists no adapters")
+
+	_, err = readAdaptersFile(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.ErrorContains(t, err, "failed to read --adapters-file")
+}
+
+func TestDeployAdaptersFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "adapters.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte("adapters:\n- name: custom\n  image: myregistry/custom:v1\n  strength: 0.2\n"), 0o600))
+
+	o := &DeployOptions{WorkspaceName: "ws", Namespace: "default", Model: "phi-3.5-mini-instruct", Count: 1, AdaptersFile: path}
+	assert.NoError(t, o.Validate())
+	adapters, _, _ := unstructured.NestedSlice(o.buildWorkspace().Object, "inference", "adapters")
+	assert.Equal(t, []interface{}{map[string]interface{}{
+		"source":   map[string]interface{}{"name": "custom", "image": "myregistry/custom:v1"},
+		"strength": "0.2",
+	}}, adapters)
+
+	o = &DeployOptions{WorkspaceName: "ws", Model: "phi-3.5-mini-instruct", Count: 1, AdaptersFile: path,
+		Adapters: []string{"custom=myregistry/other:v1"}}
+	assert.ErrorContains(t, o.Validate(), "adapter custom is specified more than once")
+
+	o = &DeployOptions{WorkspaceName: "ws", Model: "phi-3.5-mini-instruct", Count: 1, AdaptersFile: path,
+		Tuning: true, InputURLs: []string{"https://example.com/data.parquet"}, OutputImage: "myregistry/model:v1"}
+	assert.ErrorContains(t, o.Validate(), "--adapters-file")
+}
+
+func TestBuildWorkspaceWithAdapters(t *testing.T) {
+	o := &DeployOptions{
+		WorkspaceName: "ws",
+		Namespace:     "default",
+		Model:         "phi-3",
+		Adapters:      []string{"custom=myregistry/custom:v1:0.2"},
+	}
+
+	workspace := o.buildWorkspace()
+	adapters, found, err := unstructured.NestedSlice(workspace.Object, "inference", "adapters")
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, []interface{}{map[string]interface{}{
+		"source":   map[string]interface{}{"name": "custom", "image": "myregistry/custom:v1"},
+		"strength": "0.2",
+	}}, adapters)
+}
+
+func TestBuildWorkspaceWithEnv(t *testing.T) {
+	o := &DeployOptions{
+		WorkspaceName:     "ws",
+		Namespace:         "default",
+		Model:             "phi-3",
+		ModelAccessSecret: "hf-token",
+		Env:               []string{"VLLM_LOGGING_LEVEL=DEBUG", "EXTRA_ARGS=--a=1 --b=2", "EMPTY="},
+	}
+
+	workspace := o.buildWorkspace()
+	presetOptions, found, err := unstructured.NestedMap(workspace.Object, "inference", "preset", "presetOptions")
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, "hf-token", presetOptions["modelAccessSecret"])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"name": "VLLM_LOGGING_LEVEL", "value": "DEBUG"},
+		map[string]interface{}{"name": "EXTRA_ARGS", "value": "--a=1 --b=2"},
+		map[string]interface{}{"name": "EMPTY", "value": ""},
+	}, presetOptions["env"])
+}
+
+func TestParseEnvVars(t *testing.T) {
+	envVars, err := parseEnvVars([]string{"A=1", "B_2=x=y"})
+	assert.NoError(t, err)
+	assert.Equal(t, []corev1.EnvVar{{Name: "A", Value: "1"}, {Name: "B_2", Value: "x=y"}}, envVars)
+
+	_, err = parseEnvVars([]string{"NOVALUE"})
+	assert.ErrorContains(t, err, "expected KEY=VALUE")
+	_, err = parseEnvVars([]string{"=value"})
+	assert.ErrorContains(t, err, "expected KEY=VALUE")
+	_, err = parseEnvVars([]string{"1BAD=x"})
+	assert.ErrorContains(t, err, `invalid --env name "1BAD"`)
+	_, err = parseEnvVars([]string{"A=1", "A=2"})
+	assert.ErrorContains(t, err, `duplicate --env key "A"`)
+
+	tuning := DeployOptions{
+		WorkspaceName: "ws",
+		Model:         "phi-3.5-mini-instruct",
+		Tuning:        true,
+		Env:           []string{"A=1"},
+		InputURLs:     []string{"https://example.com/data.parquet"},
+		OutputImage:   "myregistry/model:latest",
+	}
+	assert.ErrorContains(t, tuning.Validate(), "cannot use inference flag --env when --tuning is enabled")
+}
+
+func TestDeployLabelsAndAnnotations(t *testing.T) {
+	t.Run("Merged into the workspace metadata", func(t *testing.T) {
+		o := &DeployOptions{
+			WorkspaceName:      "ws",
+			Namespace:          "default",
+			Model:              "phi-3",
+			Labels:             []string{"team=research", "app.example.com/tier=gpu"},
+			Annotations:        []string{"cost-center=ml-platform", "argocd.argoproj.io/tracking-id=apps:kaito/Workspace:default/ws"},
+			EnableLoadBalancer: true,
+		}
+
+		workspace := o.buildWorkspace()
+		assert.Equal(t, map[string]string{"team": "research", "app.example.com/tier": "gpu"}, workspace.GetLabels())
+		assert.Equal(t, map[string]string{
+			"cost-center":                    "ml-platform",
+			"argocd.argoproj.io/tracking-id": "apps:kaito/Workspace:default/ws",
+			"kaito.sh/enable-lb":             "true",
+		}, workspace.GetAnnotations())
+	})
+
+	t.Run("No metadata without the flags", func(t *testing.T) {
+		workspace := (&DeployOptions{WorkspaceName: "ws", Namespace: "default", Model: "phi-3"}).buildWorkspace()
+		assert.Nil(t, workspace.GetLabels())
+		assert.Nil(t, workspace.GetAnnotations())
+	})
+
+	t.Run("Parsing", func(t *testing.T) {
+		pairs, err := parseMetadataPairs("annotation", []string{"note=a=b, with spaces", "empty="})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"note": "a=b, with spaces", "empty": ""}, pairs)
+
+		_, err = parseMetadataPairs("label", []string{"team"})
+		assert.ErrorContains(t, err, `invalid --label "team": expected key=value`)
+		_, err = parseMetadataPairs("label", []string{"bad key=x"})
+		assert.ErrorContains(t, err, `invalid --label key "bad key"`)
+		_, err = parseMetadataPairs("label", []string{"team=has spaces"})
+		assert.ErrorContains(t, err, `invalid --label value "has spaces"`)
+		_, err = parseMetadataPairs("annotation", []string{"a=1", "a=2"})
+		assert.ErrorContains(t, err, `duplicate --annotation key "a"`)
+		_, err = parseMetadataPairs("annotation", []string{"kaito.sh/enable-lb=false"})
+		assert.ErrorContains(t, err, "reserved for Kaito")
+		_, err = parseMetadataPairs("label", []string{"kaito.sh/workspace=other"})
+		assert.ErrorContains(t, err, "reserved for Kaito")
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		o := &DeployOptions{WorkspaceName: "ws", Model: "phi-3.5-mini-instruct", Count: 1, Labels: []string{"kaito.sh/x=y"}}
+		assert.ErrorContains(t, o.Validate(), "reserved for Kaito")
+
+		o = &DeployOptions{WorkspaceName: "ws", Model: "phi-3.5-mini-instruct", Count: 1, Annotations: []string{"cost-center"}}
+		assert.ErrorContains(t, o.Validate(), "expected key=value")
+	})
+}
+
+func TestDeployOutputYAML(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "workspace.yaml")
+	o := &DeployOptions{
+		configFlags:   genericclioptions.NewConfigFlags(true),
+		WorkspaceName: "my-ws",
+		Namespace:     "default",
+		Model:         "phi-4",
+		Count:         1,
+		DryRun:        dryRunClient,
+		OutputYAML:    path,
+	}
+	assert.NoError(t, o.Run(context.TODO()))
+
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	var workspace map[string]interface{}
+	assert.NoError(t, yaml.Unmarshal(data, &workspace))
+	assert.Equal(t, "Workspace", workspace["kind"])
+	name, _, _ := unstructured.NestedString(workspace, "inference", "preset", "name")
+	assert.Equal(t, "phi-4", name)
+
+	// '-' only prints the manifest, so it cannot wait or dry run on the server
+	stdout := DeployOptions{WorkspaceName: "my-ws", Model: "phi-4", Count: 1, OutputYAML: "-"}
+	assert.NoError(t, stdout.Validate())
+	stdout.DryRun = dryRunServer
+	assert.Error(t, stdout.Validate())
+}
+
+func TestInferenceConfigMapOwnerReference(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	assert.NoError(t, os.WriteFile(configFile, []byte("vllm:\n  max-model-len: 4096\n"), 0o644))
+
+	workspace := newTestWorkspace("my-ws", "default", nil)
+	workspace.SetUID("1234")
+	owner := workspaceOwnerReference(workspace)
+	assert.Equal(t, &metav1.OwnerReference{APIVersion: "kaito.sh/v1beta1", Kind: "Workspace", Name: "my-ws", UID: "1234"}, owner)
+	assert.Nil(t, workspaceOwnerReference(newTestWorkspace("no-uid", "default", nil)))
+
+	t.Run("New ConfigMap is owned by the workspace", func(t *testing.T) {
+		clientset := fake.NewSimpleClientset()
+		assert.NoError(t, createInferenceConfigMap(context.TODO(), clientset, configFile, "my-ws", "default", owner, false, nil))
+
+		configMap, err := clientset.CoreV1().ConfigMaps("default").Get(context.TODO(), "my-ws-inference-config", metav1.GetOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, []metav1.OwnerReference{*owner}, configMap.OwnerReferences)
+	})
+
+	t.Run("Existing ConfigMap gains the owner once", func(t *testing.T) {
+		other := metav1.OwnerReference{APIVersion: "v1", Kind: "Pod", Name: "other", UID: "5678"}
+		clientset := fake.NewSimpleClientset(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-ws-inference-config", Namespace: "default", OwnerReferences: []metav1.OwnerReference{other}},
+			Data:       map[string]string{"inference_config.yaml": "old"},
+		})
+		for i := 0; i < 2; i++ {
+			assert.NoError(t, createInferenceConfigMap(context.TODO(), clientset, configFile, "my-ws", "default", owner, true, nil))
+		}
+
+		configMap, err := clientset.CoreV1().ConfigMaps("default").Get(context.TODO(), "my-ws-inference-config", metav1.GetOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, []metav1.OwnerReference{other, *owner}, configMap.OwnerReferences)
+		assert.Contains(t, configMap.Data["inference_config.yaml"], "max-model-len")
+	})
+}
+
+func TestInferenceConfigMapForce(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	assert.NoError(t, os.WriteFile(configFile, []byte("vllm:\n  max-model-len: 4096\n"), 0o644))
+
+	newClientset := func(config string) *fake.Clientset {
+		return fake.NewSimpleClientset(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-ws-inference-config", Namespace: "default"},
+			Data:       map[string]string{inferenceConfigKey: config},
+		})
+	}
+	getConfig := func(clientset *fake.Clientset) string {
+		configMap, err := clientset.CoreV1().ConfigMaps("default").Get(context.TODO(), "my-ws-inference-config", metav1.GetOptions{})
+		assert.NoError(t, err)
+		return configMap.Data[inferenceConfigKey]
+	}
+
+	t.Run("Different content requires --force", func(t *testing.T) {
+		clientset := newClientset("vllm:\n  max-model-len: 2048\n")
+		err := createInferenceConfigMap(context.TODO(), clientset, configFile, "my-ws", "default", nil, false, nil)
+		assert.ErrorContains(t, err, "\n  vllm:\n")
+		assert.ErrorContains(t, err, "-   max-model-len: 2048")
+		assert.ErrorContains(t, err, "+   max-model-len: 4096")
+		assert.ErrorContains(t, err, "use --force")
+		assert.Equal(t, "vllm:\n  max-model-len: 2048\n", getConfig(clientset))
+
+		assert.NoError(t, createInferenceConfigMap(context.TODO(), clientset, configFile, "my-ws", "default", nil, true, nil))
+		assert.Equal(t, "vllm:\n  max-model-len: 4096\n", getConfig(clientset))
+	})
+
+	t.Run("Identical content is not updated", func(t *testing.T) {
+		clientset := newClientset("vllm:\n  max-model-len: 4096\n")
+		assert.NoError(t, createInferenceConfigMap(context.TODO(), clientset, configFile, "my-ws", "default", nil, false, nil))
+		for _, action := range clientset.Actions() {
+			assert.NotEqual(t, "update", action.GetVerb())
+		}
+	})
+}
+
+func TestLineDiff(t *testing.T) {
+	assert.Equal(t, "  a\n- b\n+ c\n  d", lineDiff("a\nb\nd\n", "a\nc\nd\n"))
+	assert.Equal(t, "  a\n+ b", lineDiff("a", "a\nb"))
+}
+
+func TestModelImageSecret(t *testing.T) {
+	o := &DeployOptions{
+		WorkspaceName:    "tune-ws",
+		Model:            "phi-3.5-mini-instruct",
+		Tuning:           true,
+		TuningMethod:     "qlora",
+		ModelImage:       "myregistry.azurecr.io/base:latest",
+		ModelImageSecret: "acr-pull",
+	}
+
+	secrets, found, err := unstructured.NestedStringSlice(o.buildWorkspace().Object, "tuning", "preset", "presetOptions", "imagePullSecrets")
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, []string{"acr-pull"}, secrets)
+
+	withoutImage := DeployOptions{WorkspaceName: "tune-ws", Model: "phi-3.5-mini-instruct", Count: 1, Tuning: true, ModelImageSecret: "acr-pull"}
+	assert.ErrorContains(t, withoutImage.Validate(), "--model-image-secret requires --model-image")
+
+	inference := DeployOptions{WorkspaceName: "ws", Model: "phi-3.5-mini-instruct", Count: 1, ModelImageSecret: "acr-pull"}
+	assert.ErrorContains(t, inference.Validate(), "--model-image-secret can only be used with --tuning")
+
+	clientset := fake.NewSimpleClientset(&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "acr-pull", Namespace: "default"}})
+	assert.NoError(t, checkSecretExists(context.TODO(), clientset, "default", "acr-pull"))
+	assert.ErrorContains(t, checkSecretExists(context.TODO(), clientset, "other", "acr-pull"), "secret acr-pull not found in namespace other")
+}
+
+func TestValidateImageReferences(t *testing.T) {
+	newOptions := func() DeployOptions {
+		return DeployOptions{
+			WorkspaceName: "tune-ws",
+			Model:         "phi-3.5-mini-instruct",
+			Count:         1,
+			Tuning:        true,
+			InputURLs:     []string{"https://example.com/data.parquet"},
+			OutputImage:   "myregistry.azurecr.io/phi-finetuned:v1",
+		}
+	}
+
+	valid := newOptions()
+	valid.ModelImage = "myregistry.azurecr.io/phi-base:v1"
+	assert.NoError(t, valid.Validate())
+
+	untagged := newOptions()
+	untagged.OutputImage = "myregistry.azurecr.io/phi-finetuned"
+	assert.NoError(t, untagged.Validate())
+
+	badOutput := newOptions()
+	badOutput.OutputImage = "myregistry.azurecr.io/Phi-Finetuned:v1"
+	assert.ErrorContains(t, badOutput.Validate(), "invalid --output-image: repository name")
+
+	badModel := newOptions()
+	badModel.ModelImage = "myregistry/phi base"
+	assert.ErrorContains(t, badModel.Validate(), "invalid --model-image")
+
+	badInference := DeployOptions{
+		WorkspaceName:  "inference-ws",
+		Model:          "phi-3.5-mini-instruct",
+		Count:          1,
+		InferenceImage: "myregistry/Runtime:v1",
+	}
+	assert.ErrorContains(t, badInference.Validate(), "invalid --inference-image")
+}
+
+func TestCheckInputURLs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodHead, r.Method)
+		if r.URL.Path == "/missing.parquet" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := server.Client()
+	assert.NoError(t, checkInputURLs(context.TODO(), client, []string{server.URL + "/data.parquet"}))
+
+	err := checkInputURLs(context.TODO(), client, []string{
+		server.URL + "/data.parquet",
+		server.URL + "/missing.parquet",
+		"http://127.0.0.1:1/unreachable",
+	})
+	assert.ErrorContains(t, err, "2 of 3 input URL(s) failed")
+	assert.ErrorContains(t, err, "/missing.parquet: HEAD returned 404 Not Found")
+	assert.ErrorContains(t, err, "/unreachable: unreachable")
+	assert.NotContains(t, err.Error(), "/data.parquet")
+
+	t.Run("Validation", func(t *testing.T) {
+		tuning := DeployOptions{WorkspaceName: "tune-ws", Model: "phi-3.5-mini-instruct", Count: 1, Tuning: true,
+			TuningMethod: "qlora", InputPVC: "data", OutputPVC: "out", ValidateInputs: true}
+		assert.ErrorContains(t, tuning.Validate(), "--validate-inputs requires --input-urls")
+
+		inference := DeployOptions{WorkspaceName: "ws", Model: "phi-3.5-mini-instruct", Count: 1, ValidateInputs: true}
+		assert.ErrorContains(t, inference.Validate(), "--validate-inputs can only be used with --tuning")
+	})
+}
+
+func TestPickModel(t *testing.T) {
+	models := []Model{
+		{Name: "falcon-7b-instruct", Type: "text-generation"},
+		{Name: "llama-3.1-8b-instruct", Type: "text-generation"},
+		{Name: "phi-3.5-mini-instruct", Type: "text-generation"},
+	}
+
+	tests := []struct {
+		name        string
+		input       string
+		expected    string
+		expectError bool
+	}{
+		{"Select by number", "2\n", "llama-3.1-8b-instruct", false},
+		{"Filter then select", "phi\n1\n", "phi-3.5-mini-instruct", false},
+		{"Enter picks the only match", "falcon\n\n", "falcon-7b-instruct", false},
+		{"Out of range number asks again", "9\n3\n", "phi-3.5-mini-instruct", false},
+		{"No match keeps the list", "mistral\n1\n", "falcon-7b-instruct", false},
+		{"Quit", "q\n", "", true},
+		{"End of input", "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			model, err := pickModel(strings.NewReader(tt.input), &out, models)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, model)
+		})
+	}
+
+	_, err := pickModel(strings.NewReader("1\n"), &bytes.Buffer{}, nil)
+	assert.Error(t, err)
+}
+
+func TestValidateInferenceConfig(t *testing.T) {
+	tests := []struct {
+		name          string
+		config        string
+		errorContains string
+	}{
+		{"Valid vllm config", "max_probe_steps: 6\nvllm:\n  gpu-memory-utilization: 0.9\n  max-model-len: 4096\n", ""},
+		{"Valid transformers config", "transformers:\n  torch_dtype: bfloat16\n", ""},
+		{"Malformed YAML", "vllm:\n  max-model-len: 4096\n bad: [\n", "not valid YAML: yaml: line"},
+		{"Empty file", "", "file is empty"},
+		{"Unknown top-level key", "vllm:\n  max-model-len: 4096\nvlm:\n  swap-space: 4\n", `line 3: unknown top-level key "vlm"`},
+		{"Section is not a mapping", "vllm: fast\n", "line 1: vllm must be a mapping"},
+		{"Out of range option", "vllm:\n  swap-space: 4\n  gpu-memory-utilization: 95\n", "line 3: vllm.gpu-memory-utilization must be a number in (0, 1]"},
+		{"Wrong option type", "vllm:\n  max-model-len: large\n", "line 2: vllm.max-model-len must be a positive integer"},
+		{"Fractional probe steps", "max_probe_steps: 1.5\n", "line 1: max_probe_steps must be a positive integer"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateInferenceConfig([]byte(tt.config))
+			if tt.errorContains == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.errorContains)
+			}
+		})
+	}
+}
+
+func TestCheckNodeCapacity(t *testing.T) {
+	newNode := func(name, instanceType string, ready bool, gpus string) *corev1.Node {
+		status := corev1.ConditionFalse
+		if ready {
+			status = corev1.ConditionTrue
+		}
+		node := &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{corev1.LabelInstanceTypeStable: instanceType, "pool": "gpu"},
+			},
+			Status: corev1.NodeStatus{
+				Conditions:  []corev1.NodeCondition{{Type: corev1.NodeReady, Status: status}},
+				Allocatable: corev1.ResourceList{},
+			},
+		}
+		if gpus != "" {
+			node.Status.Allocatable[gpuResourceName] = resource.MustParse(gpus)
+		}
+		return node
+	}
+	clientset := fake.NewSimpleClientset(
+		newNode("gpu-1", "Standard_NC24ads_A100_v4", true, "1"),
+		newNode("gpu-2", "Standard_NC24ads_A100_v4", false, "1"),
+		newNode("gpu-3", "Standard_NC6s_v3", true, "1"),
+		newNode("cpu-1", "Standard_D4s_v3", true, ""),
+	)
+
+	t.Run("Counts ready nodes of the instance type", func(t *testing.T) {
+		request := capacityRequest{InstanceType: "Standard_NC24ads_A100_v4", Count: 2}
+		report, err := checkNodeCapacity(context.TODO(), clientset, request)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"gpu-1"}, report.Matching)
+		assert.Equal(t, []string{"gpu-2"}, report.NotReady)
+
+		var out bytes.Buffer
+		assert.False(t, printCapacityReport(&out, request, report))
+		assert.Contains(t, out.String(), "only 1 of 2 requested nodes of instance type Standard_NC24ads_A100_v4")
+		assert.Contains(t, out.String(), "not ready or cordoned: gpu-2")
+		assert.Contains(t, out.String(), "auto-provisioning")
+	})
+
+	t.Run("Without instance type any GPU node matches", func(t *testing.T) {
+		request := capacityRequest{Count: 2}
+		report, err := checkNodeCapacity(context.TODO(), clientset, request)
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{"gpu-1", "gpu-3"}, report.Matching)
+
+		var out bytes.Buffer
+		assert.True(t, printCapacityReport(&out, request, report))
+		assert.Contains(t, out.String(), "2 of 2 requested GPU nodes available")
+	})
+
+	t.Run("Node selector and preferred nodes narrow the match", func(t *testing.T) {
+		report, err := checkNodeCapacity(context.TODO(), clientset, capacityRequest{
+			LabelSelector:  map[string]string{"pool": "gpu"},
+			PreferredNodes: []string{"gpu-3", "cpu-1"},
+			Count:          1,
+		})
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{"gpu-3", "cpu-1"}, report.Matching)
+	})
+}
+
+func TestValidateTuningMethod(t *testing.T) {
+	for _, method := range append([]string{""}, supportedTuningMethods...) {
+		assert.NoError(t, validateTuningMethod(method), method)
+	}
+
+	err := validateTuningMethod("qlova")
+	assert.EqualError(t, err, `unsupported tuning method "qlova"; did you mean "qlora"? Supported methods: qlora, lora`)
+
+	err = validateTuningMethod("LoRA")
+	assert.ErrorContains(t, err, `did you mean "lora"?`)
+
+	err = validateTuningMethod("full-finetune")
+	assert.EqualError(t, err, `unsupported tuning method "full-finetune"; supported methods: qlora, lora`)
+}
+
+func TestDeployWorkspaceFile(t *testing.T) {
+	gvr := schema.GroupVersionResource{Group: "kaito.sh", Version: "v1beta1", Resource: "workspaces"}
+	writeFile := func(t *testing.T, content string) string {
+		path := filepath.Join(t.TempDir(), "workspace.yaml")
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+	const manifest = `
+apiVersion: kaito.sh/v1beta1
+kind: Workspace
+metadata:
+  name: custom-ws
+  resourceVersion: "42"
+resource:
+  instanceType: Standard_NC24ads_A100_v4
+  count: 2
+  labelSelector:
+    matchLabels:
+      apps: llm
+inference:
+  preset:
+    name: phi-4
+  futureField: enabled
+status:
+  conditions: []
+`
+
+	t.Run("Manifest is applied as-is", func(t *testing.T) {
+		o := &DeployOptions{WorkspaceFile: writeFile(t, manifest), Count: 1, Namespace: "default"}
+		assert.NoError(t, o.Validate())
+		assert.Equal(t, "custom-ws", o.WorkspaceName)
+		assert.Equal(t, "phi-4", o.Model)
+		assert.Equal(t, 2, o.Count)
+		assert.Equal(t, map[string]string{"apps": "llm"}, o.LabelSelector)
+
+		client := newFakeDynamicClient()
+		_, _, err := o.applyWorkspace(context.TODO(), client, o.buildWorkspace())
+		assert.NoError(t, err)
+
+		created, err := client.Resource(gvr).Namespace("default").Get(context.TODO(), "custom-ws", metav1.GetOptions{})
+		assert.NoError(t, err)
+		field, _, _ := unstructured.NestedString(created.Object, "inference", "futureField")
+		assert.Equal(t, "enabled", field, "fields without a flag are kept")
+		_, hasStatus := created.Object["status"]
+		assert.False(t, hasStatus, "status is dropped from the manifest")
+	})
+
+	t.Run("Tuning manifest sets tuning mode", func(t *testing.T) {
+		path := writeFile(t, "apiVersion: kaito.sh/v1beta1\nkind: Workspace\nmetadata:\n  name: tune-ws\ntuning:\n  method: lora\n")
+		o := &DeployOptions{WorkspaceFile: path, Count: 1, TuningMethod: "qlora"}
+		assert.NoError(t, o.Validate())
+		assert.True(t, o.Tuning)
+	})
+
+	t.Run("Workspace name comes from the flag when the manifest has none", func(t *testing.T) {
+		path := writeFile(t, "apiVersion: kaito.sh/v1beta1\nkind: Workspace\ninference: {}\n")
+		o := &DeployOptions{WorkspaceFile: path, WorkspaceName: "named-ws", Count: 1}
+		assert.NoError(t, o.Validate())
+		assert.Equal(t, "named-ws", o.buildWorkspace().GetName())
+
+		assert.ErrorContains(t, (&DeployOptions{WorkspaceFile: path, Count: 1}).Validate(), "has no metadata.name")
+	})
+
+	t.Run("Namespace comes from the manifest or the flag", func(t *testing.T) {
+		namespaced := writeFile(t, "apiVersion: kaito.sh/v1beta1\nkind: Workspace\nmetadata:\n  name: ws\n  namespace: team-a\ninference: {}\n")
+		o := &DeployOptions{WorkspaceFile: namespaced, Count: 1}
+		assert.NoError(t, o.Validate())
+		assert.Equal(t, "team-a", o.Namespace)
+
+		o = &DeployOptions{WorkspaceFile: writeFile(t, manifest), Namespace: "team-b", Count: 1}
+		assert.NoError(t, o.Validate())
+		assert.Equal(t, "team-b", o.buildWorkspace().GetNamespace())
+	})
+
+	t.Run("Invalid manifests and flags", func(t *testing.T) {
+		path := writeFile(t, manifest)
+		assert.ErrorContains(t, (&DeployOptions{WorkspaceFile: path, WorkspaceName: "other", Count: 1}).Validate(),
+			"does not match metadata.name custom-ws")
+		namespaced := writeFile(t, "apiVersion: kaito.sh/v1beta1\nkind: Workspace\nmetadata:\n  name: ws\n  namespace: team-a\ninference: {}\n")
+		assert.ErrorContains(t, (&DeployOptions{WorkspaceFile: namespaced, Namespace: "team-b", Count: 1}).Validate(),
+			"--namespace team-b does not match metadata.namespace team-a")
+		assert.ErrorContains(t, (&DeployOptions{WorkspaceFile: path, Model: "phi-4", Count: 1}).Validate(),
+			"--model cannot be used with --workspace-file")
+		assert.ErrorContains(t, (&DeployOptions{WorkspaceFile: path, Count: 3}).Validate(), "--count cannot be used")
+		assert.ErrorContains(t, (&DeployOptions{WorkspaceFile: path, Count: 1, Labels: []string{"team=a"}}).Validate(),
+			"--label cannot be used with --workspace-file")
+
+		ragEngine := writeFile(t, "apiVersion: kaito.sh/v1alpha1\nkind: RAGEngine\nmetadata:\n  name: rag\n")
+		assert.ErrorContains(t, (&DeployOptions{WorkspaceFile: ragEngine, Count: 1}).Validate(), "must contain a kaito.sh/v1beta1 Workspace")
+		assert.ErrorContains(t, (&DeployOptions{WorkspaceFile: writeFile(t, ""), Count: 1}).Validate(), "is empty")
+	})
+}
+
+func TestDeployHFToken(t *testing.T) {
+	t.Run("Token becomes the model access secret", func(t *testing.T) {
+		o := &DeployOptions{WorkspaceName: "llama-ws", Model: "phi-3.5-mini-instruct", Count: 1, HFToken: " hf_abc\n"}
+		assert.NoError(t, o.Validate())
+		assert.Equal(t, "hf_abc", o.hfToken)
+
+		secret, found, err := unstructured.NestedString(o.buildWorkspace().Object, "inference", "preset", "presetOptions", "modelAccessSecret")
+		assert.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, "llama-ws-hf-token", secret)
+	})
+
+	t.Run("Token file", func(t *testing.T) {
+		tokenFile := filepath.Join(t.TempDir(), "token")
+		assert.NoError(t, os.WriteFile(tokenFile, []byte("hf_file\n"), 0o600))
+		o := &DeployOptions{WorkspaceName: "llama-ws", Model: "phi-3.5-mini-instruct", Count: 1, HFTokenFile: tokenFile}
+		assert.NoError(t, o.Validate())
+		assert.Equal(t, "hf_file", o.hfToken)
+
+		empty := filepath.Join(t.TempDir(), "empty")
+		assert.NoError(t, os.WriteFile(empty, []byte("\n"), 0o600))
+		o.HFTokenFile = empty
+		assert.ErrorContains(t, o.Validate(), "the Hugging Face token is empty")
+
+		o.HFTokenFile = filepath.Join(t.TempDir(), "missing")
+		assert.ErrorContains(t, o.Validate(), "failed to read --hf-token-file")
+	})
+
+	t.Run("Conflicting flags", func(t *testing.T) {
+		base := DeployOptions{WorkspaceName: "llama-ws", Model: "phi-3.5-mini-instruct", Count: 1, HFToken: "hf_abc"}
+
+		both := base
+		both.HFTokenFile = "token"
+		assert.ErrorContains(t, both.Validate(), "--hf-token and --hf-token-file cannot be used together")
+
+		withSecret := base
+		withSecret.ModelAccessSecret = "hf-token"
+		assert.ErrorContains(t, withSecret.Validate(), "cannot be used with --model-access-secret")
+
+		tuning := base
+		tuning.Tuning = true
+		assert.ErrorContains(t, tuning.Validate(), "cannot use inference flag --hf-token when --tuning is enabled")
+	})
+
+	owner := &metav1.OwnerReference{APIVersion: "kaito.sh/v1beta1", Kind: "Workspace", Name: "llama-ws", UID: "1234"}
+	getSecret := func(clientset *fake.Clientset) *corev1.Secret {
+		secret, err := clientset.CoreV1().Secrets("default").Get(context.TODO(), "llama-ws-hf-token", metav1.GetOptions{})
+		assert.NoError(t, err)
+		return secret
+	}
+
+	t.Run("New secret is owned by the workspace", func(t *testing.T) {
+		clientset := fake.NewSimpleClientset()
+		assert.NoError(t, createHFTokenSecret(context.TODO(), clientset, "hf_abc", "llama-ws", "default", owner, false, nil))
+
+		secret := getSecret(clientset)
+		assert.Equal(t, []byte("hf_abc"), secret.Data[hfTokenKey])
+		assert.Equal(t, []metav1.OwnerReference{*owner}, secret.OwnerReferences)
+	})
+
+	t.Run("Existing secret", func(t *testing.T) {
+		clientset := fake.NewSimpleClientset(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "llama-ws-hf-token", Namespace: "default"},
+			Data:       map[string][]byte{hfTokenKey: []byte("hf_old")},
+		})
+		err := createHFTokenSecret(context.TODO(), clientset, "hf_abc", "llama-ws", "default", owner, false, nil)
+		assert.ErrorContains(t, err, "use --force")
+		assert.Equal(t, []byte("hf_old"), getSecret(clientset).Data[hfTokenKey])
+
+		for i := 0; i < 2; i++ {
+			assert.NoError(t, createHFTokenSecret(context.TODO(), clientset, "hf_abc", "llama-ws", "default", owner, true, nil))
+		}
+		secret := getSecret(clientset)
+		assert.Equal(t, []byte("hf_abc"), secret.Data[hfTokenKey])
+		assert.Equal(t, []metav1.OwnerReference{*owner}, secret.OwnerReferences)
+	})
+}
@@ -0,0 +1,341 @@
+/*
+Copyright (c) 2024 Kaito Project
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+)
+
+// DescribeOptions holds the options for the describe command
+type DescribeOptions struct {
+	configFlags *genericclioptions.ConfigFlags
+	clients     *clientFactory
+
+	WorkspaceName string
+	Namespace     string
+}
+
+// NewDescribeCmd creates the describe command
+func NewDescribeCmd(configFlags *genericclioptions.ConfigFlags) *cobra.Command {
+	o := &DescribeOptions{
+		configFlags: configFlags,
+		clients:     newClientFactory(configFlags),
+	}
+
+	cmd := &cobra.Command{
+		Use:   "describe",
+		Short: "Show a detailed report of a Kaito workspace",
+		Long: `Describe prints everything known about a Kaito workspace in one report,
+similar to 'kubectl describe': metadata, the full resource and inference or
+tuning spec, all conditions with their reasons, worker nodes, the backing
+Service and its endpoints, the workspace pods and recent events.
+
+Use it as the first stop when troubleshooting; 'status' gives a shorter summary.`,
+		Example: `  # Describe a workspace
+  kubectl kaito describe --workspace-name my-workspace
+
+  # Describe a workspace in another namespace
+  kubectl kaito describe --workspace-name my-workspace -n kaito-workspaces`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.validate(); err != nil {
+				klog.Errorf("Validation failed: %v", err)
+				return fmt.Errorf("validation failed: %w", err)
+			}
+			return o.run()
+		},
+	}
+
+	cmd.Flags().StringVar(&o.WorkspaceName, "workspace-name", "", "Name of the workspace to describe (required)")
+	cmd.Flags().StringVarP(&o.Namespace, "namespace", "n", "", "Kubernetes namespace")
+
+	if err := cmd.MarkFlagRequired("workspace-name"); err != nil {
+		klog.Errorf("Failed to mark workspace-name flag as required: %v", err)
+	}
+
+	return cmd
+}
+
+func (o *DescribeOptions) validate() error {
+	klog.V(4).Info("Validating describe options")
+
+	if o.WorkspaceName == "" {
+		return fmt.Errorf("workspace name is required")
+	}
+
+	return nil
+}
+
+func (o *DescribeOptions) run() error {
+	klog.V(2).Infof("Describing workspace: %s", o.WorkspaceName)
+
+	dynamicClient, err := o.clients.DynamicClient()
+	if err != nil {
+		return err
+	}
+
+	clientset, err := o.clients.KubernetesClient()
+	if err != nil {
+		return err
+	}
+
+	// Get namespace
+	o.Namespace = resolveNamespace(o.configFlags, o.Namespace)
+
+	gvr := schema.GroupVersionResource{
+		Group:    "kaito.sh",
+		Version:  "v1beta1",
+		Resource: "workspaces",
+	}
+
+	workspace, err := dynamicClient.Resource(gvr).Namespace(o.Namespace).Get(context.TODO(), o.WorkspaceName, metav1.GetOptions{})
+	if err != nil {
+		klog.Errorf("Failed to get workspace %s: %v", o.WorkspaceName, err)
+		return fmt.Errorf("failed to get workspace %s: %w", o.WorkspaceName, err)
+	}
+
+	return describeWorkspace(os.Stdout, workspace, clientset)
+}
+
+// describeWorkspace writes the full report for a workspace. Related objects that
+// cannot be read are reported inline so the rest of the report is still shown.
+func describeWorkspace(out io.Writer, workspace *unstructured.Unstructured, clientset kubernetes.Interface) error {
+	w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+
+	fmt.Fprintf(w, "Name:\t%s\n", workspace.GetName())
+	fmt.Fprintf(w, "Namespace:\t%s\n", workspace.GetNamespace())
+	fmt.Fprintf(w, "Labels:\t%s\n", formatStringMap(workspace.GetLabels()))
+	annotations := workspace.GetAnnotations()
+	// The last-applied configuration repeats the spec below
+	delete(annotations, corev1.LastAppliedConfigAnnotation)
+	fmt.Fprintf(w, "Annotations:\t%s\n", formatStringMap(annotations))
+	fmt.Fprintf(w, "Age:\t%s\n", resourceAge(workspace))
+	if _, found := workspace.Object["tuning"]; found {
+		fmt.Fprintln(w, "Mode:\tFine-tuning")
+	} else {
+		fmt.Fprintln(w, "Mode:\tInference")
+	}
+
+	for _, section := range []string{"resource", "inference", "tuning"} {
+		if spec, found := workspace.Object[section]; found {
+			fmt.Fprintf(w, "%s:\n", capitalizeFirst(section))
+			writeIndentedYAML(w, spec)
+		}
+	}
+
+	describeConditions(w, workspace)
+
+	fmt.Fprintln(w, "Worker Nodes:")
+	workerNodes, _, _ := unstructured.NestedStringSlice(workspace.Object, "status", "workerNodes")
+	if len(workerNodes) == 0 {
+		fmt.Fprintln(w, "  <none>")
+	}
+	for _, node := range workerNodes {
+		fmt.Fprintf(w, "  %s\n", node)
+	}
+
+	if clientset != nil {
+		describeService(w, clientset, workspace.GetNamespace(), workspace.GetName())
+		describePods(w, clientset, workspace.GetNamespace(), workspace.GetName())
+		describeEvents(w, clientset, workspace.GetNamespace(), workspace.GetName())
+	}
+
+	return w.Flush()
+}
+
+// formatStringMap renders labels or annotations as sorted key=value pairs
+func formatStringMap(values map[string]string) string {
+	if len(values) == 0 {
+		return "<none>"
+	}
+	pairs := make([]string, 0, len(values))
+	for key, value := range values {
+		pairs = append(pairs, key+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, "\n\t")
+}
+
+// writeIndentedYAML writes value as YAML indented under a section heading
+func writeIndentedYAML(w io.Writer, value interface{}) {
+	data, err := yaml.Marshal(value)
+	if err != nil {
+		fmt.Fprintf(w, "  <invalid: %v>\n", err)
+		return
+	}
+	for _, line := range strings.Split(strings.TrimRight(string(data), "\n"), "\n") {
+		fmt.Fprintf(w, "  %s\n", line)
+	}
+}
+
+func describeConditions(w io.Writer, workspace *unstructured.Unstructured) {
+	fmt.Fprintln(w, "Conditions:")
+	conditions, _, _ := unstructured.NestedSlice(workspace.Object, "status", "conditions")
+	if len(conditions) == 0 {
+		fmt.Fprintln(w, "  <none>")
+		return
+	}
+
+	fmt.Fprintln(w, "  TYPE\tSTATUS\tREASON\tLAST TRANSITION\tMESSAGE")
+	for _, condition := range conditions {
+		condMap, ok := condition.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		condType, _ := condMap["type"].(string)
+		status, _ := condMap["status"].(string)
+		reason, _ := condMap["reason"].(string)
+		lastTransitionTime, _ := condMap["lastTransitionTime"].(string)
+		message, _ := condMap["message"].(string)
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\n", condType, status, reason, lastTransitionTime, message)
+	}
+}
+
+// describeService reports the Service named after the workspace and its ready endpoints
+func describeService(w io.Writer, clientset kubernetes.Interface, namespace, name string) {
+	fmt.Fprintln(w, "Service:")
+	svc, err := clientset.CoreV1().Services(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		fmt.Fprintln(w, "  <not created yet>")
+		return
+	}
+	if err != nil {
+		fmt.Fprintf(w, "  <error: %v>\n", err)
+		return
+	}
+
+	fmt.Fprintf(w, "  Type:\t%s\n", svc.Spec.Type)
+	fmt.Fprintf(w, "  Cluster IP:\t%s\n", svc.Spec.ClusterIP)
+	var ingress []string
+	for _, lb := range svc.Status.LoadBalancer.Ingress {
+		if lb.IP != "" {
+			ingress = append(ingress, lb.IP)
+		} else if lb.Hostname != "" {
+			ingress = append(ingress, lb.Hostname)
+		}
+	}
+	if len(ingress) > 0 {
+		fmt.Fprintf(w, "  External IP:\t%s\n", strings.Join(ingress, ", "))
+	} else if svc.Spec.Type == corev1.ServiceTypeLoadBalancer {
+		fmt.Fprintln(w, "  External IP:\t<pending>")
+	}
+	var ports []string
+	for _, port := range svc.Spec.Ports {
+		ports = append(ports, fmt.Sprintf("%d/%s", port.Port, port.Protocol))
+	}
+	fmt.Fprintf(w, "  Ports:\t%s\n", strings.Join(ports, ", "))
+
+	slices, err := clientset.DiscoveryV1().EndpointSlices(namespace).List(context.TODO(), metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", discoveryv1.LabelServiceName, name),
+	})
+	if err != nil {
+		fmt.Fprintf(w, "  Endpoints:\t<error: %v>\n", err)
+		return
+	}
+	fmt.Fprintf(w, "  Endpoints:\t%s\n", readyEndpoints(slices.Items))
+}
+
+// readyEndpoints lists the addresses of ready endpoints in the slices
+func readyEndpoints(slices []discoveryv1.EndpointSlice) string {
+	var addresses []string
+	for _, slice := range slices {
+		for _, endpoint := range slice.Endpoints {
+			if endpoint.Conditions.Ready != nil && !*endpoint.Conditions.Ready {
+				continue
+			}
+			addresses = append(addresses, endpoint.Addresses...)
+		}
+	}
+	if len(addresses) == 0 {
+		return "<none>"
+	}
+	sort.Strings(addresses)
+	return strings.Join(addresses, ", ")
+}
+
+// describePods lists the pods Kaito labels with the workspace name
+func describePods(w io.Writer, clientset kubernetes.Interface, namespace, name string) {
+	fmt.Fprintln(w, "Pods:")
+	pods, err := clientset.CoreV1().Pods(namespace).List(context.TODO(), metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("kaito.sh/workspace=%s", name),
+	})
+	if err != nil {
+		fmt.Fprintf(w, "  <error: %v>\n", err)
+		return
+	}
+	if len(pods.Items) == 0 {
+		fmt.Fprintln(w, "  <none>")
+		return
+	}
+
+	sort.Slice(pods.Items, func(i, j int) bool { return pods.Items[i].Name < pods.Items[j].Name })
+	fmt.Fprintln(w, "  NAME\tSTATUS\tRESTARTS\tNODE\tAGE")
+	for _, pod := range pods.Items {
+		phase, restarts := podPhaseAndRestarts(&pod)
+		node := pod.Spec.NodeName
+		if node == "" {
+			node = "<none>"
+		}
+		age := "Unknown"
+		if !pod.CreationTimestamp.IsZero() {
+			age = shortDuration(time.Since(pod.CreationTimestamp.Time))
+		}
+		fmt.Fprintf(w, "  %s\t%s\t%d\t%s\t%s\n", pod.Name, phase, restarts, node, age)
+	}
+}
+
+func describeEvents(w io.Writer, clientset kubernetes.Interface, namespace, name string) {
+	fmt.Fprintln(w, "Events:")
+	events, err := workspaceEvents(context.TODO(), clientset, namespace, name)
+	if err != nil {
+		fmt.Fprintf(w, "  <error: %v>\n", err)
+		return
+	}
+	if len(events) == 0 {
+		fmt.Fprintln(w, "  <none>")
+		return
+	}
+	if len(events) > maxStatusEvents {
+		events = events[len(events)-maxStatusEvents:]
+	}
+
+	fmt.Fprintln(w, "  AGE\tTYPE\tREASON\tOBJECT\tMESSAGE")
+	for _, event := range events {
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%s/%s\t%s\n",
+			eventAge(event), event.Type, event.Reason,
+			strings.ToLower(event.InvolvedObject.Kind), event.InvolvedObject.Name,
+			strings.TrimSpace(event.Message))
+	}
+}
@@ -0,0 +1,96 @@
+/*
+Copyright (c) 2024 Kaito Project
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestDescribeCmd(t *testing.T) {
+	configFlags := genericclioptions.NewConfigFlags(true)
+	cmd := NewDescribeCmd(configFlags)
+
+	assert.Equal(t, "describe", cmd.Use)
+	assert.NotNil(t, cmd.Flags().Lookup("workspace-name"))
+	assert.NotNil(t, cmd.Flags().Lookup("namespace"))
+
+	o := &DescribeOptions{}
+	assert.Error(t, o.validate())
+}
+
+func TestDescribeWorkspace(t *testing.T) {
+	workspace := newTestWorkspace("my-ws", "default", map[string]string{"ResourceReady": "True"})
+	workspace.SetLabels(map[string]string{"team": "ml", "app": "llm"})
+	workspace.Object["resource"] = map[string]interface{}{"instanceType": "Standard_NC6s_v3", "count": int64(1)}
+	workspace.Object["inference"] = map[string]interface{}{"preset": map[string]interface{}{"name": "phi-4"}}
+	assert.NoError(t, unstructured.SetNestedStringSlice(workspace.Object, []string{"node-1"}, "status", "workerNodes"))
+
+	ready := true
+	clientset := fake.NewSimpleClientset(
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-ws", Namespace: "default"},
+			Spec: corev1.ServiceSpec{
+				Type:      corev1.ServiceTypeClusterIP,
+				ClusterIP: "10.0.0.10",
+				Ports:     []corev1.ServicePort{{Port: 80, Protocol: corev1.ProtocolTCP}},
+			},
+		},
+		&discoveryv1.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-ws-abc", Namespace: "default",
+				Labels: map[string]string{discoveryv1.LabelServiceName: "my-ws"}},
+			Endpoints: []discoveryv1.Endpoint{
+				{Addresses: []string{"10.244.0.5"}, Conditions: discoveryv1.EndpointConditions{Ready: &ready}},
+			},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-ws-0", Namespace: "default",
+				Labels: map[string]string{"kaito.sh/workspace": "my-ws"}},
+			Spec:   corev1.PodSpec{NodeName: "node-1"},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning},
+		},
+	)
+
+	var out bytes.Buffer
+	assert.NoError(t, describeWorkspace(&out, workspace, clientset))
+	report := out.String()
+
+	assert.Contains(t, report, "Labels:       app=llm\n              team=ml\n")
+	assert.Contains(t, report, "Resource:\n  count: 1\n  instanceType: Standard_NC6s_v3\n")
+	assert.Contains(t, report, "Inference:\n  preset:\n    name: phi-4\n")
+	assert.Contains(t, report, "ResourceReady")
+	assert.Contains(t, report, "Worker Nodes:\n  node-1\n")
+	assert.Contains(t, report, "10.0.0.10")
+	assert.Contains(t, report, "Endpoints:   10.244.0.5")
+	assert.Contains(t, report, "my-ws-0")
+	assert.Contains(t, report, "Running")
+	assert.Contains(t, report, "Events:\n  <none>\n")
+
+	// A workspace whose service is not created yet still describes
+	out.Reset()
+	assert.NoError(t, describeWorkspace(&out, workspace, fake.NewSimpleClientset()))
+	assert.Contains(t, out.String(), "Service:\n  <not created yet>\n")
+	assert.Contains(t, out.String(), "Pods:\n  <none>\n")
+}
@@ -0,0 +1,286 @@
+/*
+Copyright (c) 2024 Kaito Project
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/klog/v2"
+)
+
+// EmbedOptions holds the options for the embed command
+type EmbedOptions struct {
+	configFlags *genericclioptions.ConfigFlags
+	clients     *clientFactory
+
+	WorkspaceName string
+	Namespace     string
+	Inputs        []string
+	InputFile     string
+	DimsOnly      bool
+	Endpoint      string
+	Scheme        string
+	Port          int
+	Retries       int
+}
+
+// NewEmbedCmd creates the embed command
+func NewEmbedCmd(configFlags *genericclioptions.ConfigFlags) *cobra.Command {
+	o := &EmbedOptions{
+		configFlags: configFlags,
+		clients:     newClientFactory(configFlags),
+	}
+
+	cmd := &cobra.Command{
+		Use:   "embed",
+		Short: "Create embeddings with a deployed embedding model",
+		Long: `Embed sends text to the OpenAI-compatible /v1/embeddings endpoint of a
+deployed Kaito workspace and prints the returned vectors, one JSON array per
+input in input order.
+
+Inputs are taken from --input (repeatable), from --input-file (one input per
+line), or from stdin (one input per line) when input is piped.`,
+		Example: `  # Embed two sentences
+  kubectl kaito embed --workspace-name my-embedder --input "hello world" --input "kubernetes"
+
+  # Check the vector size of the deployed model
+  kubectl kaito embed --workspace-name my-embedder --input test --dims-only
+
+  # Embed every line of a file
+  kubectl kaito embed --workspace-name my-embedder --input-file sentences.txt`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.validate(); err != nil {
+				klog.Errorf("Validation failed: %v", err)
+				return fmt.Errorf("validation failed: %w", err)
+			}
+			ctx, stop := withInterrupt(cmd.Context())
+			defer stop()
+			return interruptedError(ctx, o.run(ctx))
+		},
+	}
+
+	cmd.Flags().StringVar(&o.WorkspaceName, "workspace-name", "", "Name of the workspace (required)")
+	cmd.Flags().StringVarP(&o.Namespace, "namespace", "n", "", "Kubernetes namespace")
+	cmd.Flags().StringArrayVar(&o.Inputs, "input", nil, "Text to embed (repeatable)")
+	cmd.Flags().StringVar(&o.InputFile, "input-file", "", "File with one input to embed per line")
+	cmd.Flags().BoolVar(&o.DimsOnly, "dims-only", false, "Print only the number of dimensions of each vector")
+	cmd.Flags().StringVar(&o.Endpoint, "endpoint", "", "Base URL of the inference endpoint; skips service discovery (e.g. http://localhost:8080)")
+	cmd.Flags().IntVar(&o.Port, "port", 0, "Service port of the inference endpoint (detected from the service ports by default)")
+	cmd.Flags().StringVar(&o.Scheme, "scheme", "", "Scheme for the inference endpoint: http or https (detected from the service ports by default)")
+	cmd.Flags().IntVar(&o.Retries, "retries", defaultChatRetries, "Retries for requests that fail with a 5xx status or connection error (0 disables retries)")
+
+	if err := cmd.MarkFlagRequired("workspace-name"); err != nil {
+		klog.Errorf("Failed to mark workspace-name flag as required: %v", err)
+	}
+
+	return cmd
+}
+
+func (o *EmbedOptions) validate() error {
+	klog.V(4).Info("Validating embed options")
+
+	if o.WorkspaceName == "" {
+		return fmt.Errorf("workspace name is required")
+	}
+	if len(o.Inputs) > 0 && o.InputFile != "" {
+		return fmt.Errorf("--input and --input-file cannot be used together")
+	}
+	if o.Retries < 0 {
+		return fmt.Errorf("retries must be 0 or greater")
+	}
+	if err := validateScheme(o.Scheme); err != nil {
+		return err
+	}
+	if err := validatePort(o.Port); err != nil {
+		return err
+	}
+	if o.Endpoint != "" {
+		if err := validateEndpointURL(o.Endpoint); err != nil {
+			return err
+		}
+		if o.Scheme != "" || o.Port != 0 {
+			return fmt.Errorf("--scheme and --port cannot be used with --endpoint")
+		}
+	}
+
+	klog.V(4).Info("Embed validation completed successfully")
+	return nil
+}
+
+func (o *EmbedOptions) run(ctx context.Context) error {
+	klog.V(2).Infof("Creating embeddings with workspace: %s", o.WorkspaceName)
+
+	if err := o.loadInputs(); err != nil {
+		return err
+	}
+
+	// Get namespace
+	o.Namespace = resolveNamespace(o.configFlags, o.Namespace)
+
+	target := inferenceTarget{
+		clients:       o.clients,
+		WorkspaceName: o.WorkspaceName,
+		Namespace:     o.Namespace,
+		Endpoint:      o.Endpoint,
+		Scheme:        o.Scheme,
+		Port:          o.Port,
+	}
+	baseURL, err := target.baseURL(ctx)
+	if err != nil {
+		return err
+	}
+
+	vectors, err := o.embed(ctx, appendAPIPath(baseURL, "/v1/embeddings"))
+	if err != nil {
+		return err
+	}
+
+	return o.printVectors(os.Stdout, vectors)
+}
+
+// loadInputs fills Inputs from --input-file or piped stdin when no --input is given
+func (o *EmbedOptions) loadInputs() error {
+	if len(o.Inputs) > 0 {
+		return nil
+	}
+
+	switch {
+	case o.InputFile != "":
+		file, err := os.Open(o.InputFile)
+		if err != nil {
+			return fmt.Errorf("failed to open input file: %w", err)
+		}
+		defer file.Close()
+		inputs, err := readInputLines(file)
+		if err != nil {
+			return fmt.Errorf("failed to read input file: %w", err)
+		}
+		o.Inputs = inputs
+	case !stdinIsTerminal():
+		inputs, err := readInputLines(os.Stdin)
+		if err != nil {
+			return fmt.Errorf("failed to read inputs from stdin: %w", err)
+		}
+		o.Inputs = inputs
+	}
+
+	if len(o.Inputs) == 0 {
+		return fmt.Errorf("no input to embed; use --input, --input-file or pipe text on stdin")
+	}
+	return nil
+}
+
+// readInputLines returns the non-blank lines of r
+func readInputLines(r io.Reader) ([]string, error) {
+	var inputs []string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			inputs = append(inputs, line)
+		}
+	}
+	return inputs, scanner.Err()
+}
+
+// embed posts the inputs to an embeddings endpoint and returns the vectors in input order
+func (o *EmbedOptions) embed(ctx context.Context, endpoint string) ([][]float64, error) {
+	klog.V(4).Infof("Sending embeddings request to endpoint: %s", endpoint)
+
+	jsonData, err := json.Marshal(map[string]interface{}{
+		"input": o.Inputs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	response, err := postJSONWithRetries(ctx, o.clients, endpoint, jsonData, defaultEndpointTimeout, o.Retries)
+	if err != nil {
+		return nil, err
+	}
+
+	vectors, err := extractEmbeddings(response)
+	if err != nil {
+		return nil, err
+	}
+	if len(vectors) != len(o.Inputs) {
+		return nil, fmt.Errorf("unexpected response: got %d embeddings for %d inputs", len(vectors), len(o.Inputs))
+	}
+	return vectors, nil
+}
+
+// extractEmbeddings reads data[].embedding from a /v1/embeddings response, ordered by data[].index
+func extractEmbeddings(response map[string]interface{}) ([][]float64, error) {
+	data, ok := response["data"].([]interface{})
+	if !ok || len(data) == 0 {
+		return nil, fmt.Errorf("unexpected response format: no data")
+	}
+
+	vectors := make([][]float64, len(data))
+	for i, item := range data {
+		entry, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("unexpected response format: invalid data entry")
+		}
+		index := i
+		if value, ok := entry["index"].(float64); ok {
+			index = int(value)
+		}
+		if index < 0 || index >= len(vectors) || vectors[index] != nil {
+			return nil, fmt.Errorf("unexpected response format: invalid embedding index %d", index)
+		}
+
+		values, ok := entry["embedding"].([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("unexpected response format: no embedding (base64 encoding is not supported)")
+		}
+		vector := make([]float64, len(values))
+		for j, value := range values {
+			number, ok := value.(float64)
+			if !ok {
+				return nil, fmt.Errorf("unexpected response format: non-numeric embedding value")
+			}
+			vector[j] = number
+		}
+		vectors[index] = vector
+	}
+	return vectors, nil
+}
+
+// printVectors writes one JSON array per vector, or its dimensions with --dims-only
+func (o *EmbedOptions) printVectors(out io.Writer, vectors [][]float64) error {
+	for i, vector := range vectors {
+		if o.DimsOnly {
+			fmt.Fprintf(out, "Input %d: %d dimensions\n", i+1, len(vector))
+			continue
+		}
+		line, err := json.Marshal(vector)
+		if err != nil {
+			return fmt.Errorf("failed to marshal embedding: %w", err)
+		}
+		fmt.Fprintln(out, string(line))
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+/*
+Copyright (c) 2024 Kaito Project
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func TestNewEmbedCmd(t *testing.T) {
+	cmd := NewEmbedCmd(genericclioptions.NewConfigFlags(true))
+
+	assert.Equal(t, "embed", cmd.Use)
+	assert.NotEmpty(t, cmd.Long)
+	assert.NotEmpty(t, cmd.Example)
+	for _, flagName := range []string{"workspace-name", "input", "input-file", "dims-only", "endpoint", "retries"} {
+		assert.NotNil(t, cmd.Flags().Lookup(flagName), "Flag %s should be present", flagName)
+	}
+}
+
+func TestEmbedOptionsValidation(t *testing.T) {
+	assert.NoError(t, (&EmbedOptions{WorkspaceName: "test", Inputs: []string{"hi"}}).validate())
+	assert.Error(t, (&EmbedOptions{Inputs: []string{"hi"}}).validate())
+	assert.Error(t, (&EmbedOptions{WorkspaceName: "test", Inputs: []string{"hi"}, InputFile: "inputs.txt"}).validate())
+	assert.Error(t, (&EmbedOptions{WorkspaceName: "test", Endpoint: "localhost:8080"}).validate())
+}
+
+func TestEmbed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/embeddings", r.URL.Path)
+
+		var payload map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		assert.Equal(t, []interface{}{"hello", "world"}, payload["input"])
+
+		// Entries may come back out of order; index says which input they belong to
+		_, _ = w.Write([]byte(`{"data":[{"index":1,"embedding":[0.5,0.25,1]},{"index":0,"embedding":[0.1,0.2,0.3]}]}`))
+	}))
+	defer server.Close()
+
+	o := &EmbedOptions{Inputs: []string{"hello", "world"}}
+	vectors, err := o.embed(context.TODO(), appendAPIPath(server.URL, "/v1/embeddings"))
+	assert.NoError(t, err)
+	assert.Equal(t, [][]float64{{0.1, 0.2, 0.3}, {0.5, 0.25, 1}}, vectors)
+
+	var out bytes.Buffer
+	assert.NoError(t, o.printVectors(&out, vectors))
+	assert.Equal(t, "[0.1,0.2,0.3]\n[0.5,0.25,1]\n", out.String())
+
+	out.Reset()
+	o.DimsOnly = true
+	assert.NoError(t, o.printVectors(&out, vectors))
+	assert.Equal(t, "Input 1: 3 dimensions\nInput 2: 3 dimensions\n", out.String())
+}
+
+func TestExtractEmbeddings(t *testing.T) {
+	_, err := extractEmbeddings(map[string]interface{}{"data": []interface{}{}})
+	assert.Error(t, err)
+
+	_, err = extractEmbeddings(map[string]interface{}{
+		"data": []interface{}{map[string]interface{}{"index": float64(0), "embedding": "AAAA"}},
+	})
+	assert.ErrorContains(t, err, "base64")
+}
+
+func TestReadInputLines(t *testing.T) {
+	inputs, err := readInputLines(strings.NewReader("first line\n\n  second line  \n"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"first line", "second line"}, inputs)
+}
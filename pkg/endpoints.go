@@ -0,0 +1,243 @@
+/*
+Copyright (c) 2024 Kaito Project
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+)
+
+// EndpointsOptions holds the options for the endpoints command
+type EndpointsOptions struct {
+	configFlags *genericclioptions.ConfigFlags
+	clients     *clientFactory
+
+	Namespace     string
+	AllNamespaces bool
+}
+
+// workspaceEndpoints are the endpoints of one workspace, or why it has none
+type workspaceEndpoints struct {
+	Namespace string
+	Name      string
+	Endpoints []EndpointInfo
+	// Skipped explains why the workspace has no endpoints, e.g. that it is not ready
+	Skipped string
+}
+
+// NewEndpointsCmd creates the endpoints command
+func NewEndpointsCmd(configFlags *genericclioptions.ConfigFlags) *cobra.Command {
+	o := &EndpointsOptions{
+		configFlags: configFlags,
+		clients:     newClientFactory(configFlags),
+	}
+
+	cmd := &cobra.Command{
+		Use:   "endpoints",
+		Short: "List the inference endpoints of all workspaces",
+		Long: `Endpoints lists the inference endpoints of every workspace in a namespace,
+or in all namespaces with -A, resolved the same way as 'get-endpoint'.
+
+Each endpoint is shown with its type (LoadBalancer, APIProxy or ClusterIP) and
+access (external, cluster or internal). Workspaces that are not ready, and
+tuning workspaces, have no inference endpoint and are listed below the table.`,
+		Example: `  # List the endpoints of the workspaces in the current namespace
+  kubectl kaito endpoints
+
+  # List the endpoints of every workspace in the cluster
+  kubectl kaito endpoints -A`,
+		Annotations: map[string]string{allNamespacesAnnotation: "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// -A/--all-namespaces is a global flag
+			o.AllNamespaces, _ = cmd.Flags().GetBool("all-namespaces")
+			if err := o.validate(); err != nil {
+				klog.Errorf("Validation failed: %v", err)
+				return fmt.Errorf("validation failed: %w", err)
+			}
+			ctx, stop := withInterrupt(cmd.Context())
+			defer stop()
+			return interruptedError(ctx, o.run(ctx))
+		},
+	}
+
+	cmd.Flags().StringVarP(&o.Namespace, "namespace", "n", "", "Kubernetes namespace")
+
+	return cmd
+}
+
+func (o *EndpointsOptions) validate() error {
+	klog.V(4).Info("Validating endpoints options")
+
+	if o.AllNamespaces && o.Namespace != "" {
+		return fmt.Errorf("--namespace cannot be used with --all-namespaces")
+	}
+	return nil
+}
+
+func (o *EndpointsOptions) run(ctx context.Context) error {
+	klog.V(2).Info("Listing workspace endpoints")
+
+	// Get namespace
+	if !o.AllNamespaces {
+		o.Namespace = resolveNamespace(o.configFlags, o.Namespace)
+	}
+
+	dynamicClient, err := o.clients.DynamicClient()
+	if err != nil {
+		return err
+	}
+
+	clientset, err := o.clients.KubernetesClient()
+	if err != nil {
+		return err
+	}
+
+	entries, err := o.collectEndpoints(ctx, dynamicClient, clientset)
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		if o.AllNamespaces {
+			fmt.Println("No workspaces found")
+		} else {
+			fmt.Printf("No workspaces found in namespace %s\n", o.Namespace)
+		}
+		return nil
+	}
+
+	for _, note := range printEndpointsTable(os.Stdout, entries, o.AllNamespaces) {
+		fmt.Println(dimText(os.Stdout, note))
+	}
+	return nil
+}
+
+// collectEndpoints resolves the endpoints of every workspace in the namespace, or
+// in all namespaces, sorted by namespace and name
+func (o *EndpointsOptions) collectEndpoints(ctx context.Context, dynamicClient dynamic.Interface, clientset kubernetes.Interface) ([]workspaceEndpoints, error) {
+	gvr := schema.GroupVersionResource{
+		Group:    "kaito.sh",
+		Version:  "v1beta1",
+		Resource: "workspaces",
+	}
+
+	// An empty namespace lists across all namespaces
+	workspaces, err := dynamicClient.Resource(gvr).Namespace(o.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		klog.Errorf("Failed to list workspaces: %v", err)
+		return nil, fmt.Errorf("failed to list workspaces: %w", err)
+	}
+
+	items := workspaces.Items
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].GetNamespace() != items[j].GetNamespace() {
+			return items[i].GetNamespace() < items[j].GetNamespace()
+		}
+		return items[i].GetName() < items[j].GetName()
+	})
+
+	entries := make([]workspaceEndpoints, 0, len(items))
+	for i := range items {
+		workspace := &items[i]
+		entry := workspaceEndpoints{Namespace: workspace.GetNamespace(), Name: workspace.GetName()}
+
+		if _, tuning := workspace.Object["tuning"]; tuning {
+			entry.Skipped = "tuning workspace, no inference endpoint"
+			entries = append(entries, entry)
+			continue
+		}
+		if !isWorkspaceReady(workspace.Object["status"]) {
+			entry.Skipped = "not ready"
+			if pending := pendingCondition(workspace); pending != "" {
+				entry.Skipped = fmt.Sprintf("not ready (%s)", pending)
+			}
+			entries = append(entries, entry)
+			continue
+		}
+
+		target := &GetEndpointOptions{
+			configFlags:   o.configFlags,
+			clients:       o.clients,
+			WorkspaceName: entry.Name,
+			Namespace:     entry.Namespace,
+		}
+		endpoints, err := target.getAllEndpoints(ctx, clientset)
+		if err != nil {
+			klog.V(3).Infof("Could not get endpoints for workspace %s/%s: %v", entry.Namespace, entry.Name, err)
+			entry.Skipped = err.Error()
+		}
+		entry.Endpoints = endpoints
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// printEndpointsTable prints one row per endpoint and returns a note for every
+// workspace without endpoints
+func printEndpointsTable(out io.Writer, entries []workspaceEndpoints, showNamespace bool) []string {
+	var notes []string
+	rows := 0
+
+	w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	columns := "WORKSPACE\tTYPE\tACCESS\tURL"
+	if showNamespace {
+		columns = "NAMESPACE\t" + columns
+	}
+
+	for _, entry := range entries {
+		name := entry.Name
+		if showNamespace {
+			name = entry.Namespace + "/" + entry.Name
+		}
+		if len(entry.Endpoints) == 0 {
+			notes = append(notes, fmt.Sprintf("- %s: %s", name, entry.Skipped))
+			continue
+		}
+
+		if rows == 0 {
+			fmt.Fprintln(w, columns)
+		}
+		for _, endpoint := range entry.Endpoints {
+			row := fmt.Sprintf("%s\t%s\t%s\t%s", entry.Name, endpoint.Type, endpoint.Access, endpoint.URL)
+			if showNamespace {
+				row = entry.Namespace + "\t" + row
+			}
+			fmt.Fprintln(w, row)
+			rows++
+		}
+	}
+	w.Flush()
+
+	if len(notes) > 0 {
+		if rows > 0 {
+			fmt.Fprintln(out)
+		}
+		notes = append([]string{"Workspaces without endpoints:"}, notes...)
+	}
+	return notes
+}
@@ -0,0 +1,109 @@
+/*
+Copyright (c) 2024 Kaito Project
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+)
+
+func TestEndpointsCmd(t *testing.T) {
+	cmd := NewEndpointsCmd(genericclioptions.NewConfigFlags(true))
+
+	assert.Equal(t, "endpoints", cmd.Use)
+	assert.Equal(t, "true", cmd.Annotations[allNamespacesAnnotation])
+	assert.NoError(t, (&EndpointsOptions{AllNamespaces: true}).validate())
+	assert.Error(t, (&EndpointsOptions{AllNamespaces: true, Namespace: "default"}).validate())
+}
+
+func TestCollectEndpoints(t *testing.T) {
+	ready := map[string]string{"ResourceReady": "True", "InferenceReady": "True"}
+	notReady := map[string]string{"ResourceReady": "True", "InferenceReady": "False"}
+
+	tuning := newTestWorkspace("tune-ws", "default", map[string]string{"ResourceReady": "True", "JobStarted": "True"})
+	tuning.Object["tuning"] = map[string]interface{}{"method": "qlora"}
+	dynamicClient := newFakeDynamicClient(
+		newTestWorkspace("phi-ws", "default", ready),
+		newTestWorkspace("llama-ws", "default", notReady),
+		newTestWorkspace("missing-svc", "default", ready),
+		newTestWorkspace("other-ws", "team-a", ready),
+		tuning,
+	)
+	newService := func(name, namespace string) *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: corev1.ServiceSpec{
+				ClusterIP: "10.0.0.10",
+				Ports:     []corev1.ServicePort{{Name: "http", Port: 80}},
+			},
+		}
+	}
+	clientset := fake.NewSimpleClientset(newService("phi-ws", "default"), newService("other-ws", "team-a"))
+	clients := &clientFactory{
+		restConfig:    &rest.Config{Host: "https://api.example.com"},
+		dynamicClient: dynamicClient,
+		clientset:     clientset,
+	}
+
+	t.Run("Single namespace", func(t *testing.T) {
+		o := &EndpointsOptions{clients: clients, Namespace: "default"}
+		entries, err := o.collectEndpoints(context.TODO(), dynamicClient, clientset)
+		assert.NoError(t, err)
+
+		names := make([]string, len(entries))
+		for i, entry := range entries {
+			names[i] = entry.Name
+		}
+		assert.Equal(t, []string{"llama-ws", "missing-svc", "phi-ws", "tune-ws"}, names)
+		assert.Equal(t, "not ready (InferenceReady=False)", entries[0].Skipped)
+		assert.Contains(t, entries[1].Skipped, "service for workspace missing-svc not found")
+		assert.Equal(t, "tuning workspace, no inference endpoint", entries[3].Skipped)
+
+		assert.Len(t, entries[2].Endpoints, 1)
+		assert.Equal(t, "APIProxy", entries[2].Endpoints[0].Type)
+		assert.Equal(t, "https://api.example.com/api/v1/namespaces/default/services/phi-ws:80/proxy", entries[2].Endpoints[0].URL)
+
+		var out bytes.Buffer
+		notes := printEndpointsTable(&out, entries, false)
+		assert.Contains(t, out.String(), "WORKSPACE  TYPE      ACCESS   URL")
+		assert.Contains(t, out.String(), "phi-ws     APIProxy  cluster  https://api.example.com/")
+		assert.Equal(t, "Workspaces without endpoints:", notes[0])
+		assert.Contains(t, notes, "- llama-ws: not ready (InferenceReady=False)")
+	})
+
+	t.Run("All namespaces", func(t *testing.T) {
+		o := &EndpointsOptions{clients: clients, AllNamespaces: true}
+		entries, err := o.collectEndpoints(context.TODO(), dynamicClient, clientset)
+		assert.NoError(t, err)
+		assert.Len(t, entries, 5)
+		assert.Equal(t, "team-a", entries[4].Namespace)
+
+		var out bytes.Buffer
+		notes := printEndpointsTable(&out, entries, true)
+		assert.Contains(t, out.String(), "NAMESPACE  WORKSPACE")
+		assert.Contains(t, out.String(), "team-a     other-ws")
+		assert.Contains(t, notes, "- default/tune-ws: tuning workspace, no inference endpoint")
+	})
+}
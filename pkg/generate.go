@@ -0,0 +1,217 @@
+/*
+Copyright (c) 2024 Kaito Project
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/klog/v2"
+)
+
+// GenerateOptions holds the options for the generate command
+type GenerateOptions struct {
+	configFlags *genericclioptions.ConfigFlags
+	clients     *clientFactory
+
+	WorkspaceName string
+	Namespace     string
+	Prompt        string
+	Endpoint      string
+	Scheme        string
+	Port          int
+	Temperature   float64
+	MaxTokens     int
+	TopP          float64
+	Retries       int
+}
+
+// NewGenerateCmd creates the generate command
+func NewGenerateCmd(configFlags *genericclioptions.ConfigFlags) *cobra.Command {
+	o := &GenerateOptions{
+		configFlags: configFlags,
+		clients:     newClientFactory(configFlags),
+	}
+
+	cmd := &cobra.Command{
+		Use:   "generate",
+		Short: "Generate a text completion from a deployed model",
+		Long: `Generate sends a prompt to the OpenAI-compatible /v1/completions endpoint
+of a deployed Kaito workspace and prints the generated text.
+
+Use it for base (non-instruct) models that do not serve /v1/chat/completions.
+The prompt is read from --prompt, or from stdin when input is piped.`,
+		Example: `  # Complete a prompt
+  kubectl kaito generate --workspace-name my-falcon --prompt "Once upon a time"
+
+  # Read the prompt from a file
+  cat prompt.txt | kubectl kaito generate --workspace-name my-falcon --max-tokens 256`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.validate(); err != nil {
+				klog.Errorf("Validation failed: %v", err)
+				return fmt.Errorf("validation failed: %w", err)
+			}
+			ctx, stop := withInterrupt(cmd.Context())
+			defer stop()
+			return interruptedError(ctx, o.run(ctx))
+		},
+	}
+
+	cmd.Flags().StringVar(&o.WorkspaceName, "workspace-name", "", "Name of the workspace (required)")
+	cmd.Flags().StringVarP(&o.Namespace, "namespace", "n", "", "Kubernetes namespace")
+	cmd.Flags().StringVar(&o.Prompt, "prompt", "", "Prompt to complete (read from stdin when omitted)")
+	cmd.Flags().Float64Var(&o.Temperature, "temperature", 0.7, "Temperature for response generation (0.0-2.0)")
+	cmd.Flags().IntVar(&o.MaxTokens, "max-tokens", 1024, "Maximum tokens in response")
+	cmd.Flags().Float64Var(&o.TopP, "top-p", 0.9, "Top-p (nucleus sampling) parameter (0.0-1.0)")
+	cmd.Flags().StringVar(&o.Endpoint, "endpoint", "", "Base URL of the inference endpoint; skips service discovery (e.g. http://localhost:8080)")
+	cmd.Flags().IntVar(&o.Port, "port", 0, "Service port of the inference endpoint (detected from the service ports by default)")
+	cmd.Flags().StringVar(&o.Scheme, "scheme", "", "Scheme for the inference endpoint: http or https (detected from the service ports by default)")
+	cmd.Flags().IntVar(&o.Retries, "retries", defaultChatRetries, "Retries for requests that fail with a 5xx status or connection error (0 disables retries)")
+
+	if err := cmd.MarkFlagRequired("workspace-name"); err != nil {
+		klog.Errorf("Failed to mark workspace-name flag as required: %v", err)
+	}
+
+	return cmd
+}
+
+func (o *GenerateOptions) validate() error {
+	klog.V(4).Info("Validating generate options")
+
+	if o.WorkspaceName == "" {
+		return fmt.Errorf("workspace name is required")
+	}
+	if o.Temperature < 0.0 || o.Temperature > 2.0 {
+		return fmt.Errorf("temperature must be between 0.0 and 2.0")
+	}
+	if o.TopP < 0.0 || o.TopP > 1.0 {
+		return fmt.Errorf("top-p must be between 0.0 and 1.0")
+	}
+	if o.MaxTokens <= 0 {
+		return fmt.Errorf("max-tokens must be greater than 0")
+	}
+	if o.Retries < 0 {
+		return fmt.Errorf("retries must be 0 or greater")
+	}
+	if err := validateScheme(o.Scheme); err != nil {
+		return err
+	}
+	if err := validatePort(o.Port); err != nil {
+		return err
+	}
+	if o.Endpoint != "" {
+		if err := validateEndpointURL(o.Endpoint); err != nil {
+			return err
+		}
+		if o.Scheme != "" || o.Port != 0 {
+			return fmt.Errorf("--scheme and --port cannot be used with --endpoint")
+		}
+	}
+
+	klog.V(4).Info("Generate validation completed successfully")
+	return nil
+}
+
+func (o *GenerateOptions) run(ctx context.Context) error {
+	klog.V(2).Infof("Generating completion with workspace: %s", o.WorkspaceName)
+
+	if o.Prompt == "" {
+		if stdinIsTerminal() {
+			return fmt.Errorf("a prompt is required; use --prompt or pipe it on stdin")
+		}
+		prompt, err := readPipedPrompt(os.Stdin)
+		if err != nil {
+			return err
+		}
+		o.Prompt = prompt
+	}
+
+	// Get namespace
+	o.Namespace = resolveNamespace(o.configFlags, o.Namespace)
+
+	target := inferenceTarget{
+		clients:       o.clients,
+		WorkspaceName: o.WorkspaceName,
+		Namespace:     o.Namespace,
+		Endpoint:      o.Endpoint,
+		Scheme:        o.Scheme,
+		Port:          o.Port,
+	}
+	baseURL, err := target.baseURL(ctx)
+	if err != nil {
+		return err
+	}
+
+	text, err := o.generate(ctx, appendAPIPath(baseURL, "/v1/completions"))
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(text)
+	return nil
+}
+
+// generate posts the prompt to a completions endpoint and returns the generated text
+func (o *GenerateOptions) generate(ctx context.Context, endpoint string) (string, error) {
+	klog.V(4).Infof("Sending completion request to endpoint: %s", endpoint)
+
+	jsonData, err := json.Marshal(o.buildRequestPayload())
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	response, err := postJSONWithRetries(ctx, o.clients, endpoint, jsonData, defaultEndpointTimeout, o.Retries)
+	if err != nil {
+		return "", err
+	}
+
+	return extractCompletionText(response)
+}
+
+func (o *GenerateOptions) buildRequestPayload() map[string]interface{} {
+	return map[string]interface{}{
+		"prompt":      o.Prompt,
+		"temperature": o.Temperature,
+		"max_tokens":  o.MaxTokens,
+		"top_p":       o.TopP,
+	}
+}
+
+// extractCompletionText reads choices[0].text from a /v1/completions response
+func extractCompletionText(response map[string]interface{}) (string, error) {
+	choices, ok := response["choices"].([]interface{})
+	if !ok || len(choices) == 0 {
+		return "", fmt.Errorf("unexpected response format: no choices")
+	}
+
+	choice, ok := choices[0].(map[string]interface{})
+	if !ok {
+		return "", fmt.Errorf("unexpected response format: invalid choice")
+	}
+
+	text, ok := choice["text"].(string)
+	if !ok {
+		return "", fmt.Errorf("unexpected response format: no text")
+	}
+
+	return strings.TrimSpace(text), nil
+}
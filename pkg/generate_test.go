@@ -0,0 +1,88 @@
+/*
+Copyright (c) 2024 Kaito Project
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func TestNewGenerateCmd(t *testing.T) {
+	cmd := NewGenerateCmd(genericclioptions.NewConfigFlags(true))
+
+	assert.Equal(t, "generate", cmd.Use)
+	assert.NotEmpty(t, cmd.Long)
+	assert.NotEmpty(t, cmd.Example)
+	for _, flagName := range []string{"workspace-name", "prompt", "max-tokens", "temperature", "top-p", "endpoint", "retries"} {
+		assert.NotNil(t, cmd.Flags().Lookup(flagName), "Flag %s should be present", flagName)
+	}
+}
+
+func TestGenerateOptionsValidation(t *testing.T) {
+	valid := func() *GenerateOptions {
+		return &GenerateOptions{WorkspaceName: "test", Temperature: 0.7, TopP: 0.9, MaxTokens: 128}
+	}
+
+	assert.NoError(t, valid().validate())
+
+	missingName := valid()
+	missingName.WorkspaceName = ""
+	assert.Error(t, missingName.validate())
+
+	badTemperature := valid()
+	badTemperature.Temperature = 3
+	assert.Error(t, badTemperature.validate())
+
+	badEndpoint := valid()
+	badEndpoint.Endpoint = "localhost:8080"
+	assert.Error(t, badEndpoint.validate())
+}
+
+func TestGenerate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/completions", r.URL.Path)
+
+		var payload map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		assert.Equal(t, "Once upon a time", payload["prompt"])
+		assert.NotContains(t, payload, "messages")
+
+		_, _ = w.Write([]byte(`{"choices":[{"text":" there was a cluster.\n"}]}`))
+	}))
+	defer server.Close()
+
+	o := &GenerateOptions{Prompt: "Once upon a time", MaxTokens: 16}
+	text, err := o.generate(context.TODO(), appendAPIPath(server.URL, "/v1/completions"))
+	assert.NoError(t, err)
+	assert.Equal(t, "there was a cluster.", text)
+}
+
+func TestExtractCompletionText(t *testing.T) {
+	_, err := extractCompletionText(map[string]interface{}{"choices": []interface{}{}})
+	assert.Error(t, err)
+
+	_, err = extractCompletionText(map[string]interface{}{
+		"choices": []interface{}{map[string]interface{}{"message": map[string]interface{}{"content": "hi"}}},
+	})
+	assert.Error(t, err)
+}
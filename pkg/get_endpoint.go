@@ -21,16 +21,21 @@ import (
 	"encoding/json"
 	"fmt"
 	"net"
+	"os"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
 )
 
 // EndpointInfo represents an available endpoint
@@ -44,15 +49,97 @@ type EndpointInfo struct {
 // GetEndpointOptions holds the options for the get-endpoint command
 type GetEndpointOptions struct {
 	configFlags   *genericclioptions.ConfigFlags
+	clients       *clientFactory
 	WorkspaceName string
 	Namespace     string
 	Format        string
+	Scheme        string
+	Port          int
+	Timeout       time.Duration
+	Wait          bool
+	// Verify probes the endpoints and picks the first that responds
+	Verify bool
+}
+
+// endpointReadyPollInterval is how often get-endpoint --wait re-checks workspace readiness
+var endpointReadyPollInterval = 5 * time.Second
+
+// serviceScheme returns the scheme used to reach svc. An explicit override wins;
+// otherwise https is chosen when a service port is named or declared for TLS.
+func serviceScheme(svc *corev1.Service, override string) string {
+	if override != "" {
+		return override
+	}
+	if svc == nil {
+		return "http"
+	}
+	for _, port := range svc.Spec.Ports {
+		name := strings.ToLower(port.Name)
+		if name == "https" || strings.HasPrefix(name, "https-") || port.Port == 443 {
+			return "https"
+		}
+		if port.AppProtocol != nil && strings.EqualFold(*port.AppProtocol, "https") {
+			return "https"
+		}
+	}
+	return "http"
+}
+
+// defaultServicePort is used when a service declares no usable ports
+const defaultServicePort int32 = 80
+
+// servicePort returns the port used to reach svc. An explicit override wins;
+// otherwise the port named http (or https) is preferred, then the first TCP port.
+func servicePort(svc *corev1.Service, override int) int32 {
+	if override > 0 {
+		return int32(override)
+	}
+	if svc == nil {
+		return defaultServicePort
+	}
+	for _, port := range svc.Spec.Ports {
+		if port.Name == "http" || port.Name == "https" {
+			return port.Port
+		}
+	}
+	for _, port := range svc.Spec.Ports {
+		if port.Protocol == "" || port.Protocol == corev1.ProtocolTCP {
+			return port.Port
+		}
+	}
+	return defaultServicePort
+}
+
+// validatePort checks a --port flag value; 0 means detect from the service
+func validatePort(port int) error {
+	if port < 0 || port > 65535 {
+		return fmt.Errorf("port must be between 1 and 65535")
+	}
+	return nil
+}
+
+// validateScheme checks a --scheme flag value
+func validateScheme(scheme string) error {
+	if scheme != "" && scheme != "http" && scheme != "https" {
+		return fmt.Errorf("scheme must be 'http' or 'https'")
+	}
+	return nil
+}
+
+// apiProxyServiceName returns the service segment of an API proxy path. The
+// API server proxies to https backends only when the service name is prefixed.
+func apiProxyServiceName(name, scheme string, port int32) string {
+	if scheme == "https" {
+		return fmt.Sprintf("https:%s:%d", name, port)
+	}
+	return fmt.Sprintf("%s:%d", name, port)
 }
 
 // NewGetEndpointCmd creates the get-endpoint command
 func NewGetEndpointCmd(configFlags *genericclioptions.ConfigFlags) *cobra.Command {
 	o := &GetEndpointOptions{
 		configFlags: configFlags,
+		clients:     newClientFactory(configFlags),
 	}
 
 	cmd := &cobra.Command{
@@ -68,19 +155,36 @@ requests to the deployed model. The endpoint supports OpenAI-compatible APIs.`,
   # Get endpoint in JSON format with metadata
   kubectl kaito get-endpoint --workspace-name my-workspace --format json
 
-  # Get all available endpoints
-  kubectl kaito get-endpoint --workspace-name my-workspace --format json`,
+  # Get all available endpoints as YAML
+  kubectl kaito get-endpoint --workspace-name my-workspace --format yaml
+
+  # Print a ready-to-run curl command for the endpoint
+  kubectl kaito get-endpoint --workspace-name my-workspace --format curl
+
+  # Print the first endpoint that actually responds, e.g. while a LoadBalancer IP is still being set up
+  kubectl kaito get-endpoint --workspace-name my-workspace --verify
+
+  # Block until the workspace is ready and its LoadBalancer has an external IP, then print its endpoint
+  kubectl kaito get-endpoint --workspace-name my-workspace --wait --timeout 20m`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := o.validate(); err != nil {
 				return err
 			}
-			return o.run()
+			ctx, stop := withInterrupt(cmd.Context())
+			defer stop()
+			return interruptedError(ctx, o.run(ctx))
 		},
 	}
 
 	cmd.Flags().StringVar(&o.WorkspaceName, "workspace-name", "", "Name of the workspace (required)")
 	cmd.Flags().StringVarP(&o.Namespace, "namespace", "n", "", "Kubernetes namespace")
-	cmd.Flags().StringVar(&o.Format, "format", "url", "Output format: url or json")
+	cmd.Flags().StringVar(&o.Format, "format", "url", "Output format: url, json, yaml or curl")
+	cmd.Flags().BoolVar(&o.Wait, "wait", false, "Wait for the workspace to become ready and its service (including a LoadBalancer external IP) to be available")
+	cmd.Flags().BoolVar(&o.Wait, "watch", false, "Alias for --wait")
+	cmd.Flags().DurationVar(&o.Timeout, "timeout", 10*time.Minute, "Maximum time to wait for the endpoint to become available (used with --wait)")
+	cmd.Flags().IntVar(&o.Port, "port", 0, "Service port for endpoint URLs (detected from the service ports by default)")
+	cmd.Flags().BoolVar(&o.Verify, "verify", false, "Probe the endpoints with a GET to /health or /v1/models and use the first that responds (url and curl formats)")
+	cmd.Flags().StringVar(&o.Scheme, "scheme", "", "Scheme for endpoint URLs: http or https (detected from the service ports by default)")
 
 	if err := cmd.MarkFlagRequired("workspace-name"); err != nil {
 		klog.Errorf("Failed to mark workspace-name flag as required: %v", err)
@@ -95,91 +199,191 @@ func (o *GetEndpointOptions) validate() error {
 	if o.WorkspaceName == "" {
 		return fmt.Errorf("workspace name is required")
 	}
-	if o.Format != "url" && o.Format != "json" {
-		return fmt.Errorf("format must be 'url' or 'json'")
+	switch o.Format {
+	case "url", "json", "yaml", "curl":
+	default:
+		return fmt.Errorf("format must be 'url', 'json', 'yaml' or 'curl'")
+	}
+	if err := validateScheme(o.Scheme); err != nil {
+		return err
+	}
+	if err := validatePort(o.Port); err != nil {
+		return err
+	}
+	if o.Verify && o.Format != "url" && o.Format != "curl" {
+		return fmt.Errorf("--verify can only be used with --format url or curl")
+	}
+	if o.Wait && o.Timeout <= 0 {
+		return fmt.Errorf("--timeout must be greater than 0 when --wait is set")
 	}
 
 	klog.V(4).Info("Get-endpoint validation completed successfully")
 	return nil
 }
 
-func (o *GetEndpointOptions) run() error {
+func (o *GetEndpointOptions) run(ctx context.Context) error {
 	klog.V(2).Infof("Getting endpoint for workspace: %s", o.WorkspaceName)
 
 	// Get namespace
-	if o.Namespace == "" {
-		if ns, _, err := o.configFlags.ToRawKubeConfigLoader().Namespace(); err == nil && ns != "" {
-			o.Namespace = ns
-		} else {
-			klog.V(4).Info("No namespace specified, using 'default'")
-			o.Namespace = "default"
-		}
-	}
-
-	// Get REST config
-	config, err := o.configFlags.ToRESTConfig()
-	if err != nil {
-		return fmt.Errorf("failed to get REST config: %w", err)
-	}
+	o.Namespace = resolveNamespace(o.configFlags, o.Namespace)
 
-	// Create dynamic client
-	dynamicClient, err := dynamic.NewForConfig(config)
+	dynamicClient, err := o.clients.DynamicClient()
 	if err != nil {
-		return fmt.Errorf("failed to create dynamic client: %w", err)
+		return err
 	}
 
-	// Create kubernetes client
-	clientset, err := kubernetes.NewForConfig(config)
+	clientset, err := o.clients.KubernetesClient()
 	if err != nil {
-		return fmt.Errorf("failed to create kubernetes client: %w", err)
+		return err
 	}
 
 	// Check workspace status first
-	if err2 := o.checkWorkspaceReady(dynamicClient); err2 != nil {
+	if o.Wait {
+		start := time.Now()
+		if err := o.waitForWorkspaceReady(ctx, dynamicClient); err != nil {
+			return err
+		}
+		if err := o.waitForServiceEndpoint(ctx, clientset, start.Add(o.Timeout)); err != nil {
+			return err
+		}
+	} else if err := o.checkWorkspaceReady(ctx, dynamicClient); err != nil {
 		return err
 	}
 
 	// Get all available endpoints
-	endpoints, err := o.getAllEndpoints(context.TODO(), clientset)
+	endpoints, err := o.getAllEndpoints(ctx, clientset)
 	if err != nil {
 		return err
 	}
 
 	// Output the result
-	if o.Format == "json" {
-		output := map[string]interface{}{
-			"workspace": o.WorkspaceName,
-			"namespace": o.Namespace,
-			"endpoints": endpoints,
+	if o.Format == "json" || o.Format == "yaml" {
+		output, err := formatEndpointList(o.Format, o.WorkspaceName, o.Namespace, endpoints)
+		if err != nil {
+			return err
 		}
+		fmt.Print(output)
+		return nil
+	}
 
-		jsonOutput, err := json.MarshalIndent(output, "", "  ")
+	// For URL and curl formats, show the best endpoint (prefer external if available)
+	if len(endpoints) == 0 {
+		return fmt.Errorf("no endpoints available for workspace %s", o.WorkspaceName)
+	}
+	endpoint := preferredEndpoint(endpoints)
+	if o.Verify {
+		endpoint = o.verifyEndpoint(ctx, endpoints)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+
+	if o.Format == "curl" {
+		fmt.Print(buildCurlCommand(endpoint, o.Namespace))
+		return nil
+	}
+
+	fmt.Println(endpoint.URL)
+	return nil
+}
+
+// formatEndpointList renders all endpoints of a workspace as JSON or YAML
+func formatEndpointList(format, workspaceName, namespace string, endpoints []EndpointInfo) (string, error) {
+	output := map[string]interface{}{
+		"workspace": workspaceName,
+		"namespace": namespace,
+		"endpoints": endpoints,
+	}
+
+	if format == "yaml" {
+		yamlOutput, err := yaml.Marshal(output)
 		if err != nil {
-			klog.Errorf("Failed to marshal JSON: %v", err)
-			return fmt.Errorf("failed to marshal JSON: %w", err)
+			klog.Errorf("Failed to marshal YAML: %v", err)
+			return "", fmt.Errorf("failed to marshal YAML: %w", err)
 		}
-		fmt.Println(string(jsonOutput))
-	} else {
-		// For URL format, show the best endpoint (prefer external if available)
-		if len(endpoints) == 0 {
-			return fmt.Errorf("no endpoints available for workspace %s", o.WorkspaceName)
+		return string(yamlOutput), nil
+	}
+
+	jsonOutput, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		klog.Errorf("Failed to marshal JSON: %v", err)
+		return "", fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+	return string(jsonOutput) + "\n", nil
+}
+
+// preferredEndpoint returns the first external endpoint, or the first endpoint if none is external
+func preferredEndpoint(endpoints []EndpointInfo) EndpointInfo {
+	return rankedEndpoints(endpoints)[0]
+}
+
+// rankedEndpoints orders endpoints by preference: external endpoints first, then the
+// others in their original order
+func rankedEndpoints(endpoints []EndpointInfo) []EndpointInfo {
+	ranked := make([]EndpointInfo, 0, len(endpoints))
+	for _, ep := range endpoints {
+		if ep.Access == "external" {
+			ranked = append(ranked, ep)
 		}
+	}
+	for _, ep := range endpoints {
+		if ep.Access != "external" {
+			ranked = append(ranked, ep)
+		}
+	}
+	return ranked
+}
 
-		// Prefer external endpoints, fallback to first available
-		for _, ep := range endpoints {
-			if ep.Access == "external" {
-				fmt.Println(ep.URL)
-				return nil
+// verifyEndpoint probes the endpoints in order of preference and returns the first
+// that responds. A LoadBalancer address can be assigned before traffic reaches the
+// model, so when none responds the preferred endpoint is returned with a warning.
+func (o *GetEndpointOptions) verifyEndpoint(ctx context.Context, endpoints []EndpointInfo) EndpointInfo {
+	ranked := rankedEndpoints(endpoints)
+	var failures []string
+	for _, ep := range ranked {
+		err := probeEndpoint(ctx, o.clients, ep.URL)
+		if err == nil {
+			if len(failures) > 0 {
+				printStatus(os.Stderr, "ℹ️  Using the %s endpoint; %s\n", ep.Type, strings.Join(failures, "; "))
 			}
+			return ep
+		}
+		if ctx.Err() != nil {
+			return ranked[0]
 		}
-		// No external endpoint, use the first one
-		fmt.Println(endpoints[0].URL)
+		klog.V(2).Infof("%s endpoint %s did not respond: %v", ep.Type, ep.URL, err)
+		failures = append(failures, fmt.Sprintf("%s %s did not respond: %v", ep.Type, ep.URL, err))
 	}
 
-	return nil
+	printStatus(os.Stderr, "⚠️  Warning: no endpoint of workspace %s responded; printing the preferred one\n", o.WorkspaceName)
+	for _, failure := range failures {
+		fmt.Fprintf(os.Stderr, "   - %s\n", failure)
+	}
+	return ranked[0]
+}
+
+// buildCurlCommand returns a ready-to-run curl command sending a sample chat request to endpoint.
+// API proxy endpoints need Kubernetes credentials, so a service account token is requested inline.
+func buildCurlCommand(endpoint EndpointInfo, namespace string) string {
+	var b strings.Builder
+
+	if endpoint.Type == "APIProxy" {
+		b.WriteString("# The API proxy requires Kubernetes credentials. The service account below needs\n")
+		b.WriteString("# 'get' on services/proxy; alternatively run 'kubectl proxy' and use http://127.0.0.1:8001\n")
+		b.WriteString("# in place of the API server address.\n")
+	}
+
+	fmt.Fprintf(&b, "curl -X POST %s/v1/chat/completions \\\n", endpoint.URL)
+	if endpoint.Type == "APIProxy" {
+		fmt.Fprintf(&b, "  -H \"Authorization: Bearer $(kubectl create token default -n %s)\" \\\n", namespace)
+	}
+	b.WriteString("  -H \"Content-Type: application/json\" \\\n")
+	b.WriteString("  -d '{\"messages\": [{\"role\": \"user\", \"content\": \"Hello!\"}], \"max_tokens\": 128}'\n")
+
+	return b.String()
 }
 
-func (o *GetEndpointOptions) checkWorkspaceReady(dynamicClient dynamic.Interface) error {
+func (o *GetEndpointOptions) checkWorkspaceReady(ctx context.Context, dynamicClient dynamic.Interface) error {
 	klog.V(3).Info("Checking workspace readiness")
 
 	gvr := schema.GroupVersionResource{
@@ -189,7 +393,7 @@ func (o *GetEndpointOptions) checkWorkspaceReady(dynamicClient dynamic.Interface
 	}
 
 	workspace, err := dynamicClient.Resource(gvr).Namespace(o.Namespace).Get(
-		context.TODO(),
+		ctx,
 		o.WorkspaceName,
 		metav1.GetOptions{},
 	)
@@ -205,7 +409,7 @@ func (o *GetEndpointOptions) checkWorkspaceReady(dynamicClient dynamic.Interface
 	}
 
 	// Check workspace ready condition
-	if !o.isWorkspaceReady(status) {
+	if !isWorkspaceReady(status) {
 		return fmt.Errorf("workspace %s is not ready yet. Use 'kubectl kaito status --workspace-name %s' to check status", o.WorkspaceName, o.WorkspaceName)
 	}
 
@@ -213,7 +417,97 @@ func (o *GetEndpointOptions) checkWorkspaceReady(dynamicClient dynamic.Interface
 	return nil
 }
 
-func (o *GetEndpointOptions) isWorkspaceReady(status interface{}) bool {
+// waitForWorkspaceReady watches the workspace until it is ready, the timeout
+// elapses or ctx is cancelled
+func (o *GetEndpointOptions) waitForWorkspaceReady(ctx context.Context, dynamicClient dynamic.Interface) error {
+	klog.V(2).Infof("Waiting up to %s for workspace %s to become ready", o.Timeout, o.WorkspaceName)
+
+	gvr := schema.GroupVersionResource{
+		Group:    "kaito.sh",
+		Version:  "v1beta1",
+		Resource: "workspaces",
+	}
+
+	announced := false
+	ready := func(workspace *unstructured.Unstructured) bool {
+		if isWorkspaceReady(workspace.Object["status"]) {
+			return true
+		}
+		if !announced {
+			printStatus(os.Stderr, "⏳ Waiting for workspace %s to become ready...\n", o.WorkspaceName)
+			announced = true
+		}
+		return false
+	}
+
+	workspace, err := waitForCondition(ctx, dynamicClient, gvr, o.WorkspaceName, o.Namespace, ready, o.Timeout)
+	switch {
+	case err == nil:
+		return nil
+	case ctx.Err() != nil:
+		return fmt.Errorf("stopped waiting for workspace %s: %w", o.WorkspaceName, ctx.Err())
+	case isWaitTimeout(err):
+		pending := "not ready"
+		if workspace != nil {
+			if condition := pendingCondition(workspace); condition != "" {
+				pending = condition
+			}
+		}
+		return fmt.Errorf("timed out after %s waiting for workspace %s (%s); use 'kubectl kaito status --workspace-name %s' to check status",
+			o.Timeout, o.WorkspaceName, pending, o.WorkspaceName)
+	default:
+		return fmt.Errorf("failed waiting for workspace %s: %w", o.WorkspaceName, err)
+	}
+}
+
+// waitForServiceEndpoint polls the workspace service until it exists and, for a
+// LoadBalancer service, until an external IP or hostname has been assigned
+func (o *GetEndpointOptions) waitForServiceEndpoint(ctx context.Context, clientset kubernetes.Interface, deadline time.Time) error {
+	lastPending := ""
+	for {
+		pending, err := o.pendingServiceEndpoint(ctx, clientset)
+		if err != nil {
+			return err
+		}
+		if pending == "" {
+			return nil
+		}
+		if time.Now().Add(endpointReadyPollInterval).After(deadline) {
+			return fmt.Errorf("timed out after %s %s", o.Timeout, pending)
+		}
+		if pending != lastPending {
+			printStatus(os.Stderr, "⏳ %s%s...\n", strings.ToUpper(pending[:1]), pending[1:])
+			lastPending = pending
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("stopped %s: %w", pending, ctx.Err())
+		case <-time.After(endpointReadyPollInterval):
+		}
+	}
+}
+
+// pendingServiceEndpoint describes what the workspace service is still waiting for,
+// or returns "" when its endpoints can be resolved
+func (o *GetEndpointOptions) pendingServiceEndpoint(ctx context.Context, clientset kubernetes.Interface) (string, error) {
+	svc, err := clientset.CoreV1().Services(o.Namespace).Get(ctx, o.WorkspaceName, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return fmt.Sprintf("waiting for service %s to be created", o.WorkspaceName), nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get service for workspace %s: %w", o.WorkspaceName, err)
+	}
+
+	if svc.Spec.Type == corev1.ServiceTypeLoadBalancer &&
+		o.getLoadBalancerEndpoint(svc, serviceScheme(svc, o.Scheme), servicePort(svc, o.Port)) == "" {
+		return fmt.Sprintf("waiting for LoadBalancer service %s to get an external IP", o.WorkspaceName), nil
+	}
+	return "", nil
+}
+
+// isWorkspaceReady reports whether a workspace status has ResourceReady and either
+// InferenceReady (inference) or JobStarted (tuning)
+func isWorkspaceReady(status interface{}) bool {
 	statusMap, ok := status.(map[string]interface{})
 	if !ok {
 		klog.V(6).Info("Status is not a map")
@@ -276,19 +570,93 @@ func (o *GetEndpointOptions) isWorkspaceReady(status interface{}) bool {
 	return (resourceReady && inferenceReady) || (resourceReady && jobStarted)
 }
 
+// readinessConditions are the workspace conditions reported when its service is missing, in the order they become true
+var readinessConditions = []string{"ResourceReady", "InferenceReady", "WorkspaceSucceeded"}
+
+// serviceNotFoundError explains a missing workspace service. Kaito creates the
+// service some time after the workspace, so this usually means it is not ready yet.
+func serviceNotFoundError(ctx context.Context, clients *clientFactory, namespace, workspaceName string) error {
+	hint := fmt.Sprintf("use 'kubectl kaito status --workspace-name %s -n %s' to check its progress", workspaceName, namespace)
+
+	workspace, err := getWorkspaceForError(ctx, clients, namespace, workspaceName)
+	if errors.IsNotFound(err) {
+		return fmt.Errorf("workspace %s not found in namespace %s", workspaceName, namespace)
+	}
+	if err != nil {
+		klog.V(4).Infof("Could not get workspace %s for its readiness: %v", workspaceName, err)
+		return fmt.Errorf("service for workspace %s not found in namespace %s; the workspace may not be ready yet, %s",
+			workspaceName, namespace, hint)
+	}
+
+	if pending := pendingCondition(workspace); pending != "" {
+		return fmt.Errorf("service for workspace %s not found in namespace %s; the workspace is not ready yet (%s), %s",
+			workspaceName, namespace, pending, hint)
+	}
+	return fmt.Errorf("service for workspace %s not found in namespace %s; the workspace may not be ready yet, %s",
+		workspaceName, namespace, hint)
+}
+
+// getWorkspaceForError fetches a workspace to describe it in an error message
+func getWorkspaceForError(ctx context.Context, clients *clientFactory, namespace, workspaceName string) (*unstructured.Unstructured, error) {
+	dynamicClient, err := clients.DynamicClient()
+	if err != nil {
+		return nil, err
+	}
+
+	gvr := schema.GroupVersionResource{
+		Group:    "kaito.sh",
+		Version:  "v1beta1",
+		Resource: "workspaces",
+	}
+	return dynamicClient.Resource(gvr).Namespace(namespace).Get(ctx, workspaceName, metav1.GetOptions{})
+}
+
+// pendingCondition describes the first readiness condition of a workspace that is
+// not True, e.g. "InferenceReady=False: model is loading", or "" if all are True
+func pendingCondition(workspace *unstructured.Unstructured) string {
+	conditions, _, _ := unstructured.NestedSlice(workspace.Object, "status", "conditions")
+
+	for _, condType := range readinessConditions {
+		status := conditionStatus(conditions, condType)
+		if status == "True" {
+			continue
+		}
+
+		pending := fmt.Sprintf("%s=%s", condType, status)
+		for _, condition := range conditions {
+			condMap, _ := condition.(map[string]interface{})
+			if t, _ := condMap["type"].(string); t != condType {
+				continue
+			}
+			if message, _ := condMap["message"].(string); message != "" {
+				pending += ": " + message
+			} else if reason, _ := condMap["reason"].(string); reason != "" {
+				pending += ": " + reason
+			}
+		}
+		return pending
+	}
+	return ""
+}
+
 func (o *GetEndpointOptions) getAllEndpoints(ctx context.Context, clientset kubernetes.Interface) ([]EndpointInfo, error) {
 	klog.V(3).Infof("Getting all endpoints for workspace: %s", o.WorkspaceName)
 
 	svc, err := clientset.CoreV1().Services(o.Namespace).Get(ctx, o.WorkspaceName, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return nil, serviceNotFoundError(ctx, o.clients, o.Namespace, o.WorkspaceName)
+	}
 	if err != nil {
 		klog.Errorf("Failed to get service for workspace %s: %v", o.WorkspaceName, err)
 		return nil, fmt.Errorf("failed to get service for workspace %s: %v", o.WorkspaceName, err)
 	}
 
 	var endpoints []EndpointInfo
+	scheme := serviceScheme(svc, o.Scheme)
+	port := servicePort(svc, o.Port)
 
 	// Check for LoadBalancer endpoint (external access)
-	if lbEndpoint := o.getLoadBalancerEndpoint(svc); lbEndpoint != "" {
+	if lbEndpoint := o.getLoadBalancerEndpoint(svc, scheme, port); lbEndpoint != "" {
 		endpoints = append(endpoints, EndpointInfo{
 			URL:         lbEndpoint,
 			Type:        "LoadBalancer",
@@ -298,7 +666,7 @@ func (o *GetEndpointOptions) getAllEndpoints(ctx context.Context, clientset kube
 	}
 
 	// Always add the API proxy endpoint (works anywhere kubectl works)
-	apiProxyEndpoint, err := o.getAPIProxyEndpoint()
+	apiProxyEndpoint, err := o.getAPIProxyEndpoint(scheme, port)
 	if err != nil {
 		klog.V(3).Infof("Could not get API proxy endpoint: %v", err)
 	} else {
@@ -311,7 +679,7 @@ func (o *GetEndpointOptions) getAllEndpoints(ctx context.Context, clientset kube
 	}
 
 	// Add cluster-internal endpoint if accessible (for pods/internal use)
-	if clusterEndpoint := o.getClusterInternalEndpoint(svc); clusterEndpoint != "" {
+	if clusterEndpoint := o.getClusterInternalEndpoint(svc, scheme, port); clusterEndpoint != "" {
 		if o.canAccessClusterEndpoint(clusterEndpoint) {
 			endpoints = append(endpoints, EndpointInfo{
 				URL:         clusterEndpoint,
@@ -329,7 +697,7 @@ func (o *GetEndpointOptions) getAllEndpoints(ctx context.Context, clientset kube
 	return endpoints, nil
 }
 
-func (o *GetEndpointOptions) getLoadBalancerEndpoint(svc *corev1.Service) string {
+func (o *GetEndpointOptions) getLoadBalancerEndpoint(svc *corev1.Service, scheme string, port int32) string {
 	if svc.Spec.Type != "LoadBalancer" {
 		return ""
 	}
@@ -337,9 +705,9 @@ func (o *GetEndpointOptions) getLoadBalancerEndpoint(svc *corev1.Service) string
 	for _, ingress := range svc.Status.LoadBalancer.Ingress {
 		var endpoint string
 		if ingress.IP != "" {
-			endpoint = fmt.Sprintf("http://%s:80", ingress.IP)
+			endpoint = fmt.Sprintf("%s://%s:%d", scheme, ingress.IP, port)
 		} else if ingress.Hostname != "" {
-			endpoint = fmt.Sprintf("http://%s:80", ingress.Hostname)
+			endpoint = fmt.Sprintf("%s://%s:%d", scheme, ingress.Hostname, port)
 		}
 		if endpoint != "" {
 			klog.V(3).Infof("Found external LoadBalancer endpoint: %s", endpoint)
@@ -349,23 +717,23 @@ func (o *GetEndpointOptions) getLoadBalancerEndpoint(svc *corev1.Service) string
 	return ""
 }
 
-func (o *GetEndpointOptions) getClusterInternalEndpoint(svc *corev1.Service) string {
+func (o *GetEndpointOptions) getClusterInternalEndpoint(svc *corev1.Service, scheme string, port int32) string {
 	if svc.Spec.ClusterIP == "" || svc.Spec.ClusterIP == "None" {
 		return ""
 	}
 
 	// Return cluster-internal endpoint (caller will check if accessible)
-	clusterEndpoint := fmt.Sprintf("http://%s.%s.svc.cluster.local:80", o.WorkspaceName, o.Namespace)
+	clusterEndpoint := fmt.Sprintf("%s://%s.%s.svc.cluster.local:%d", scheme, o.WorkspaceName, o.Namespace, port)
 	klog.V(3).Infof("Cluster-internal endpoint: %s", clusterEndpoint)
 	return clusterEndpoint
 }
 
 // getAPIProxyEndpoint constructs the Kubernetes API proxy endpoint for the service
-func (o *GetEndpointOptions) getAPIProxyEndpoint() (string, error) {
+func (o *GetEndpointOptions) getAPIProxyEndpoint(scheme string, port int32) (string, error) {
 	// Get the REST config to build the API server URL
-	config, err := o.configFlags.ToRESTConfig()
+	config, err := o.clients.RESTConfig()
 	if err != nil {
-		return "", fmt.Errorf("failed to get REST config: %w", err)
+		return "", err
 	}
 
 	// Build the API proxy URL
@@ -375,8 +743,8 @@ func (o *GetEndpointOptions) getAPIProxyEndpoint() (string, error) {
 		namespace = "default"
 	}
 
-	apiProxyURL := fmt.Sprintf("%s/api/v1/namespaces/%s/services/%s:80/proxy",
-		strings.TrimSuffix(config.Host, "/"), namespace, o.WorkspaceName)
+	apiProxyURL := fmt.Sprintf("%s/api/v1/namespaces/%s/services/%s/proxy",
+		strings.TrimSuffix(config.Host, "/"), namespace, apiProxyServiceName(o.WorkspaceName, scheme, port))
 
 	klog.V(3).Infof("Constructed API proxy URL: %s", apiProxyURL)
 	return apiProxyURL, nil
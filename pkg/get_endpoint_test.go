@@ -17,10 +17,20 @@ limitations under the License.
 package cmd
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes/fake"
 )
 
 func TestGetEndpointCmd(t *testing.T) {
@@ -47,4 +57,299 @@ func TestGetEndpointCmd(t *testing.T) {
 		namespaceFlag := flags.Lookup("namespace")
 		assert.NotNil(t, namespaceFlag)
 	})
+
+	t.Run("Format validation", func(t *testing.T) {
+		for _, format := range []string{"url", "json", "yaml", "curl"} {
+			assert.NoError(t, (&GetEndpointOptions{WorkspaceName: "ws", Format: format}).validate(), format)
+		}
+		assert.ErrorContains(t, (&GetEndpointOptions{WorkspaceName: "ws", Format: "xml"}).validate(), "'yaml'")
+	})
+
+	t.Run("Verify requires the url or curl format", func(t *testing.T) {
+		assert.NoError(t, (&GetEndpointOptions{WorkspaceName: "ws", Format: "curl", Verify: true}).validate())
+		assert.ErrorContains(t, (&GetEndpointOptions{WorkspaceName: "ws", Format: "json", Verify: true}).validate(),
+			"--verify can only be used with --format url or curl")
+	})
+}
+
+func TestFormatEndpointList(t *testing.T) {
+	endpoints := []EndpointInfo{{
+		URL:         "http://203.0.113.42:80",
+		Type:        "LoadBalancer",
+		Access:      "external",
+		Description: "Direct public access via LoadBalancer",
+	}}
+
+	yamlOutput, err := formatEndpointList("yaml", "my-ws", "default", endpoints)
+	assert.NoError(t, err)
+	assert.Equal(t, `endpoints:
+- access: external
+  description: Direct public access via LoadBalancer
+  type: LoadBalancer
+  url: http://203.0.113.42:80
+namespace: default
+workspace: my-ws
+`, yamlOutput)
+
+	jsonOutput, err := formatEndpointList("json", "my-ws", "default", endpoints)
+	assert.NoError(t, err)
+	assert.Contains(t, jsonOutput, `"url": "http://203.0.113.42:80"`)
+	assert.True(t, strings.HasSuffix(jsonOutput, "}\n"))
+}
+
+func TestServiceScheme(t *testing.T) {
+	https := "https"
+	tests := []struct {
+		name     string
+		ports    []corev1.ServicePort
+		override string
+		expected string
+	}{
+		{name: "Plain http port", ports: []corev1.ServicePort{{Name: "http", Port: 80}}, expected: "http"},
+		{name: "Port named https", ports: []corev1.ServicePort{{Name: "https", Port: 8443}}, expected: "https"},
+		{name: "Port 443", ports: []corev1.ServicePort{{Port: 443}}, expected: "https"},
+		{name: "App protocol https", ports: []corev1.ServicePort{{Port: 80, AppProtocol: &https}}, expected: "https"},
+		{name: "Override wins", ports: []corev1.ServicePort{{Name: "https", Port: 443}}, override: "http", expected: "http"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &corev1.Service{Spec: corev1.ServiceSpec{Ports: tt.ports}}
+			assert.Equal(t, tt.expected, serviceScheme(svc, tt.override))
+		})
+	}
+}
+
+func TestEndpointSchemes(t *testing.T) {
+	o := &GetEndpointOptions{WorkspaceName: "my-workspace", Namespace: "default"}
+	svc := &corev1.Service{
+		Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer, ClusterIP: "10.0.0.1"},
+		Status: corev1.ServiceStatus{LoadBalancer: corev1.LoadBalancerStatus{
+			Ingress: []corev1.LoadBalancerIngress{{IP: "1.2.3.4"}},
+		}},
+	}
+
+	assert.Equal(t, "https://1.2.3.4:80", o.getLoadBalancerEndpoint(svc, "https", 80))
+	assert.Equal(t, "http://my-workspace.default.svc.cluster.local:80", o.getClusterInternalEndpoint(svc, "http", 80))
+	assert.Equal(t, "http://my-workspace.default.svc.cluster.local:5000", o.getClusterInternalEndpoint(svc, "http", 5000))
+	assert.Equal(t, "https:my-workspace:80", apiProxyServiceName("my-workspace", "https", 80))
+	assert.Equal(t, "my-workspace:5000", apiProxyServiceName("my-workspace", "http", 5000))
+
+	assert.NoError(t, validateScheme(""))
+	assert.NoError(t, validateScheme("https"))
+	assert.Error(t, validateScheme("ftp"))
+}
+
+func TestServicePort(t *testing.T) {
+	tests := []struct {
+		name     string
+		ports    []corev1.ServicePort
+		override int
+		expected int32
+	}{
+		{name: "No ports", expected: 80},
+		{name: "Port named http preferred", ports: []corev1.ServicePort{{Name: "metrics", Port: 9090}, {Name: "http", Port: 5000}}, expected: 5000},
+		{name: "First TCP port", ports: []corev1.ServicePort{{Name: "dns", Port: 53, Protocol: corev1.ProtocolUDP}, {Name: "api", Port: 8000, Protocol: corev1.ProtocolTCP}}, expected: 8000},
+		{name: "Override wins", ports: []corev1.ServicePort{{Name: "http", Port: 80}}, override: 5000, expected: 5000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &corev1.Service{Spec: corev1.ServiceSpec{Ports: tt.ports}}
+			assert.Equal(t, tt.expected, servicePort(svc, tt.override))
+		})
+	}
+
+	assert.NoError(t, validatePort(0))
+	assert.Error(t, validatePort(70000))
+}
+
+func TestGetEndpointWaitForWorkspaceReady(t *testing.T) {
+	origInterval := endpointReadyPollInterval
+	endpointReadyPollInterval = 10 * time.Millisecond
+	defer func() { endpointReadyPollInterval = origInterval }()
+
+	t.Run("Not ready workspace is reported", func(t *testing.T) {
+		client := newFakeDynamicClient(newTestWorkspace("pending-ws", "default", map[string]string{"ResourceReady": "False"}))
+		o := &GetEndpointOptions{WorkspaceName: "pending-ws", Namespace: "default"}
+
+		err := o.checkWorkspaceReady(context.TODO(), client)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "not ready")
+	})
+
+	t.Run("Ready workspace returns immediately", func(t *testing.T) {
+		client := newFakeDynamicClient(newTestWorkspace("ready-ws", "default", map[string]string{
+			"ResourceReady":  "True",
+			"InferenceReady": "True",
+		}))
+		o := &GetEndpointOptions{WorkspaceName: "ready-ws", Namespace: "default", Timeout: time.Second}
+
+		assert.NoError(t, o.waitForWorkspaceReady(context.TODO(), client))
+	})
+
+	t.Run("Not ready workspace times out", func(t *testing.T) {
+		client := newFakeDynamicClient(newTestWorkspace("pending-ws", "default", map[string]string{"ResourceReady": "False"}))
+		o := &GetEndpointOptions{WorkspaceName: "pending-ws", Namespace: "default", Timeout: 50 * time.Millisecond}
+
+		err := o.waitForWorkspaceReady(context.TODO(), client)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "timed out")
+	})
+
+	t.Run("Cancelled context stops waiting", func(t *testing.T) {
+		client := newFakeDynamicClient(newTestWorkspace("pending-ws", "default", map[string]string{"ResourceReady": "False"}))
+		o := &GetEndpointOptions{WorkspaceName: "pending-ws", Namespace: "default", Timeout: time.Minute}
+
+		ctx, cancel := context.WithCancel(context.TODO())
+		cancel()
+		assert.ErrorIs(t, o.waitForWorkspaceReady(ctx, client), context.Canceled)
+	})
+}
+
+func TestBuildCurlCommand(t *testing.T) {
+	t.Run("LoadBalancer endpoint", func(t *testing.T) {
+		cmd := buildCurlCommand(EndpointInfo{URL: "http://1.2.3.4:80", Type: "LoadBalancer", Access: "external"}, "default")
+		assert.Contains(t, cmd, "curl -X POST http://1.2.3.4:80/v1/chat/completions")
+		assert.Contains(t, cmd, `-H "Content-Type: application/json"`)
+		assert.Contains(t, cmd, `"messages"`)
+		assert.NotContains(t, cmd, "Authorization")
+	})
+
+	t.Run("API proxy endpoint includes a token", func(t *testing.T) {
+		cmd := buildCurlCommand(EndpointInfo{URL: "https://api/api/v1/namespaces/ml/services/ws:80/proxy", Type: "APIProxy", Access: "cluster"}, "ml")
+		assert.Contains(t, cmd, `-H "Authorization: Bearer $(kubectl create token default -n ml)"`)
+	})
+
+	t.Run("Prefers external endpoints", func(t *testing.T) {
+		endpoints := []EndpointInfo{{URL: "proxy", Access: "cluster"}, {URL: "lb", Access: "external"}}
+		assert.Equal(t, "lb", preferredEndpoint(endpoints).URL)
+		assert.Equal(t, "proxy", preferredEndpoint(endpoints[:1]).URL)
+	})
+}
+
+func TestVerifyEndpoint(t *testing.T) {
+	live := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer live.Close()
+	dead := httptest.NewServer(http.NotFoundHandler())
+	dead.Close()
+
+	o := &GetEndpointOptions{WorkspaceName: "ws"}
+
+	t.Run("Falls back to an endpoint that responds", func(t *testing.T) {
+		endpoints := []EndpointInfo{
+			{URL: live.URL, Type: "ClusterIP", Access: "internal"},
+			{URL: dead.URL, Type: "LoadBalancer", Access: "external"},
+		}
+		assert.Equal(t, live.URL, o.verifyEndpoint(context.TODO(), endpoints).URL)
+	})
+
+	t.Run("Keeps the preferred endpoint when it responds", func(t *testing.T) {
+		endpoints := []EndpointInfo{
+			{URL: dead.URL, Type: "ClusterIP", Access: "internal"},
+			{URL: live.URL, Type: "LoadBalancer", Access: "external"},
+		}
+		assert.Equal(t, live.URL, o.verifyEndpoint(context.TODO(), endpoints).URL)
+	})
+
+	t.Run("Prints the preferred endpoint when none responds", func(t *testing.T) {
+		endpoints := []EndpointInfo{
+			{URL: dead.URL + "/internal", Type: "ClusterIP", Access: "internal"},
+			{URL: dead.URL, Type: "LoadBalancer", Access: "external"},
+		}
+		assert.Equal(t, dead.URL, o.verifyEndpoint(context.TODO(), endpoints).URL)
+	})
+
+	t.Run("Ranks external endpoints first", func(t *testing.T) {
+		endpoints := []EndpointInfo{{URL: "proxy", Access: "cluster"}, {URL: "ip", Access: "internal"}, {URL: "lb", Access: "external"}}
+		var urls []string
+		for _, ep := range rankedEndpoints(endpoints) {
+			urls = append(urls, ep.URL)
+		}
+		assert.Equal(t, []string{"lb", "proxy", "ip"}, urls)
+	})
+}
+
+func TestGetEndpointWaitForServiceEndpoint(t *testing.T) {
+	origInterval := endpointReadyPollInterval
+	endpointReadyPollInterval = 10 * time.Millisecond
+	defer func() { endpointReadyPollInterval = origInterval }()
+
+	newService := func(svcType corev1.ServiceType, ingress ...corev1.LoadBalancerIngress) *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-ws", Namespace: "default"},
+			Spec:       corev1.ServiceSpec{Type: svcType, ClusterIP: "10.0.0.1"},
+			Status:     corev1.ServiceStatus{LoadBalancer: corev1.LoadBalancerStatus{Ingress: ingress}},
+		}
+	}
+	o := &GetEndpointOptions{WorkspaceName: "my-ws", Namespace: "default", Timeout: 50 * time.Millisecond}
+
+	t.Run("ClusterIP service is available immediately", func(t *testing.T) {
+		clientset := fake.NewSimpleClientset(newService(corev1.ServiceTypeClusterIP))
+		assert.NoError(t, o.waitForServiceEndpoint(context.TODO(), clientset, time.Now().Add(o.Timeout)))
+	})
+
+	t.Run("LoadBalancer with ingress is available", func(t *testing.T) {
+		clientset := fake.NewSimpleClientset(newService(corev1.ServiceTypeLoadBalancer, corev1.LoadBalancerIngress{IP: "20.1.2.3"}))
+		assert.NoError(t, o.waitForServiceEndpoint(context.TODO(), clientset, time.Now().Add(o.Timeout)))
+	})
+
+	t.Run("Pending external IP times out", func(t *testing.T) {
+		clientset := fake.NewSimpleClientset(newService(corev1.ServiceTypeLoadBalancer))
+		err := o.waitForServiceEndpoint(context.TODO(), clientset, time.Now().Add(o.Timeout))
+		assert.ErrorContains(t, err, "timed out")
+		assert.ErrorContains(t, err, "external IP")
+	})
+
+	t.Run("Missing service times out", func(t *testing.T) {
+		err := o.waitForServiceEndpoint(context.TODO(), fake.NewSimpleClientset(), time.Now().Add(o.Timeout))
+		assert.ErrorContains(t, err, "waiting for service my-ws to be created")
+	})
+
+	t.Run("Watch is an alias for wait", func(t *testing.T) {
+		cmd := NewGetEndpointCmd(genericclioptions.NewConfigFlags(true))
+		assert.NoError(t, cmd.Flags().Parse([]string{"--watch"}))
+		assert.Equal(t, "true", cmd.Flags().Lookup("wait").Value.String())
+	})
+}
+
+func TestServiceNotFoundError(t *testing.T) {
+	newClients := func(objects ...runtime.Object) *clientFactory {
+		return &clientFactory{dynamicClient: newFakeDynamicClient(objects...), clientset: fake.NewSimpleClientset()}
+	}
+
+	t.Run("Pending condition and status hint are reported", func(t *testing.T) {
+		workspace := newTestWorkspace("my-ws", "default", nil)
+		assert.NoError(t, unstructured.SetNestedSlice(workspace.Object, []interface{}{
+			map[string]interface{}{"type": "ResourceReady", "status": "True"},
+			map[string]interface{}{"type": "InferenceReady", "status": "False", "message": "Inference pod is not ready"},
+		}, "status", "conditions"))
+		clients := newClients(workspace)
+
+		o := &GetEndpointOptions{clients: clients, WorkspaceName: "my-ws", Namespace: "default"}
+		_, err := o.getAllEndpoints(context.TODO(), clients.clientset)
+		assert.ErrorContains(t, err, "service for workspace my-ws not found in namespace default")
+		assert.ErrorContains(t, err, "(InferenceReady=False: Inference pod is not ready)")
+		assert.ErrorContains(t, err, "kubectl kaito status --workspace-name my-ws -n default")
+	})
+
+	t.Run("Chat discovery reports a missing condition as Unknown", func(t *testing.T) {
+		clients := newClients(newTestWorkspace("my-ws", "default", nil))
+		target := inferenceTarget{clients: clients, WorkspaceName: "my-ws", Namespace: "default"}
+
+		_, err := target.discoverBaseURL(context.TODO(), clients.clientset)
+		assert.ErrorContains(t, err, "(ResourceReady=Unknown)")
+	})
+
+	t.Run("Missing workspace is reported", func(t *testing.T) {
+		err := serviceNotFoundError(context.TODO(), newClients(), "default", "my-ws")
+		assert.EqualError(t, err, "workspace my-ws not found in namespace default")
+	})
+
+	t.Run("Without a cluster the hint is still given", func(t *testing.T) {
+		err := serviceNotFoundError(context.TODO(), nil, "default", "my-ws")
+		assert.ErrorContains(t, err, "the workspace may not be ready yet")
+	})
 }
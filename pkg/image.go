@@ -0,0 +1,66 @@
+/*
+Copyright (c) 2024 Kaito Project
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// maxImageNameLength is the longest repository name, including the registry, that
+// registries accept
+const maxImageNameLength = 255
+
+// imageReferencePattern matches [registry[:port]/]repository[:tag][@digest], following
+// the grammar of github.com/distribution/reference
+var imageReferencePattern = func() *regexp.Regexp {
+	domainComponent := `(?:[a-zA-Z0-9]|[a-zA-Z0-9][a-zA-Z0-9-]*[a-zA-Z0-9])`
+	domain := domainComponent + `(?:\.` + domainComponent + `)*(?::[0-9]+)?`
+	pathComponent := `[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*`
+	name := `(?:` + domain + `/)?` + pathComponent + `(?:/` + pathComponent + `)*`
+	tag := `[\w][\w.-]{0,127}`
+	digest := `[A-Za-z][A-Za-z0-9]*(?:[-_+.][A-Za-z][A-Za-z0-9]*)*:[0-9a-fA-F]{32,}`
+	return regexp.MustCompile(`^(` + name + `)(?::(` + tag + `))?(?:@(` + digest + `))?$`)
+}()
+
+// imageReference is a parsed container image reference
+type imageReference struct {
+	Name   string
+	Tag    string
+	Digest string
+}
+
+// parseImageReference parses a container image reference such as
+// myregistry.azurecr.io/phi-finetuned:v1
+func parseImageReference(ref string) (imageReference, error) {
+	if ref == "" {
+		return imageReference{}, fmt.Errorf("image reference is empty")
+	}
+
+	match := imageReferencePattern.FindStringSubmatch(ref)
+	if match == nil {
+		if lower := strings.ToLower(ref); lower != ref && imageReferencePattern.MatchString(lower) {
+			return imageReference{}, fmt.Errorf("repository name in %q must be lowercase", ref)
+		}
+		return imageReference{}, fmt.Errorf("%q is not a valid image reference; expected [registry/]repository[:tag][@digest]", ref)
+	}
+	if len(match[1]) > maxImageNameLength {
+		return imageReference{}, fmt.Errorf("image name in %q is longer than %d characters", ref, maxImageNameLength)
+	}
+	return imageReference{Name: match[1], Tag: match[2], Digest: match[3]}, nil
+}
@@ -0,0 +1,59 @@
+/*
+Copyright (c) 2024 Kaito Project
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseImageReference(t *testing.T) {
+	digest := "sha256:" + strings.Repeat("a", 64)
+
+	tests := []struct {
+		ref      string
+		expected imageReference
+		errMsg   string
+	}{
+		{ref: "phi-finetuned", expected: imageReference{Name: "phi-finetuned"}},
+		{ref: "myregistry.azurecr.io/phi-finetuned:v1", expected: imageReference{Name: "myregistry.azurecr.io/phi-finetuned", Tag: "v1"}},
+		{ref: "localhost:5000/team/phi_base:1.0-rc", expected: imageReference{Name: "localhost:5000/team/phi_base", Tag: "1.0-rc"}},
+		{ref: "myregistry/phi@" + digest, expected: imageReference{Name: "myregistry/phi", Digest: digest}},
+		{ref: "myregistry/phi:v1@" + digest, expected: imageReference{Name: "myregistry/phi", Tag: "v1", Digest: digest}},
+		{ref: "", errMsg: "image reference is empty"},
+		{ref: "myregistry/Phi-Finetuned:v1", errMsg: "must be lowercase"},
+		{ref: "myregistry/phi:", errMsg: "is not a valid image reference"},
+		{ref: "https://myregistry/phi:v1", errMsg: "is not a valid image reference"},
+		{ref: "myregistry/phi:v1 ", errMsg: "is not a valid image reference"},
+		{ref: "myregistry/phi@sha256:abc", errMsg: "is not a valid image reference"},
+		{ref: strings.Repeat("a", 256), errMsg: "longer than 255 characters"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			ref, err := parseImageReference(tt.ref)
+			if tt.errMsg != "" {
+				assert.ErrorContains(t, err, tt.errMsg)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, ref)
+		})
+	}
+}
@@ -0,0 +1,83 @@
+/*
+Copyright (c) 2024 Kaito Project
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// instanceTypeGPUMemoryGiB is the total GPU memory of common GPU instance types.
+// Instance types that are not listed are not checked.
+var instanceTypeGPUMemoryGiB = map[string]float64{
+	// NVIDIA T4 (16 GiB)
+	"Standard_NC4as_T4_v3":  16,
+	"Standard_NC8as_T4_v3":  16,
+	"Standard_NC16as_T4_v3": 16,
+	"Standard_NC64as_T4_v3": 64,
+	// NVIDIA V100 (16 GiB)
+	"Standard_NC6s_v3":  16,
+	"Standard_NC12s_v3": 32,
+	"Standard_NC24s_v3": 64,
+	// NVIDIA A10 (24 GiB)
+	"Standard_NV36ads_A10_v5": 24,
+	"Standard_NV72ads_A10_v5": 48,
+	// NVIDIA A100
+	"Standard_NC24ads_A100_v4":  80,
+	"Standard_NC48ads_A100_v4":  160,
+	"Standard_NC96ads_A100_v4":  320,
+	"Standard_ND96asr_v4":       320,
+	"Standard_ND96amsr_A100_v4": 640,
+	// NVIDIA H100
+	"Standard_NC40ads_H100_v5":  94,
+	"Standard_NC80adis_H100_v5": 188,
+	"Standard_ND96isr_H100_v5":  640,
+}
+
+// instanceGPUMemoryGiB returns the known GPU memory of an instance type (case-insensitive)
+func instanceGPUMemoryGiB(instanceType string) (float64, bool) {
+	for name, memory := range instanceTypeGPUMemoryGiB {
+		if strings.EqualFold(name, instanceType) {
+			return memory, true
+		}
+	}
+	return 0, false
+}
+
+// parseGPUMemoryGiB parses a model's GPU memory requirement such as "16Gi" or "16GB".
+// It reports false when the value is missing or not understood.
+func parseGPUMemoryGiB(value string) (float64, bool) {
+	// Quantities use binary suffixes (Gi), the models list sometimes uses GB
+	value = strings.TrimSuffix(strings.TrimSuffix(strings.TrimSpace(value), "B"), "b")
+	if value == "" {
+		return 0, false
+	}
+	if strings.HasSuffix(value, "G") {
+		value += "i"
+	}
+	// A bare number is taken as GiB
+	if last := value[len(value)-1]; last >= '0' && last <= '9' {
+		value += "Gi"
+	}
+
+	quantity, err := resource.ParseQuantity(value)
+	if err != nil || quantity.Sign() <= 0 {
+		return 0, false
+	}
+	return quantity.AsApproximateFloat64() / (1 << 30), true
+}
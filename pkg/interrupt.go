@@ -0,0 +1,57 @@
+/*
+Copyright (c) 2024 Kaito Project
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"k8s.io/klog/v2"
+)
+
+// interruptExitCode is the exit code of a command stopped with Ctrl+C, as in shells
+const interruptExitCode = 130
+
+// errInterrupted is returned by a command stopped with Ctrl+C. Execute prints it as
+// a short message instead of the errors of the cancelled requests.
+var errInterrupted = errors.New("interrupted")
+
+// withInterrupt returns a copy of ctx that is cancelled on Ctrl+C (SIGINT) or SIGTERM,
+// for long-running commands to pass to their watches and requests. After the first
+// signal the default handling is restored, so a second Ctrl+C terminates the process
+// even if a step does not honor the context.
+func withInterrupt(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	context.AfterFunc(ctx, func() {
+		klog.V(2).Info("Interrupted, cancelling in-flight requests")
+		stop()
+	})
+	return ctx, stop
+}
+
+// interruptedError returns errInterrupted for an error returned after ctx was
+// cancelled, and err otherwise
+func interruptedError(ctx context.Context, err error) error {
+	if err != nil && ctx.Err() != nil {
+		klog.V(3).Infof("Command stopped after interrupt: %v", err)
+		return errInterrupted
+	}
+	return err
+}
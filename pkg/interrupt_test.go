@@ -0,0 +1,81 @@
+/*
+Copyright (c) 2024 Kaito Project
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func TestWithInterrupt(t *testing.T) {
+	ctx, stop := withInterrupt(context.Background())
+	defer stop()
+
+	self, err := os.FindProcess(os.Getpid())
+	assert.NoError(t, err)
+	assert.NoError(t, self.Signal(os.Interrupt))
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("context was not cancelled by SIGINT")
+	}
+}
+
+func TestInterruptedError(t *testing.T) {
+	assert.NoError(t, interruptedError(context.Background(), nil))
+
+	failure := fmt.Errorf("failed to get workspace: boom")
+	assert.Equal(t, failure, interruptedError(context.Background(), failure))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.NoError(t, interruptedError(ctx, nil))
+	assert.Equal(t, errInterrupted, interruptedError(ctx, fmt.Errorf("failed to get workspace: %w", context.Canceled)))
+}
+
+func TestExecuteInterrupted(t *testing.T) {
+	t.Cleanup(func() { jsonErrors = false })
+	for _, tt := range []struct {
+		args     []string
+		expected string
+	}{
+		{args: []string{"stopped"}, expected: "\nInterrupted\n"},
+		{args: []string{"stopped", "--json-errors"}, expected: `{"error":"interrupted","command":"stopped","code":130}` + "\n"},
+	} {
+		root := NewRootCmd(genericclioptions.NewConfigFlags(true), true)
+		root.AddCommand(&cobra.Command{
+			Use: "stopped",
+			RunE: func(cmd *cobra.Command, args []string) error {
+				return errInterrupted
+			},
+		})
+		var stderr bytes.Buffer
+		root.SetErr(&stderr)
+
+		assert.Equal(t, interruptExitCode, Execute(root, tt.args))
+		assert.Equal(t, tt.expected, stderr.String())
+	}
+}
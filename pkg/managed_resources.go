@@ -0,0 +1,318 @@
+/*
+Copyright (c) 2024 Kaito Project
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+// workspaceLabel is the label Kaito sets on the objects it creates for a workspace
+const workspaceLabel = "kaito.sh/workspace"
+
+// managedResources are the Kubernetes objects Kaito created for a workspace, and the
+// PersistentVolumeClaims its tuning spec refers to
+type managedResources struct {
+	Deployments  []appsv1.Deployment
+	StatefulSets []appsv1.StatefulSet
+	Services     []corev1.Service
+	Jobs         []batchv1.Job
+	Pods         []corev1.Pod
+	PVCs         []managedPVC
+}
+
+// managedPVC is a PersistentVolumeClaim of a workspace
+type managedPVC struct {
+	Name string
+	// Claim is nil when the referenced claim does not exist
+	Claim *corev1.PersistentVolumeClaim
+	// ReferencedBy is the tuning field naming the claim, e.g. tuning.input, or empty
+	// when the claim belongs to the workspace
+	ReferencedBy string
+}
+
+// tuningPVCFields are the fields of a tuning spec that can name a PersistentVolumeClaim
+var tuningPVCFields = []struct {
+	name string
+	path []string
+}{
+	{"tuning.input", []string{"tuning", "input", "volumeSource", "persistentVolumeClaim", "claimName"}},
+	{"tuning.output", []string{"tuning", "output", "volume", "persistentVolumeClaim", "claimName"}},
+}
+
+// isManagedBy reports whether obj carries the workspace label or an owner reference
+// to the workspace
+func isManagedBy(obj metav1.Object, workspace *unstructured.Unstructured) bool {
+	if obj.GetLabels()[workspaceLabel] == workspace.GetName() {
+		return true
+	}
+	return hasOwner(obj, workspace.GetUID(), "Workspace", workspace.GetName())
+}
+
+// hasOwner reports whether obj has an owner reference with the given UID, or to the
+// kind and name when the UID is not known
+func hasOwner(obj metav1.Object, uid types.UID, kind, name string) bool {
+	for _, owner := range obj.GetOwnerReferences() {
+		if uid != "" && owner.UID == uid {
+			return true
+		}
+		if uid == "" && owner.Kind == kind && owner.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// collectManagedResources lists the objects in the workspace namespace that carry the
+// workspace label or are owned by the workspace, the pods of its workloads and Jobs,
+// and the PersistentVolumeClaims named by its tuning spec
+func collectManagedResources(ctx context.Context, clientset kubernetes.Interface, workspace *unstructured.Unstructured) (*managedResources, error) {
+	namespace := workspace.GetNamespace()
+	resources := &managedResources{}
+
+	deployments, err := clientset.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployments: %w", err)
+	}
+	for _, deployment := range deployments.Items {
+		if isManagedBy(&deployment, workspace) {
+			resources.Deployments = append(resources.Deployments, deployment)
+		}
+	}
+
+	statefulSets, err := clientset.AppsV1().StatefulSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list statefulsets: %w", err)
+	}
+	for _, statefulSet := range statefulSets.Items {
+		if isManagedBy(&statefulSet, workspace) {
+			resources.StatefulSets = append(resources.StatefulSets, statefulSet)
+		}
+	}
+
+	services, err := clientset.CoreV1().Services(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list services: %w", err)
+	}
+	for _, svc := range services.Items {
+		if isManagedBy(&svc, workspace) {
+			resources.Services = append(resources.Services, svc)
+		}
+	}
+
+	jobs, err := clientset.BatchV1().Jobs(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jobs: %w", err)
+	}
+	for _, job := range jobs.Items {
+		if isManagedBy(&job, workspace) {
+			resources.Jobs = append(resources.Jobs, job)
+		}
+	}
+
+	pods, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+	for _, pod := range pods.Items {
+		if isManagedBy(&pod, workspace) || resources.ownsPod(&pod) {
+			resources.Pods = append(resources.Pods, pod)
+		}
+	}
+
+	claims, err := clientset.CoreV1().PersistentVolumeClaims(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list persistentvolumeclaims: %w", err)
+	}
+	for i := range claims.Items {
+		if isManagedBy(&claims.Items[i], workspace) {
+			resources.PVCs = append(resources.PVCs, managedPVC{Name: claims.Items[i].Name, Claim: &claims.Items[i]})
+		}
+	}
+	for _, field := range tuningPVCFields {
+		name, _, _ := unstructured.NestedString(workspace.Object, field.path...)
+		if name == "" {
+			continue
+		}
+		if resources.addPVCReference(name, field.name) {
+			continue
+		}
+		claim, err := clientset.CoreV1().PersistentVolumeClaims(namespace).Get(ctx, name, metav1.GetOptions{})
+		if errors.IsNotFound(err) {
+			claim = nil
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to get persistentvolumeclaim %s: %w", name, err)
+		}
+		resources.PVCs = append(resources.PVCs, managedPVC{Name: name, Claim: claim, ReferencedBy: field.name})
+	}
+
+	resources.sort()
+	return resources, nil
+}
+
+// addPVCReference records that field names a claim that was already found, and
+// reports whether there was one
+func (r *managedResources) addPVCReference(name, field string) bool {
+	for i := range r.PVCs {
+		if r.PVCs[i].Name != name {
+			continue
+		}
+		if r.PVCs[i].ReferencedBy == "" {
+			r.PVCs[i].ReferencedBy = field
+		} else {
+			r.PVCs[i].ReferencedBy += "," + field
+		}
+		return true
+	}
+	return false
+}
+
+// ownsPod reports whether a managed StatefulSet or Job owns the pod. Deployment pods
+// are owned by ReplicaSets and found through the workspace label instead.
+func (r *managedResources) ownsPod(pod *corev1.Pod) bool {
+	for _, statefulSet := range r.StatefulSets {
+		if hasOwner(pod, statefulSet.UID, "StatefulSet", statefulSet.Name) {
+			return true
+		}
+	}
+	for _, job := range r.Jobs {
+		if hasOwner(pod, job.UID, "Job", job.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *managedResources) sort() {
+	sort.Slice(r.Deployments, func(i, j int) bool { return r.Deployments[i].Name < r.Deployments[j].Name })
+	sort.Slice(r.StatefulSets, func(i, j int) bool { return r.StatefulSets[i].Name < r.StatefulSets[j].Name })
+	sort.Slice(r.Services, func(i, j int) bool { return r.Services[i].Name < r.Services[j].Name })
+	sort.Slice(r.Jobs, func(i, j int) bool { return r.Jobs[i].Name < r.Jobs[j].Name })
+	sort.Slice(r.Pods, func(i, j int) bool { return r.Pods[i].Name < r.Pods[j].Name })
+	sort.SliceStable(r.PVCs, func(i, j int) bool { return r.PVCs[i].Name < r.PVCs[j].Name })
+}
+
+// isEmpty reports whether no managed resources were found
+func (r *managedResources) isEmpty() bool {
+	return len(r.Deployments)+len(r.StatefulSets)+len(r.Services)+len(r.Jobs)+len(r.Pods)+len(r.PVCs) == 0
+}
+
+// writeManagedResources prints the managed resources grouped by kind, leaving out
+// kinds without objects
+func writeManagedResources(out io.Writer, r *managedResources) {
+	w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	defer w.Flush()
+
+	if r.isEmpty() {
+		fmt.Fprintln(w, "No managed resources found")
+		return
+	}
+
+	if len(r.Deployments) > 0 {
+		fmt.Fprintln(w, "Deployments:")
+		fmt.Fprintln(w, "  NAME\tREADY\tAGE")
+		for _, deployment := range r.Deployments {
+			fmt.Fprintf(w, "  %s\t%d/%d\t%s\n", deployment.Name, deployment.Status.ReadyReplicas,
+				replicaCount(deployment.Spec.Replicas), objectAge(deployment.CreationTimestamp))
+		}
+	}
+	if len(r.StatefulSets) > 0 {
+		fmt.Fprintln(w, "StatefulSets:")
+		fmt.Fprintln(w, "  NAME\tREADY\tAGE")
+		for _, statefulSet := range r.StatefulSets {
+			fmt.Fprintf(w, "  %s\t%d/%d\t%s\n", statefulSet.Name, statefulSet.Status.ReadyReplicas,
+				replicaCount(statefulSet.Spec.Replicas), objectAge(statefulSet.CreationTimestamp))
+		}
+	}
+	if len(r.Services) > 0 {
+		fmt.Fprintln(w, "Services:")
+		fmt.Fprintln(w, "  NAME\tTYPE\tCLUSTER-IP\tPORTS\tAGE")
+		for _, svc := range r.Services {
+			var ports []string
+			for _, port := range svc.Spec.Ports {
+				ports = append(ports, fmt.Sprintf("%d/%s", port.Port, port.Protocol))
+			}
+			fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\n", svc.Name, svc.Spec.Type, svc.Spec.ClusterIP,
+				strings.Join(ports, ","), objectAge(svc.CreationTimestamp))
+		}
+	}
+	if len(r.Jobs) > 0 {
+		fmt.Fprintln(w, "Jobs:")
+		fmt.Fprintln(w, "  NAME\tSTATUS\tAGE")
+		for i := range r.Jobs {
+			fmt.Fprintf(w, "  %s\t%s\t%s\n", r.Jobs[i].Name, tuningJobState(&r.Jobs[i]), objectAge(r.Jobs[i].CreationTimestamp))
+		}
+	}
+	if len(r.Pods) > 0 {
+		fmt.Fprintln(w, "Pods:")
+		fmt.Fprintln(w, "  NAME\tSTATUS\tRESTARTS\tNODE\tAGE")
+		for i := range r.Pods {
+			pod := &r.Pods[i]
+			phase, restarts := podPhaseAndRestarts(pod)
+			node := pod.Spec.NodeName
+			if node == "" {
+				node = "<none>"
+			}
+			fmt.Fprintf(w, "  %s\t%s\t%d\t%s\t%s\n", pod.Name, phase, restarts, node, objectAge(pod.CreationTimestamp))
+		}
+	}
+	if len(r.PVCs) > 0 {
+		fmt.Fprintln(w, "PersistentVolumeClaims:")
+		fmt.Fprintln(w, "  NAME\tSTATUS\tREFERENCED BY\tAGE")
+		for _, pvc := range r.PVCs {
+			status, age := "<not found>", "<none>"
+			if pvc.Claim != nil {
+				status, age = string(pvc.Claim.Status.Phase), objectAge(pvc.Claim.CreationTimestamp)
+			}
+			referencedBy := pvc.ReferencedBy
+			if referencedBy == "" {
+				referencedBy = "<none>"
+			}
+			fmt.Fprintf(w, "  %s\t%s\t%s\t%s\n", pvc.Name, status, referencedBy, age)
+		}
+	}
+}
+
+// replicaCount returns the desired replicas of a workload, which default to 1
+func replicaCount(replicas *int32) int32 {
+	if replicas == nil {
+		return 1
+	}
+	return *replicas
+}
+
+// objectAge returns the age of an object in kubectl's short format, or Unknown
+func objectAge(created metav1.Time) string {
+	if created.IsZero() {
+		return "Unknown"
+	}
+	return shortDuration(time.Since(created.Time))
+}
@@ -0,0 +1,113 @@
+/*
+Copyright (c) 2024 Kaito Project
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestCollectManagedResources(t *testing.T) {
+	workspace := newTestWorkspace("my-ws", "default", nil)
+	workspace.SetUID("ws-uid")
+	ownedByWorkspace := []metav1.OwnerReference{{Kind: "Workspace", Name: "my-ws", UID: "ws-uid"}}
+	labeled := map[string]string{workspaceLabel: "my-ws"}
+
+	clientset := fake.NewSimpleClientset(
+		&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-ws", Namespace: "default", UID: "sts-uid", OwnerReferences: ownedByWorkspace},
+			Status:     appsv1.StatefulSetStatus{ReadyReplicas: 1},
+		},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "unrelated", Namespace: "default"}},
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-ws", Namespace: "default", OwnerReferences: ownedByWorkspace},
+			Spec: corev1.ServiceSpec{
+				Type:      corev1.ServiceTypeClusterIP,
+				ClusterIP: "10.0.0.10",
+				Ports:     []corev1.ServicePort{{Port: 80, Protocol: corev1.ProtocolTCP}},
+			},
+		},
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "my-ws-headless", Namespace: "default", Labels: labeled}},
+		&batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "my-ws", Namespace: "default", UID: "job-uid", OwnerReferences: ownedByWorkspace}},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-ws-0", Namespace: "default",
+				OwnerReferences: []metav1.OwnerReference{{Kind: "StatefulSet", Name: "my-ws", UID: "sts-uid"}}},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning, ContainerStatuses: []corev1.ContainerStatus{{RestartCount: 2}}},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-ws-tuning", Namespace: "default",
+				OwnerReferences: []metav1.OwnerReference{{Kind: "Job", Name: "my-ws", UID: "job-uid"}}},
+		},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "my-ws-abc", Namespace: "default", Labels: labeled}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "other-ws-0", Namespace: "default", Labels: map[string]string{workspaceLabel: "other-ws"}}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "my-ws-0", Namespace: "other", Labels: labeled}},
+		&corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "training-data", Namespace: "default"},
+			Status:     corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimBound},
+		},
+	)
+	assert.NoError(t, unstructured.SetNestedField(workspace.Object, "training-data", tuningPVCFields[0].path...))
+	assert.NoError(t, unstructured.SetNestedField(workspace.Object, "results", tuningPVCFields[1].path...))
+
+	resources, err := collectManagedResources(context.TODO(), clientset, workspace)
+	assert.NoError(t, err)
+
+	names := func(objects ...metav1.Object) []string {
+		var result []string
+		for _, obj := range objects {
+			result = append(result, obj.GetName())
+		}
+		return result
+	}
+	assert.Empty(t, resources.Deployments)
+	assert.Len(t, resources.StatefulSets, 1)
+	assert.Equal(t, []string{"my-ws", "my-ws-headless"}, names(&resources.Services[0], &resources.Services[1]))
+	assert.Len(t, resources.Jobs, 1)
+	assert.Len(t, resources.Pods, 3)
+	assert.Equal(t, []string{"my-ws-0", "my-ws-abc", "my-ws-tuning"}, names(&resources.Pods[0], &resources.Pods[1], &resources.Pods[2]))
+	assert.Len(t, resources.PVCs, 2)
+	assert.Equal(t, "results", resources.PVCs[0].Name)
+	assert.Nil(t, resources.PVCs[0].Claim, "a missing referenced claim is still listed")
+	assert.Equal(t, "tuning.input", resources.PVCs[1].ReferencedBy)
+
+	var out bytes.Buffer
+	writeManagedResources(&out, resources)
+	output := out.String()
+	assert.Contains(t, output, "StatefulSets:\n")
+	assert.NotContains(t, output, "Deployments:")
+	assert.Regexp(t, `my-ws\s+ClusterIP\s+10\.0\.0\.10\s+80/TCP`, output)
+	assert.Regexp(t, `my-ws-0\s+Running\s+2\s+<none>`, output)
+	assert.Regexp(t, `results\s+<not found>\s+tuning\.output`, output)
+	assert.Regexp(t, `training-data\s+Bound\s+tuning\.input`, output)
+
+	t.Run("No managed resources", func(t *testing.T) {
+		resources, err := collectManagedResources(context.TODO(), fake.NewSimpleClientset(), newTestWorkspace("my-ws", "default", nil))
+		assert.NoError(t, err)
+		out.Reset()
+		writeManagedResources(&out, resources)
+		assert.Equal(t, "No managed resources found\n", out.String())
+	})
+}
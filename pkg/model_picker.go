@@ -0,0 +1,79 @@
+/*
+Copyright (c) 2024 Kaito Project
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// pickModel prompts for a model from the supported models list. Typing text
+// narrows the list the same way as 'models list --search', typing a number
+// selects that entry, and an empty line picks the only remaining match.
+func pickModel(in io.Reader, out io.Writer, models []Model) (string, error) {
+	if len(models) == 0 {
+		return "", fmt.Errorf("model name is required; no supported models are available to pick from")
+	}
+
+	scanner := bufio.NewScanner(in)
+	matches := models
+	for {
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, "Supported models:")
+		for i, model := range matches {
+			fmt.Fprintf(out, "  %2d) %-40s %s\n", i+1, model.Name, model.Type)
+		}
+		printStatus(out, "🔍 Type to filter, a number to select, or 'q' to quit: ")
+
+		if !scanner.Scan() {
+			if err := scanner.Err(); err != nil {
+				return "", fmt.Errorf("failed to read model selection: %w", err)
+			}
+			return "", fmt.Errorf("model name is required (no model selected)")
+		}
+		input := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case input == "q" || input == "quit":
+			return "", fmt.Errorf("model name is required (selection cancelled)")
+		case input == "":
+			if len(matches) == 1 {
+				return matches[0].Name, nil
+			}
+			printStatus(out, "ℹ️  Enter a number to select a model\n")
+			continue
+		}
+
+		if index, err := strconv.Atoi(input); err == nil {
+			if index < 1 || index > len(matches) {
+				printStatus(out, "⚠️  Choose a number between 1 and %d\n", len(matches))
+				continue
+			}
+			return matches[index-1].Name, nil
+		}
+
+		filtered := searchModels(models, input)
+		if len(filtered) == 0 {
+			printStatus(out, "⚠️  No models match %q\n", input)
+			continue
+		}
+		matches = filtered
+	}
+}
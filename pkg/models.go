@@ -18,14 +18,25 @@ package cmd
 
 import (
 	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"syscall"
 	"text/tabwriter"
 	"time"
+	"unicode"
 
 	"github.com/spf13/cobra"
 	"golang.org/x/text/cases"
@@ -38,8 +49,28 @@ import (
 // SupportedModelsURL is the official URL for Kaito supported models
 const SupportedModelsURL = "https://raw.githubusercontent.com/kaito-project/kaito/main/presets/workspace/models/supported_models.yaml"
 
+// modelsURL is where the supported models list is fetched from, configurable via
+// '--models-url' to point at an internal mirror
+var modelsURL = SupportedModelsURL
+
+// modelsCAFile is a PEM bundle to trust when fetching the models list, configurable
+// via '--models-ca-file' for mirrors served with a private CA
+var modelsCAFile string
+
+// modelsRootCAs holds the system roots plus the --models-ca-file certificates, or
+// nil to use the system roots only; it is loaded before a command runs
+var modelsRootCAs *x509.CertPool
+
+// ModelAdapter describes an adapter that can be loaded on top of a model
+type ModelAdapter struct {
+	Name        string `json:"name" yaml:"name"`
+	Source      string `json:"source,omitempty" yaml:"source,omitempty"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
 // Model represents a supported AI model from the official Kaito repository
 type Model struct {
+	Adapters     []ModelAdapter    `json:"adapters,omitempty" yaml:"adapters,omitempty"`
 	Tags         []string          `json:"tags" yaml:"tags"`
 	Properties   map[string]string `json:"properties,omitempty" yaml:"properties,omitempty"`
 	Name         string            `json:"name" yaml:"name"`
@@ -50,13 +81,17 @@ type Model struct {
 	Tag          string            `json:"tag" yaml:"tag"`
 	GPUMemory    string            `json:"gpu_memory" yaml:"gpuMemory"`
 	InstanceType string            `json:"instance_type,omitempty" yaml:"instanceType,omitempty"`
-	MinNodes     int               `json:"min_nodes" yaml:"minNodes"`
-	MaxNodes     int               `json:"max_nodes" yaml:"maxNodes"`
+	// MinNodes and MaxNodes are the node range declared by the catalog; 0 means the
+	// catalog does not declare that bound and the count is not checked against it
+	MinNodes int `json:"min_nodes,omitempty" yaml:"minNodes,omitempty"`
+	MaxNodes int `json:"max_nodes,omitempty" yaml:"maxNodes,omitempty"`
 }
 
 // KaitoSupportedModelsResponse represents the structure of the official supported_models.yaml
 type KaitoSupportedModelsResponse struct {
 	Models []struct {
+		Adapters     []ModelAdapter    `yaml:"adapters,omitempty"`
+		Tags         []string          `yaml:"tags,omitempty"`
 		Properties   map[string]string `yaml:"properties,omitempty"`
 		Name         string            `yaml:"name"`
 		Version      string            `yaml:"version,omitempty"`
@@ -71,38 +106,397 @@ type KaitoSupportedModelsResponse struct {
 	} `yaml:"models"`
 }
 
+// fallbackModels is used when the official supported models list cannot be fetched
+// or cached; it covers the common Kaito presets and may lag behind the official list
+var fallbackModels = []Model{
+	newFallbackModel("deepseek-r1-distill-llama-8b"),
+	newFallbackModel("deepseek-r1-distill-qwen-14b"),
+	newFallbackModel("falcon-7b"),
+	newFallbackModel("falcon-7b-instruct"),
+	newFallbackModel("falcon-40b"),
+	newFallbackModel("falcon-40b-instruct"),
+	newFallbackModel("llama-3.1-8b-instruct"),
+	newFallbackModel("llama-3.3-70b-instruct"),
+	newFallbackModel("mistral-7b"),
+	newFallbackModel("mistral-7b-instruct"),
+	newFallbackModel("phi-2"),
+	newFallbackModel("phi-3-mini-4k-instruct"),
+	newFallbackModel("phi-3-mini-128k-instruct"),
+	newFallbackModel("phi-3-medium-4k-instruct"),
+	newFallbackModel("phi-3-medium-128k-instruct"),
+	newFallbackModel("phi-3.5-mini-instruct"),
+	newFallbackModel("phi-4"),
+	newFallbackModel("phi-4-mini-instruct"),
+	newFallbackModel("qwen2.5-coder-7b-instruct"),
+	newFallbackModel("qwen2.5-coder-32b-instruct"),
+}
+
+// Defaults for catalog entries without a type or runtime, matching the values the
+// Kaito supported models list uses for its presets
+const (
+	defaultModelType    = "text-generation"
+	defaultModelRuntime = "tfs"
+)
+
+// newFallbackModel builds a fallback Model entry with the defaults used for fetched models
+func newFallbackModel(name string) Model {
+	return Model{
+		Name:        name,
+		Type:        defaultModelType,
+		Runtime:     defaultModelRuntime,
+		Description: fmt.Sprintf("Kaito preset model: %s (offline fallback list)", name),
+	}
+}
+
+// defaultModelsCacheTTL is how long a cached supported models list is considered fresh
+const defaultModelsCacheTTL = 24 * time.Hour
+
+// modelsCacheTTL is the cache freshness window, configurable via 'models --cache-ttl'
+var modelsCacheTTL = defaultModelsCacheTTL
+
+// modelsCacheMode controls how the local supported models cache is used
+type modelsCacheMode int
+
+const (
+	// modelsCacheDefault reads the cache when fresh and updates it after a network fetch
+	modelsCacheDefault modelsCacheMode = iota
+	// modelsCacheRefresh skips reading the cache but still updates it after a network fetch
+	modelsCacheRefresh
+	// modelsCacheDisabled neither reads nor writes the cache
+	modelsCacheDisabled
+)
+
+// modelsCachePath returns the location of the local supported models cache. A list
+// fetched from a --models-url mirror is cached separately from the official one.
+func modelsCachePath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to determine home directory: %w", err)
+	}
+	name := "models_cache.yaml"
+	if modelsURL != SupportedModelsURL {
+		sum := sha256.Sum256([]byte(modelsURL))
+		name = fmt.Sprintf("models_cache-%x.yaml", sum[:6])
+	}
+	return filepath.Join(home, ".kaito", name), nil
+}
+
+// readModelsCache returns the cached supported_models.yaml body if it is younger than ttl
+func readModelsCache(ttl time.Duration) ([]byte, error) {
+	cachePath, err := modelsCachePath()
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := os.Stat(cachePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat models cache: %w", err)
+	}
+	if age := time.Since(info.ModTime()); age > ttl {
+		return nil, fmt.Errorf("models cache is stale (age %s, ttl %s)", age.Round(time.Second), ttl)
+	}
+
+	body, err := os.ReadFile(cachePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read models cache: %w", err)
+	}
+	return body, nil
+}
+
+// writeModelsCache stores the supported_models.yaml body in the local cache
+func writeModelsCache(body []byte) error {
+	cachePath, err := modelsCachePath()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(cachePath), 0o755); err != nil {
+		return fmt.Errorf("failed to create models cache directory: %w", err)
+	}
+	if err := os.WriteFile(cachePath, body, 0o644); err != nil {
+		return fmt.Errorf("failed to write models cache: %w", err)
+	}
+	return nil
+}
+
+// modelsCacheValidators are the HTTP validators of the cached supported models list.
+// They are sent back on the next fetch so an unchanged list is not downloaded again.
+type modelsCacheValidators struct {
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+}
+
+// isEmpty reports whether there is no validator for a conditional request
+func (v modelsCacheValidators) isEmpty() bool {
+	return v.ETag == "" && v.LastModified == ""
+}
+
+// modelsCacheValidatorsPath returns where the validators of the models cache are stored,
+// next to the cache file they describe
+func modelsCacheValidatorsPath() (string, error) {
+	cachePath, err := modelsCachePath()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(cachePath, ".yaml") + ".validators.yaml", nil
+}
+
+// readModelsCacheValidators returns the stored validators of the models cache
+func readModelsCacheValidators() (modelsCacheValidators, error) {
+	var validators modelsCacheValidators
+	validatorsPath, err := modelsCacheValidatorsPath()
+	if err != nil {
+		return validators, err
+	}
+
+	data, err := os.ReadFile(validatorsPath)
+	if err != nil {
+		return validators, fmt.Errorf("failed to read models cache validators: %w", err)
+	}
+	if err := yaml.Unmarshal(data, &validators); err != nil {
+		return validators, fmt.Errorf("failed to parse models cache validators: %w", err)
+	}
+	return validators, nil
+}
+
+// writeModelsCacheValidators stores the validators of the models cache, removing them
+// when the server sent none
+func writeModelsCacheValidators(validators modelsCacheValidators) error {
+	validatorsPath, err := modelsCacheValidatorsPath()
+	if err != nil {
+		return err
+	}
+
+	if validators.isEmpty() {
+		if err := os.Remove(validatorsPath); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove models cache validators: %w", err)
+		}
+		return nil
+	}
+
+	data, err := yaml.Marshal(validators)
+	if err != nil {
+		return fmt.Errorf("failed to encode models cache validators: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(validatorsPath), 0o755); err != nil {
+		return fmt.Errorf("failed to create models cache directory: %w", err)
+	}
+	if err := os.WriteFile(validatorsPath, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write models cache validators: %w", err)
+	}
+	return nil
+}
+
+// revalidateModelsCache returns the cached body after the server confirmed it is
+// unchanged, and marks the cache fresh again for another TTL
+func revalidateModelsCache() ([]byte, error) {
+	cachePath, err := modelsCachePath()
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := os.ReadFile(cachePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read models cache: %w", err)
+	}
+	now := time.Now()
+	if err := os.Chtimes(cachePath, now, now); err != nil {
+		klog.V(3).Infof("Could not refresh models cache timestamp: %v", err)
+	}
+	return body, nil
+}
+
+// modelsResponse is the result of fetching the supported models list
+type modelsResponse struct {
+	Body []byte
+	// NotModified is set when the server answered a conditional request with 304
+	NotModified bool
+	Validators  modelsCacheValidators
+}
+
+// defaultModelsFetchTimeout bounds each attempt to download the supported models list
+const defaultModelsFetchTimeout = 30 * time.Second
+
+// modelsFetchTimeout is the per-attempt fetch timeout, configurable via '--models-timeout'
+var modelsFetchTimeout = defaultModelsFetchTimeout
+
+// maxModelsFetchAttempts is how many times a transient fetch failure is attempted before giving up
+const maxModelsFetchAttempts = 3
+
+// modelsFetchBackoff is the delay before the first retry; it doubles on each subsequent attempt
+var modelsFetchBackoff = time.Second
+
+// newModelsHTTPClient builds the client used to fetch the supported models list.
+// A single client is shared by all attempts of a fetch so retries reuse open connections.
+// HTTP_PROXY, HTTPS_PROXY, NO_PROXY, --models-ca-file and --insecure-skip-tls-verify
+// are honored.
+func newModelsHTTPClient(timeout time.Duration) *http.Client {
+	transport := newHTTPTransport()
+	if modelsRootCAs != nil {
+		if transport.TLSClientConfig == nil {
+			transport.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+		}
+		transport.TLSClientConfig.RootCAs = modelsRootCAs
+	}
+	return &http.Client{Transport: transport, Timeout: timeout}
+}
+
+// loadCertPool returns the system roots with the PEM certificates of caFile added
+func loadCertPool(caFile string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read --models-ca-file: %w", err)
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		klog.V(4).Infof("System certificate pool not available, trusting only %s: %v", caFile, err)
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("--models-ca-file %s contains no PEM certificates", caFile)
+	}
+	return pool, nil
+}
+
 // fetchSupportedModelsFromKaito retrieves the official supported models from Kaito repository
-func fetchSupportedModelsFromKaito() ([]Model, error) {
-	klog.V(3).Info("Fetching supported models from official Kaito repository")
+func fetchSupportedModelsFromKaito(cacheMode modelsCacheMode) ([]Model, error) {
+	klog.V(3).Infof("Fetching supported models from %s", modelsURL)
+
+	if modelsFetchTimeout <= 0 {
+		return nil, fmt.Errorf("models fetch timeout must be positive, got %s", modelsFetchTimeout)
+	}
+
+	client := newModelsHTTPClient(modelsFetchTimeout)
+	defer client.CloseIdleConnections()
+
+	// Revalidate a stale cache with a conditional request instead of downloading it again
+	var validators modelsCacheValidators
+	if cacheMode == modelsCacheDefault {
+		if stored, err := readModelsCacheValidators(); err == nil {
+			validators = stored
+		} else {
+			klog.V(4).Infof("No models cache validators: %v", err)
+		}
+	}
+
+	resp, err := fetchModelsBody(client, modelsURL, modelsFetchTimeout, validators)
+	if err != nil {
+		return nil, err
+	}
+
+	body := resp.Body
+	if resp.NotModified {
+		klog.V(3).Info("Supported models list not modified, using local cache")
+		if body, err = revalidateModelsCache(); err != nil {
+			klog.V(3).Infof("Could not use models cache, downloading the full list: %v", err)
+			if resp, err = fetchModelsBody(client, modelsURL, modelsFetchTimeout, modelsCacheValidators{}); err != nil {
+				return nil, err
+			}
+			body = resp.Body
+		}
+	}
+
+	models, err := parseSupportedModels(body)
+	if err != nil {
+		return nil, err
+	}
+
+	if cacheMode != modelsCacheDisabled && !resp.NotModified {
+		if err := writeModelsCache(body); err != nil {
+			klog.V(3).Infof("Could not update models cache: %v", err)
+		} else if err := writeModelsCacheValidators(resp.Validators); err != nil {
+			klog.V(3).Infof("Could not update models cache validators: %v", err)
+		}
+	}
+
+	klog.V(3).Infof("Successfully fetched %d models from official Kaito repository", len(models))
+	return models, nil
+}
+
+// fetchModelsBody downloads url, retrying transient failures with exponential backoff.
+// Non-empty validators make the request conditional on the list having changed.
+func fetchModelsBody(client *http.Client, url string, timeout time.Duration, validators modelsCacheValidators) (modelsResponse, error) {
+	backoff := modelsFetchBackoff
+	var lastErr error
+	for attempt := 1; attempt <= maxModelsFetchAttempts; attempt++ {
+		resp, retryable, err := fetchModelsBodyOnce(client, url, timeout, validators)
+		if err == nil {
+			return resp, nil
+		}
+		lastErr = err
+		if !retryable || attempt == maxModelsFetchAttempts {
+			break
+		}
+		klog.V(3).Infof("Attempt %d/%d to fetch supported models failed, retrying in %s: %v", attempt, maxModelsFetchAttempts, backoff, err)
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+	klog.Errorf("Failed to fetch supported models: %v", lastErr)
+	return modelsResponse{}, lastErr
+}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+// fetchModelsBodyOnce performs a single fetch attempt and reports whether a failure is worth retrying
+func fetchModelsBodyOnce(client *http.Client, url string, timeout time.Duration, validators modelsCacheValidators) (modelsResponse, bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
-	req, err := http.NewRequestWithContext(ctx, "GET", SupportedModelsURL, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
-		klog.Errorf("Failed to create request: %v", err)
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return modelsResponse{}, false, fmt.Errorf("failed to create request: %w", err)
+	}
+	if validators.ETag != "" {
+		req.Header.Set("If-None-Match", validators.ETag)
+	}
+	if validators.LastModified != "" {
+		req.Header.Set("If-Modified-Since", validators.LastModified)
 	}
 
-	client := &http.Client{Timeout: 30 * time.Second}
 	resp, err := client.Do(req)
 	if err != nil {
-		klog.Errorf("Failed to fetch supported models: %v", err)
-		return nil, fmt.Errorf("failed to fetch supported models from %s: %w", SupportedModelsURL, err)
+		return modelsResponse{}, isRetryableFetchError(err), fmt.Errorf("failed to fetch supported models from %s: %w", url, err)
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusNotModified && !validators.isEmpty() {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return modelsResponse{NotModified: true, Validators: validators}, false, nil
+	}
 	if resp.StatusCode != http.StatusOK {
-		klog.Errorf("HTTP request failed with status: %d", resp.StatusCode)
-		return nil, fmt.Errorf("HTTP request failed with status %d", resp.StatusCode)
+		// Drain the body so the connection can be reused by the next attempt
+		_, _ = io.Copy(io.Discard, resp.Body)
+		retryable := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+		return modelsResponse{}, retryable, fmt.Errorf("HTTP request failed with status %d", resp.StatusCode)
 	}
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		klog.Errorf("Failed to read response body: %v", err)
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return modelsResponse{}, true, fmt.Errorf("failed to read response body: %w", err)
+	}
+	return modelsResponse{
+		Body: body,
+		Validators: modelsCacheValidators{
+			ETag:         resp.Header.Get("ETag"),
+			LastModified: resp.Header.Get("Last-Modified"),
+		},
+	}, false, nil
+}
+
+// isRetryableFetchError reports whether a transport error is likely transient.
+// DNS lookups that fail outright (e.g. no network) are not retried.
+func isRetryableFetchError(err error) bool {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return dnsErr.IsTimeout || dnsErr.IsTemporary
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
 	}
+	return errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) || errors.Is(err, io.ErrUnexpectedEOF)
+}
 
+// parseSupportedModels converts a supported_models.yaml document into our Model format
+func parseSupportedModels(body []byte) ([]Model, error) {
 	var kaitoModels KaitoSupportedModelsResponse
 	if err := yaml.Unmarshal(body, &kaitoModels); err != nil {
 		klog.Errorf("Failed to parse YAML response: %v", err)
@@ -113,6 +507,8 @@ func fetchSupportedModelsFromKaito() ([]Model, error) {
 	var models []Model
 	for _, km := range kaitoModels.Models {
 		model := Model{
+			Adapters:     km.Adapters,
+			Tags:         km.Tags,
 			Name:         km.Name,
 			Type:         km.Type,
 			Runtime:      km.Runtime,
@@ -128,17 +524,13 @@ func fetchSupportedModelsFromKaito() ([]Model, error) {
 
 		// Set default values if not specified
 		if model.Type == "" {
-			model.Type = "LLM"
+			model.Type = defaultModelType
 		}
 		if model.Runtime == "" {
-			model.Runtime = "vllm"
-		}
-		if model.MinNodes == 0 {
-			model.MinNodes = 1
-		}
-		if model.MaxNodes == 0 {
-			model.MaxNodes = model.MinNodes
+			model.Runtime = defaultModelRuntime
 		}
+		// A missing node range stays 0 so that no bound the catalog never declared
+		// is enforced
 
 		// Generate description if not provided
 		if model.Description == "" {
@@ -148,18 +540,36 @@ func fetchSupportedModelsFromKaito() ([]Model, error) {
 		models = append(models, model)
 	}
 
-	klog.V(3).Infof("Successfully fetched %d models from official Kaito repository", len(models))
 	return models, nil
 }
 
 // getSupportedModels returns supported models, first trying to fetch from official source,
 // falling back to hardcoded list if necessary
 func getSupportedModels() []Model {
+	return loadSupportedModels(modelsCacheDefault)
+}
+
+// loadSupportedModels returns supported models, reading the local cache when it is fresh
+// and allowed by cacheMode before fetching from the official source
+func loadSupportedModels(cacheMode modelsCacheMode) []Model {
 	klog.V(4).Info("Getting supported models list")
 
-	models, err := fetchSupportedModelsFromKaito()
+	if cacheMode == modelsCacheDefault {
+		if body, err := readModelsCache(modelsCacheTTL); err == nil {
+			if models, err := parseSupportedModels(body); err == nil && len(models) > 0 {
+				klog.V(3).Infof("Using %d models from local cache", len(models))
+				return models
+			}
+		} else {
+			klog.V(4).Infof("Models cache not used: %v", err)
+		}
+	}
+
+	models, err := fetchSupportedModelsFromKaito(cacheMode)
 	if err != nil || len(models) == 0 {
 		klog.Errorf("Failed to fetch from official repository, using fallback models: %v", err)
+		klog.Warning("The fallback models list may be stale; some newer Kaito presets may be missing")
+		return fallbackModels
 	}
 
 	return models
@@ -167,13 +577,17 @@ func getSupportedModels() []Model {
 
 // ValidateModelName checks if the provided model name is supported by Kaito
 func ValidateModelName(modelName string) error {
+	return validateModelName(getSupportedModels(), modelName)
+}
+
+// validateModelName checks modelName against an already loaded catalog
+func validateModelName(models []Model, modelName string) error {
 	klog.V(4).Infof("Validating model name: %s", modelName)
 
 	if modelName == "" {
 		return fmt.Errorf("model name cannot be empty")
 	}
 
-	models := getSupportedModels()
 	for _, model := range models {
 		if model.Name == modelName {
 			klog.V(4).Infof("Model %s is valid", modelName)
@@ -181,6 +595,11 @@ func ValidateModelName(modelName string) error {
 		}
 	}
 
+	return unsupportedModelError(modelName, models)
+}
+
+// unsupportedModelError reports a model name missing from models, suggesting similar names
+func unsupportedModelError(modelName string, models []Model) error {
 	// Generate suggestions for similar model names
 	suggestions := []string{}
 	lowerModelName := strings.ToLower(modelName)
@@ -204,8 +623,10 @@ func ValidateModelName(modelName string) error {
 // NewModelsCmd creates the models command with subcommands
 func NewModelsCmd(configFlags *genericclioptions.ConfigFlags) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "models",
-		Short: "Manage and list supported AI models",
+		Use: "models",
+		// The model catalog is not namespaced, so -A is accepted and has no effect
+		Annotations: map[string]string{allNamespacesAnnotation: "true"},
+		Short:       "Manage and list supported AI models",
 		Long: `List and describe supported AI models available in Kaito.
 
 This command helps you discover which models are supported, their requirements,
@@ -220,8 +641,17 @@ official Kaito repository to ensure accuracy.`,
   # Describe a specific model
   kubectl kaito models describe phi-3.5-mini-instruct
 
+  # List adapters available for a model
+  kubectl kaito models adapters phi-3.5-mini-instruct
+
+  # Compare models side by side
+  kubectl kaito models compare phi-3.5-mini-instruct phi-4
+
+  # Check model names before deploying, e.g. in CI
+  kubectl kaito models validate phi-4 llama-3.1-8b-instruct
+
   # Filter models by type
-  kubectl kaito models list --type LLM
+  kubectl kaito models list --type text-generation
 
   # Filter models by tags
   kubectl kaito models list --tags microsoft,small`,
@@ -231,18 +661,40 @@ official Kaito repository to ensure accuracy.`,
 		},
 	}
 
+	cmd.PersistentFlags().DurationVar(&modelsCacheTTL, "cache-ttl", defaultModelsCacheTTL, "How long the locally cached models list is considered fresh")
+
 	// Add subcommands
 	cmd.AddCommand(newModelsListCmd(configFlags))
 	cmd.AddCommand(newModelsDescribeCmd())
+	cmd.AddCommand(newModelsAdaptersCmd())
+	cmd.AddCommand(newModelsCompareCmd())
+	cmd.AddCommand(newModelsValidateCmd())
 
 	return cmd
 }
 
+// ModelsListOptions holds the options for the models list command
+type ModelsListOptions struct {
+	Tags      []string
+	Search    string
+	Type      string
+	GroupBy   string
+	Output    string
+	Detailed  bool
+	NoHeaders bool
+	Refresh   bool
+	NoCache   bool
+}
+
+// Output formats accepted by models list --output
+const (
+	modelsOutputTable = "table"
+	modelsOutputJSON  = "json"
+	modelsOutputName  = "name"
+)
+
 func newModelsListCmd(configFlags *genericclioptions.ConfigFlags) *cobra.Command {
-	var (
-		detailed   bool
-		outputJSON bool
-	)
+	o := &ModelsListOptions{}
 
 	cmd := &cobra.Command{
 		Use:   "list",
@@ -258,14 +710,43 @@ Models are fetched from the official Kaito repository to ensure accuracy.`,
   kubectl kaito models list --detailed
 
   # Output in JSON format
-  kubectl kaito models list --output json`,
+  kubectl kaito models list --output json
+
+  # Print only the model names, one per line, for shell pipelines
+  kubectl kaito models list -o name | fzf
+
+  # Print the table without the header and footer lines
+  kubectl kaito models list --no-headers
+
+  # Search models by name, family, tags, or description
+  kubectl kaito models list --search llama3
+
+  # Filter models by type and tags
+  kubectl kaito models list --type text-generation --tags microsoft,small
+
+  # Compare the variants of each family
+  kubectl kaito models list --detailed --group-by family
+
+  # Re-fetch the list instead of using the local cache
+  kubectl kaito models list --refresh`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runModelsList(detailed, outputJSON)
+			if err := o.validate(); err != nil {
+				klog.Errorf("Validation failed: %v", err)
+				return fmt.Errorf("validation failed: %w", err)
+			}
+			return o.run()
 		},
 	}
 
-	cmd.Flags().BoolVar(&detailed, "detailed", false, "Show detailed model information")
-	cmd.Flags().BoolVar(&outputJSON, "output", false, "Output in JSON format")
+	cmd.Flags().BoolVar(&o.Detailed, "detailed", false, "Show detailed model information")
+	cmd.Flags().StringVar(&o.GroupBy, "group-by", "", "Group models by family; with --detailed each family gets a header")
+	cmd.Flags().StringVarP(&o.Output, "output", "o", modelsOutputTable, "Output format: table, json or name (model names only, one per line)")
+	cmd.Flags().BoolVar(&o.NoHeaders, "no-headers", false, "Print the table without the header line and the closing note")
+	cmd.Flags().StringVar(&o.Search, "search", "", "Fuzzy search models by name, family, tags, or description")
+	cmd.Flags().StringVar(&o.Type, "type", "", "Only show models of this type")
+	cmd.Flags().StringSliceVar(&o.Tags, "tags", nil, "Only show models that have all of these tags (comma-separated)")
+	cmd.Flags().BoolVar(&o.Refresh, "refresh", false, "Re-fetch the models list and update the local cache")
+	cmd.Flags().BoolVar(&o.NoCache, "no-cache", false, "Bypass the local models cache entirely")
 
 	return cmd
 }
@@ -293,20 +774,492 @@ func newModelsDescribeCmd() *cobra.Command {
 	return cmd
 }
 
-func runModelsList(detailed, outputJSON bool) error {
+func (o *ModelsListOptions) validate() error {
+	if o.GroupBy != "" && o.GroupBy != modelGroupByFamily {
+		return fmt.Errorf("invalid --group-by %q; must be %q", o.GroupBy, modelGroupByFamily)
+	}
+	switch o.Output {
+	case "", modelsOutputTable:
+		if o.NoHeaders && o.Detailed {
+			return fmt.Errorf("--no-headers cannot be used with --detailed")
+		}
+	case modelsOutputJSON, modelsOutputName:
+		if o.NoHeaders {
+			return fmt.Errorf("--no-headers can only be used with the table output")
+		}
+	default:
+		return fmt.Errorf("invalid --output %q; must be %q, %q or %q", o.Output, modelsOutputTable, modelsOutputJSON, modelsOutputName)
+	}
+	return nil
+}
+
+func (o *ModelsListOptions) run() error {
 	klog.V(2).Info("Listing supported models")
 
-	models := getSupportedModels()
+	models := loadSupportedModels(o.cacheMode())
+	models = filterModels(models, o.Type, o.Tags)
+	if o.Search != "" {
+		models = searchModels(models, o.Search)
+	}
+
+	var groups []modelGroup
+	if o.GroupBy == modelGroupByFamily {
+		groups = groupModelsByFamily(models)
+		models = nil
+		for _, group := range groups {
+			models = append(models, group.Models...)
+		}
+	}
 
-	if outputJSON {
+	switch o.Output {
+	case modelsOutputJSON:
 		return printModelsJSON(models)
+	case modelsOutputName:
+		printModelNames(os.Stdout, models)
+		return nil
+	}
+
+	// Without headers the output is meant for other tools, so no hint either
+	if len(models) == 0 && o.hasFilters() && !o.NoHeaders {
+		fmt.Println("No models matched the given filters.")
+		fmt.Println("Use 'kubectl kaito models list' without --type, --tags, or --search to see all supported models.")
+		return nil
 	}
 
-	if detailed {
-		return printModelsDetailed(models)
+	if o.Detailed && groups != nil {
+		return printModelsDetailedByFamily(os.Stdout, groups)
+	}
+	if o.Detailed {
+		return printModelsDetailed(os.Stdout, models)
 	}
 
-	return printModelsTable(models)
+	return printModelsTable(os.Stdout, models, o.NoHeaders)
+}
+
+// modelGroupByFamily is the --group-by value that groups models by extractModelFamily
+const modelGroupByFamily = "family"
+
+// modelGroup is one family of models for --group-by family
+type modelGroup struct {
+	Family string
+	Models []Model
+}
+
+// groupModelsByFamily clusters models by family. Families are sorted by name and
+// the models of a family by tag, newest first, then by name.
+func groupModelsByFamily(models []Model) []modelGroup {
+	index := map[string]int{}
+	var groups []modelGroup
+	for _, model := range models {
+		family := extractModelFamily(model.Name)
+		i, found := index[family]
+		if !found {
+			i = len(groups)
+			index[family] = i
+			groups = append(groups, modelGroup{Family: family})
+		}
+		groups[i].Models = append(groups[i].Models, model)
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		return strings.ToLower(groups[i].Family) < strings.ToLower(groups[j].Family)
+	})
+	for _, group := range groups {
+		sort.SliceStable(group.Models, func(i, j int) bool {
+			if c := compareModelTags(group.Models[i].Tag, group.Models[j].Tag); c != 0 {
+				return c > 0
+			}
+			return group.Models[i].Name < group.Models[j].Name
+		})
+	}
+	return groups
+}
+
+// compareModelTags compares dotted tags such as 0.2.0 part by part, numerically where
+// both parts are numbers. It returns a positive number when a is newer than b.
+func compareModelTags(a, b string) int {
+	aParts, bParts := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(aParts) || i < len(bParts); i++ {
+		var aPart, bPart string
+		if i < len(aParts) {
+			aPart = aParts[i]
+		}
+		if i < len(bParts) {
+			bPart = bParts[i]
+		}
+		aNum, aErr := strconv.Atoi(aPart)
+		bNum, bErr := strconv.Atoi(bPart)
+		switch {
+		case aErr == nil && bErr == nil && aNum != bNum:
+			return aNum - bNum
+		case (aErr != nil || bErr != nil) && aPart != bPart:
+			return strings.Compare(aPart, bPart)
+		}
+	}
+	return 0
+}
+
+// hasFilters reports whether any of --type, --tags, or --search were given
+func (o *ModelsListOptions) hasFilters() bool {
+	return o.Type != "" || len(o.Tags) > 0 || o.Search != ""
+}
+
+// cacheMode maps the --refresh and --no-cache flags to a models cache mode
+func (o *ModelsListOptions) cacheMode() modelsCacheMode {
+	switch {
+	case o.NoCache:
+		return modelsCacheDisabled
+	case o.Refresh:
+		return modelsCacheRefresh
+	default:
+		return modelsCacheDefault
+	}
+}
+
+// filterModels returns the models matching modelType (case-insensitive) and carrying all of tags
+func filterModels(models []Model, modelType string, tags []string) []Model {
+	var filtered []Model
+	for _, model := range models {
+		if modelType != "" && !strings.EqualFold(model.Type, modelType) {
+			continue
+		}
+		if !hasAllTags(model, tags) {
+			continue
+		}
+		filtered = append(filtered, model)
+	}
+	return filtered
+}
+
+// hasAllTags reports whether the model carries every requested tag (case-insensitive)
+func hasAllTags(model Model, tags []string) bool {
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			continue
+		}
+		found := false
+		for _, modelTag := range model.Tags {
+			if strings.EqualFold(modelTag, tag) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// searchModels returns the models matching term, best matches first
+func searchModels(models []Model, term string) []Model {
+	type scoredModel struct {
+		model Model
+		score int
+	}
+
+	var matches []scoredModel
+	for _, model := range models {
+		if score := modelSearchScore(model, term); score >= 0 {
+			matches = append(matches, scoredModel{model: model, score: score})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score < matches[j].score
+	})
+
+	results := make([]Model, len(matches))
+	for i, match := range matches {
+		results[i] = match.model
+	}
+	return results
+}
+
+// modelSearchScore ranks how well a model matches a search term. Lower is better
+// and -1 means no match. Punctuation is ignored so 'llama3' matches 'llama-3.1-8b-instruct'.
+func modelSearchScore(model Model, term string) int {
+	needle := normalizeSearchText(term)
+	if needle == "" {
+		return 0
+	}
+
+	name := normalizeSearchText(model.Name)
+	switch {
+	case name == needle:
+		return 0
+	case strings.HasPrefix(name, needle):
+		return 1
+	case strings.Contains(name, needle):
+		return 2
+	case strings.Contains(normalizeSearchText(extractModelFamily(model.Name)), needle):
+		return 3
+	}
+
+	for _, tag := range model.Tags {
+		if strings.Contains(normalizeSearchText(tag), needle) {
+			return 3
+		}
+	}
+
+	if strings.Contains(strings.ToLower(model.Description), strings.ToLower(strings.TrimSpace(term))) {
+		return 4
+	}
+
+	// Tolerate small typos against the start of the model name
+	maxDistance := len(needle) / 4
+	if maxDistance > 0 {
+		prefix := name
+		if len(prefix) > len(needle) {
+			prefix = prefix[:len(needle)]
+		}
+		if distance := levenshteinDistance(needle, prefix); distance <= maxDistance {
+			return 5 + distance
+		}
+	}
+
+	return -1
+}
+
+// normalizeSearchText lowercases s and strips everything except letters and digits
+func normalizeSearchText(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// levenshteinDistance returns the edit distance between a and b
+func levenshteinDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(rb)]
+}
+
+func newModelsAdaptersCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "adapters <model-name>",
+		Short: "List adapters available for a model",
+		Long: `List the adapters published for a model in the supported models catalog,
+including their source images and descriptions.
+
+Use the adapter names and sources with 'kubectl kaito deploy --adapters' to serve
+multiple LoRA adapters on top of a base model.`,
+		Example: `  # List adapters for the Phi-3.5 model
+  kubectl kaito models adapters phi-3.5-mini-instruct`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runModelsAdapters(args[0])
+		},
+	}
+
+	return cmd
+}
+
+func runModelsAdapters(modelName string) error {
+	klog.V(2).Infof("Listing adapters for model: %s", modelName)
+
+	models := getSupportedModels()
+
+	for _, model := range models {
+		if model.Name == modelName {
+			return printModelAdapters(model)
+		}
+	}
+
+	// Use the validation function to provide helpful error message
+	return validateModelName(models, modelName)
+}
+
+func printModelAdapters(model Model) error {
+	klog.V(3).Infof("Printing adapters for model: %s", model.Name)
+
+	if len(model.Adapters) == 0 {
+		fmt.Printf("No adapter metadata is available for model %s.\n", model.Name)
+		fmt.Println()
+		printStatus(os.Stdout, "💡 Adapters can still be loaded from your own images with:\n")
+		fmt.Printf("   kubectl kaito deploy --workspace-name my-workspace --model %s --adapters <name>=<image>[:weight]\n", model.Name)
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tSOURCE\tDESCRIPTION")
+
+	for _, adapter := range model.Adapters {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", adapter.Name, adapter.Source, adapter.Description)
+	}
+
+	return w.Flush()
+}
+
+func newModelsValidateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate <model-name> [<model-name>...]",
+		Short: "Check that model names are supported by Kaito",
+		Long: `Check each model name against the supported models list, the same way deploy
+does. Supported names are reported on stdout; unsupported names are reported on
+stderr with suggestions of similar model names.
+
+The command exits with status 0 when every name is supported and 1 otherwise,
+so it can pre-check model names in CI before a deploy.`,
+		Example: `  # Check a single model name
+  kubectl kaito models validate phi-3.5-mini-instruct
+
+  # Check several model names at once
+  kubectl kaito models validate phi-4 mistral-7b-instruct llama-3.1-8b-instruct`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runModelsValidate(args, getSupportedModels())
+		},
+	}
+
+	return cmd
+}
+
+// runModelsValidate reports whether each name is a supported model and returns an
+// ExitError with code 1 when any of them is not
+func runModelsValidate(modelNames []string, models []Model) error {
+	klog.V(2).Infof("Validating model names: %v", modelNames)
+
+	supported := make(map[string]bool, len(models))
+	for _, model := range models {
+		supported[model.Name] = true
+	}
+
+	invalid := 0
+	for _, name := range modelNames {
+		switch {
+		case name == "":
+			invalid++
+			printStatus(os.Stderr, "❌ model name cannot be empty\n")
+		case supported[name]:
+			printStatus(os.Stdout, "✓ %s: supported\n", name)
+		default:
+			invalid++
+			printStatus(os.Stderr, "❌ %s: %v\n", name, unsupportedModelError(name, models))
+		}
+	}
+
+	if invalid > 0 {
+		return &ExitError{Code: 1}
+	}
+	return nil
+}
+
+func newModelsCompareCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "compare <model-name> <model-name> [<model-name>...]",
+		Short: "Compare supported AI models side by side",
+		Long: `Compare two or more supported AI models in a table with one column per model,
+showing their type, runtime, version, tag, node counts, GPU memory and
+recommended instance type. Useful for capacity planning before a deployment.`,
+		Example: `  # Compare two Phi models
+  kubectl kaito models compare phi-3.5-mini-instruct phi-4
+
+  # Compare three models
+  kubectl kaito models compare falcon-7b mistral-7b llama-3.1-8b-instruct`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runModelsCompare(args)
+		},
+	}
+
+	return cmd
+}
+
+func runModelsCompare(modelNames []string) error {
+	klog.V(2).Infof("Comparing models: %v", modelNames)
+
+	selected, err := lookupModels(getSupportedModels(), modelNames)
+	if err != nil {
+		return err
+	}
+
+	return printModelsComparison(os.Stdout, selected)
+}
+
+// lookupModels returns the named models in argument order, failing on the first unknown name
+func lookupModels(models []Model, modelNames []string) ([]Model, error) {
+	byName := make(map[string]Model, len(models))
+	for _, model := range models {
+		byName[model.Name] = model
+	}
+
+	selected := make([]Model, 0, len(modelNames))
+	for _, name := range modelNames {
+		model, ok := byName[name]
+		if !ok {
+			return nil, unsupportedModelError(name, models)
+		}
+		selected = append(selected, model)
+	}
+	return selected, nil
+}
+
+// printModelsComparison writes a table with one row per attribute and one column per model
+func printModelsComparison(out io.Writer, models []Model) error {
+	klog.V(3).Info("Printing models comparison")
+
+	rows := []struct {
+		label string
+		value func(Model) string
+	}{
+		{"TYPE", func(m Model) string { return m.Type }},
+		{"RUNTIME", func(m Model) string { return m.Runtime }},
+		{"VERSION", func(m Model) string { return m.Version }},
+		{"TAG", func(m Model) string { return m.Tag }},
+		{"MIN NODES", func(m Model) string { return nodeCountString(m.MinNodes) }},
+		{"MAX NODES", func(m Model) string { return nodeCountString(m.MaxNodes) }},
+		{"GPU MEMORY", func(m Model) string { return m.GPUMemory }},
+		{"INSTANCE TYPE", func(m Model) string { return m.InstanceType }},
+	}
+
+	w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+
+	header := []string{"MODEL"}
+	for _, model := range models {
+		header = append(header, model.Name)
+	}
+	fmt.Fprintln(w, strings.Join(header, "\t"))
+
+	for _, row := range rows {
+		cells := []string{row.label}
+		for _, model := range models {
+			cells = append(cells, valueOrDash(row.value(model)))
+		}
+		fmt.Fprintln(w, strings.Join(cells, "\t"))
+	}
+
+	return w.Flush()
+}
+
+// nodeCountString formats a node count, leaving unknown (zero) counts empty
+func nodeCountString(count int) string {
+	if count == 0 {
+		return ""
+	}
+	return strconv.Itoa(count)
 }
 
 func runModelsDescribe(modelName string) error {
@@ -321,7 +1274,7 @@ func runModelsDescribe(modelName string) error {
 	}
 
 	// Use the validation function to provide helpful error message
-	return ValidateModelName(modelName)
+	return validateModelName(models, modelName)
 }
 
 func capitalizeFirst(s string) string {
@@ -376,13 +1329,42 @@ func extractModelFamily(modelName string) string {
 	return "Unknown"
 }
 
-func printModelsTable(models []Model) error {
+// printModelNames prints the name of every model, one per line
+func printModelNames(out io.Writer, models []Model) {
+	for _, model := range models {
+		// Skip base model, as the table does
+		if strings.ToLower(model.Name) == "base" {
+			continue
+		}
+		fmt.Fprintln(out, model.Name)
+	}
+}
+
+// printModelsTable prints one row per model. With noHeaders the header line and
+// the closing note are left out, so every line is a model.
+func printModelsTable(out io.Writer, models []Model, noHeaders bool) error {
 	klog.V(3).Info("Printing models table")
 
-	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	// Sizing columns are only shown when at least one model has data for them
+	showGPUMemory, showParams := false, false
+	for _, model := range models {
+		showGPUMemory = showGPUMemory || model.GPUMemory != ""
+		showParams = showParams || modelParameterSize(model.Name) != ""
+	}
+
+	w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
 	defer w.Flush()
 
-	fmt.Fprintln(w, "NAME\tTYPE\tFAMILY\tRUNTIME\tTAG")
+	header := "NAME\tTYPE\tFAMILY\tRUNTIME\tTAG"
+	if showParams {
+		header += "\tPARAMS"
+	}
+	if showGPUMemory {
+		header += "\tGPU MEMORY"
+	}
+	if !noHeaders {
+		fmt.Fprintln(w, header)
+	}
 
 	for _, model := range models {
 		// Skip base model
@@ -393,45 +1375,114 @@ func printModelsTable(models []Model) error {
 		// Extract family from first part of model name
 		family := extractModelFamily(model.Name)
 
-		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+		row := fmt.Sprintf("%s\t%s\t%s\t%s\t%s",
 			model.Name, model.Type, family, model.Runtime, model.Tag)
+		if showParams {
+			row += "\t" + valueOrDash(modelParameterSize(model.Name))
+		}
+		if showGPUMemory {
+			row += "\t" + valueOrDash(model.GPUMemory)
+		}
+		fmt.Fprintln(w, row)
 	}
 
 	if err := w.Flush(); err != nil {
 		return err
 	}
+	if noHeaders {
+		return nil
+	}
 
-	fmt.Println()
-	fmt.Println("💡 Note: For deployment guidance and instanceType requirements,")
-	fmt.Println("   use 'kubectl kaito models describe <model>' or refer to Kaito workspace examples.")
+	fmt.Fprintln(out)
+	printStatus(out, "💡 Note: For deployment guidance and instanceType requirements,\n")
+	fmt.Fprintln(out, "   use 'kubectl kaito models describe <model>' or refer to Kaito workspace examples.")
 
 	return nil
 }
 
-func printModelsDetailed(models []Model) error {
+// modelParameterSizePattern matches a parameter count segment of a model name,
+// such as "8b", "0.5b", "8x7b" or "350m"
+var modelParameterSizePattern = regexp.MustCompile(`^(\d+x)?\d+(\.\d+)?[bm]$`)
+
+// modelParameterSize derives a rough parameter count like "8B" from a model name,
+// or returns "" when the name does not include one (e.g. phi-3-mini-4k-instruct)
+func modelParameterSize(modelName string) string {
+	for _, part := range strings.Split(strings.ToLower(modelName), "-") {
+		if modelParameterSizePattern.MatchString(part) {
+			return part[:len(part)-1] + strings.ToUpper(part[len(part)-1:])
+		}
+	}
+	return ""
+}
+
+// valueOrDash returns value, or "-" for an empty table cell
+func valueOrDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+func printModelsDetailed(out io.Writer, models []Model) error {
 	klog.V(3).Info("Printing detailed models information")
 
 	for i, model := range models {
 		if i > 0 {
-			fmt.Println()
+			fmt.Fprintln(out)
 		}
 
-		fmt.Printf("Name: %s\n", model.Name)
-		fmt.Printf("Type: %s\n", model.Type)
-		fmt.Printf("Runtime: %s\n", model.Runtime)
-		fmt.Printf("Version: %s\n", model.Version)
-		fmt.Printf("Description: %s\n", model.Description)
+		fmt.Fprintf(out, "Name: %s\n", model.Name)
+		fmt.Fprintf(out, "Type: %s\n", model.Type)
+		fmt.Fprintf(out, "Runtime: %s\n", model.Runtime)
+		fmt.Fprintf(out, "Version: %s\n", model.Version)
+		fmt.Fprintf(out, "Description: %s\n", model.Description)
+		if size := modelParameterSize(model.Name); size != "" {
+			fmt.Fprintf(out, "Parameters: %s\n", size)
+		}
+		if model.GPUMemory != "" {
+			fmt.Fprintf(out, "GPU Memory: %s\n", model.GPUMemory)
+		}
+		if model.MinNodes > 0 || model.MaxNodes > 0 {
+			fmt.Fprintf(out, "Nodes: min %s, max %s\n", valueOrDash(nodeCountString(model.MinNodes)), valueOrDash(nodeCountString(model.MaxNodes)))
+		}
+		if model.InstanceType != "" {
+			fmt.Fprintf(out, "Instance Type: %s\n", model.InstanceType)
+		}
 		if len(model.Tags) > 0 {
-			fmt.Printf("Tags: %s\n", strings.Join(model.Tags, ", "))
+			fmt.Fprintf(out, "Tags: %s\n", strings.Join(model.Tags, ", "))
 		}
 	}
 
 	return nil
 }
 
+// printModelsDetailedByFamily prints the detailed model information under a header per family
+func printModelsDetailedByFamily(out io.Writer, groups []modelGroup) error {
+	for i, group := range groups {
+		if i > 0 {
+			fmt.Fprintln(out)
+		}
+		header := fmt.Sprintf("%s (%d models)", group.Family, len(group.Models))
+		if len(group.Models) == 1 {
+			header = fmt.Sprintf("%s (1 model)", group.Family)
+		}
+		fmt.Fprintln(out, header)
+		fmt.Fprintln(out, strings.Repeat("=", len(header)))
+		if err := printModelsDetailed(out, group.Models); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func printModelsJSON(models []Model) error {
 	klog.V(3).Info("Printing models in JSON format")
 
+	// Print an empty array rather than null when filters excluded everything
+	if models == nil {
+		models = []Model{}
+	}
+
 	jsonData, err := json.MarshalIndent(models, "", "  ")
 	if err != nil {
 		klog.Errorf("Failed to marshal models to JSON: %v", err)
@@ -454,7 +1505,7 @@ func printModelDetail(model Model) error {
 	fmt.Printf("Version: %s\n", model.Version)
 	fmt.Println()
 	fmt.Println("Resource Requirements:")
-	fmt.Println("  💡 GPU requirements are not available in the official Kaito repository.")
+	printStatus(os.Stdout, "  💡 GPU requirements are not available in the official Kaito repository.\n")
 	fmt.Println("     For instanceType guidance, refer to:")
 	fmt.Println("     - Kaito workspace examples in the GitHub repository")
 	fmt.Println("     - Azure VM sizes documentation")
@@ -17,12 +17,50 @@ limitations under the License.
 package cmd
 
 import (
+	"bytes"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 )
 
+// TestMain keeps the tests away from the real ~/.kaito cache and the network: HOME
+// is a temporary directory and the models list is served locally with the fallback
+// presets. Tests that need other models or cache states override both.
+func TestMain(m *testing.M) {
+	home, err := os.MkdirTemp("", "kaito-test-home")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to create test HOME: %v\n", err)
+		os.Exit(1)
+	}
+	os.Setenv("HOME", home)
+
+	var catalog strings.Builder
+	catalog.WriteString("models:\n")
+	for _, model := range fallbackModels {
+		fmt.Fprintf(&catalog, "  - name: %s\n    type: %s\n    runtime: %s\n", model.Name, model.Type, model.Runtime)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(catalog.String()))
+	}))
+	modelsURL = server.URL + "/supported_models.yaml"
+
+	code := m.Run()
+
+	server.Close()
+	os.RemoveAll(home)
+	os.Exit(code)
+}
+
 func TestModelsCmd(t *testing.T) {
 	configFlags := genericclioptions.NewConfigFlags(true)
 	cmd := NewModelsCmd(configFlags)
@@ -36,7 +74,7 @@ func TestModelsCmd(t *testing.T) {
 
 	t.Run("Subcommands present", func(t *testing.T) {
 		subcommands := cmd.Commands()
-		assert.Len(t, subcommands, 2)
+		assert.Len(t, subcommands, 5)
 
 		subcommandNames := make([]string, len(subcommands))
 		for i, subcmd := range subcommands {
@@ -45,6 +83,51 @@ func TestModelsCmd(t *testing.T) {
 
 		assert.Contains(t, subcommandNames, "list")
 		assert.Contains(t, subcommandNames, "describe")
+		assert.Contains(t, subcommandNames, "adapters")
+		assert.Contains(t, subcommandNames, "compare")
+		assert.Contains(t, subcommandNames, "validate")
+	})
+}
+
+func TestModelsListCmd(t *testing.T) {
+	cmd := newModelsListCmd(genericclioptions.NewConfigFlags(true))
+
+	t.Run("Filter flags present", func(t *testing.T) {
+		for _, flagName := range []string{"type", "tags", "search", "detailed", "output", "no-headers"} {
+			assert.NotNil(t, cmd.Flags().Lookup(flagName), "Flag %s should be present", flagName)
+		}
+	})
+
+	t.Run("Tags flag parses comma-separated values", func(t *testing.T) {
+		assert.NoError(t, cmd.Flags().Set("tags", "microsoft,small"))
+		tags, err := cmd.Flags().GetStringSlice("tags")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"microsoft", "small"}, tags)
+	})
+
+	t.Run("Output formats", func(t *testing.T) {
+		for _, output := range []string{"table", "json", "name"} {
+			assert.NoError(t, (&ModelsListOptions{Output: output}).validate(), output)
+		}
+		assert.ErrorContains(t, (&ModelsListOptions{Output: "yaml"}).validate(), `invalid --output "yaml"`)
+		assert.NoError(t, (&ModelsListOptions{Output: "table", NoHeaders: true}).validate())
+		assert.ErrorContains(t, (&ModelsListOptions{Output: "name", NoHeaders: true}).validate(), "only be used with the table output")
+		assert.ErrorContains(t, (&ModelsListOptions{Output: "table", NoHeaders: true, Detailed: true}).validate(), "--detailed")
+
+		assert.NoError(t, cmd.Flags().Parse([]string{"-o", "name"}))
+		assert.Equal(t, "name", cmd.Flags().Lookup("output").Value.String())
+	})
+
+	t.Run("Name output", func(t *testing.T) {
+		var out bytes.Buffer
+		printModelNames(&out, []Model{{Name: "phi-4"}, {Name: "base"}, {Name: "falcon-7b"}})
+		assert.Equal(t, "phi-4\nfalcon-7b\n", out.String())
+	})
+
+	t.Run("Has filters", func(t *testing.T) {
+		assert.False(t, (&ModelsListOptions{Detailed: true}).hasFilters())
+		assert.True(t, (&ModelsListOptions{Type: "LLM"}).hasFilters())
+		assert.True(t, (&ModelsListOptions{Tags: []string{"small"}}).hasFilters())
 	})
 }
 
@@ -162,3 +245,620 @@ func TestExtractModelFamily(t *testing.T) {
 		})
 	}
 }
+
+func TestModelsCache(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	body := []byte(`models:
+  - name: phi-3.5-mini-instruct
+    tag: 0.2.0
+  - name: falcon-7b
+    type: text-generation
+`)
+
+	t.Run("Missing cache", func(t *testing.T) {
+		_, err := readModelsCache(time.Hour)
+		assert.Error(t, err)
+	})
+
+	t.Run("Fresh cache", func(t *testing.T) {
+		assert.NoError(t, writeModelsCache(body))
+
+		cached, err := readModelsCache(time.Hour)
+		assert.NoError(t, err)
+		assert.Equal(t, body, cached)
+
+		models := loadSupportedModels(modelsCacheDefault)
+		assert.Len(t, models, 2)
+		assert.Equal(t, "phi-3.5-mini-instruct", models[0].Name)
+	})
+
+	t.Run("Stale cache", func(t *testing.T) {
+		cachePath, err := modelsCachePath()
+		assert.NoError(t, err)
+		old := time.Now().Add(-2 * time.Hour)
+		assert.NoError(t, os.Chtimes(cachePath, old, old))
+
+		_, err = readModelsCache(time.Hour)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "stale")
+	})
+}
+
+func TestParseSupportedModels(t *testing.T) {
+	models, err := parseSupportedModels([]byte(`models:
+  - name: phi-2
+  - name: falcon-7b
+    type: text-generation
+    runtime: tfs
+    minNodes: 2
+`))
+	assert.NoError(t, err)
+	assert.Len(t, models, 2)
+
+	// Defaults are applied for missing fields
+	assert.Equal(t, defaultModelType, models[0].Type)
+	assert.Equal(t, defaultModelRuntime, models[0].Runtime)
+	assert.Equal(t, newFallbackModel("phi-2").Type, models[0].Type, "fetched and fallback models share defaults")
+	assert.Equal(t, newFallbackModel("phi-2").Runtime, models[0].Runtime, "fetched and fallback models share defaults")
+	assert.Contains(t, models[0].Description, "phi-2")
+
+	// A node range the catalog does not declare is left unset
+	assert.Equal(t, 0, models[0].MinNodes)
+	assert.Equal(t, 0, models[0].MaxNodes)
+	assert.NoError(t, validateNodeCount(models, "phi-2", 2))
+
+	assert.Equal(t, "text-generation", models[1].Type)
+	assert.Equal(t, 2, models[1].MinNodes)
+	assert.Equal(t, 0, models[1].MaxNodes)
+	assert.ErrorContains(t, validateNodeCount(models, "falcon-7b", 1), "at least 2")
+	assert.NoError(t, validateNodeCount(models, "falcon-7b", 8))
+
+	_, err = parseSupportedModels([]byte("models: [unterminated"))
+	assert.Error(t, err)
+}
+
+func TestFallbackModels(t *testing.T) {
+	t.Run("Fallback list has common presets", func(t *testing.T) {
+		names := make([]string, len(fallbackModels))
+		for i, model := range fallbackModels {
+			names[i] = model.Name
+			assert.NotEmpty(t, model.Type)
+			assert.NotEmpty(t, model.Runtime)
+			assert.Zero(t, model.MinNodes, "the fallback list declares no node range")
+			assert.Zero(t, model.MaxNodes, "the fallback list declares no node range")
+		}
+
+		assert.Contains(t, names, "phi-3.5-mini-instruct")
+		assert.Contains(t, names, "llama-3.1-8b-instruct")
+		assert.Contains(t, names, "falcon-7b")
+	})
+}
+
+func TestSearchModels(t *testing.T) {
+	models := []Model{
+		{Name: "falcon-7b", Type: "text-generation", Description: "Falcon base model"},
+		{Name: "llama-3.1-8b-instruct", Type: "text-generation", Tags: []string{"meta"}},
+		{Name: "phi-3.5-mini-instruct", Type: "text-generation", Tags: []string{"microsoft", "small"}},
+		{Name: "phi-4", Type: "text-generation", Tags: []string{"microsoft"}},
+	}
+
+	names := func(models []Model) []string {
+		result := make([]string, len(models))
+		for i, model := range models {
+			result[i] = model.Name
+		}
+		return result
+	}
+
+	tests := []struct {
+		name     string
+		term     string
+		expected []string
+	}{
+		{"Punctuation-insensitive name match", "llama3", []string{"llama-3.1-8b-instruct"}},
+		{"Case-insensitive match", "PHI", []string{"phi-3.5-mini-instruct", "phi-4"}},
+		{"Exact match ranks first", "phi-4", []string{"phi-4", "phi-3.5-mini-instruct"}},
+		{"Tag match", "microsoft", []string{"phi-3.5-mini-instruct", "phi-4"}},
+		{"Description match", "base model", []string{"falcon-7b"}},
+		{"Typo tolerance", "falcno-7b", []string{"falcon-7b"}},
+		{"No match", "mistral", []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, names(searchModels(models, tt.term)))
+		})
+	}
+}
+
+func TestFilterModels(t *testing.T) {
+	models := []Model{
+		{Name: "falcon-7b", Type: "text-generation"},
+		{Name: "phi-3.5-mini-instruct", Type: "text-generation", Tags: []string{"microsoft", "small"}},
+		{Name: "phi-4", Type: "LLM", Tags: []string{"Microsoft"}},
+	}
+
+	t.Run("Filter by type is case-insensitive", func(t *testing.T) {
+		filtered := filterModels(models, "llm", nil)
+		assert.Len(t, filtered, 1)
+		assert.Equal(t, "phi-4", filtered[0].Name)
+	})
+
+	t.Run("Filter by tags requires all tags", func(t *testing.T) {
+		filtered := filterModels(models, "", []string{"microsoft", "small"})
+		assert.Len(t, filtered, 1)
+		assert.Equal(t, "phi-3.5-mini-instruct", filtered[0].Name)
+	})
+
+	t.Run("No filters returns all models", func(t *testing.T) {
+		assert.Len(t, filterModels(models, "", nil), 3)
+	})
+}
+
+func TestLevenshteinDistance(t *testing.T) {
+	assert.Equal(t, 0, levenshteinDistance("phi", "phi"))
+	assert.Equal(t, 1, levenshteinDistance("phi", "pho"))
+	assert.Equal(t, 3, levenshteinDistance("", "abc"))
+	assert.Equal(t, 2, levenshteinDistance("falcno", "falcon"))
+}
+
+func TestParseModelAdapters(t *testing.T) {
+	models, err := parseSupportedModels([]byte(`models:
+  - name: phi-3.5-mini-instruct
+    adapters:
+      - name: phi-3-adapter
+        source: myregistry/phi-3-adapter:0.1.0
+        description: Example adapter
+  - name: falcon-7b
+`))
+	assert.NoError(t, err)
+	assert.Len(t, models, 2)
+
+	assert.Equal(t, []ModelAdapter{{
+		Name:        "phi-3-adapter",
+		Source:      "myregistry/phi-3-adapter:0.1.0",
+		Description: "Example adapter",
+	}}, models[0].Adapters)
+	assert.Empty(t, models[1].Adapters)
+
+	// Models without adapter metadata degrade gracefully
+	assert.NoError(t, printModelAdapters(models[1]))
+}
+
+func TestFetchModelsBody(t *testing.T) {
+	origBackoff := modelsFetchBackoff
+	modelsFetchBackoff = time.Millisecond
+	defer func() { modelsFetchBackoff = origBackoff }()
+
+	t.Run("Retries transient failures", func(t *testing.T) {
+		var calls int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&calls, 1) < maxModelsFetchAttempts {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			_, _ = w.Write([]byte("models:\n  - name: phi-4\n"))
+		}))
+		defer server.Close()
+
+		resp, err := fetchModelsBody(newModelsHTTPClient(time.Second), server.URL, time.Second, modelsCacheValidators{})
+		assert.NoError(t, err)
+		assert.Contains(t, string(resp.Body), "phi-4")
+		assert.Equal(t, int32(maxModelsFetchAttempts), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("Does not retry client errors", func(t *testing.T) {
+		var calls int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		_, err := fetchModelsBody(newModelsHTTPClient(time.Second), server.URL, time.Second, modelsCacheValidators{})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "404")
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("Honors the timeout", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+		}))
+		defer server.Close()
+
+		start := time.Now()
+		_, err := fetchModelsBody(newModelsHTTPClient(20*time.Millisecond), server.URL, 20*time.Millisecond, modelsCacheValidators{})
+		assert.Error(t, err)
+		assert.Less(t, time.Since(start), time.Second)
+	})
+}
+
+func TestModelsTimeoutFlag(t *testing.T) {
+	configFlags := genericclioptions.NewConfigFlags(true)
+	cmd := NewRootCmd(configFlags, true)
+
+	flag := cmd.PersistentFlags().Lookup("models-timeout")
+	assert.NotNil(t, flag)
+	assert.Equal(t, defaultModelsFetchTimeout.String(), flag.DefValue)
+}
+
+func TestModelsURLOverride(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("models:\n  - name: mirrored-model\n"))
+	}))
+	defer server.Close()
+
+	defaultCache, err := modelsCachePath()
+	assert.NoError(t, err)
+
+	origURL := modelsURL
+	modelsURL = server.URL + "/supported_models.yaml"
+	defer func() { modelsURL = origURL }()
+
+	models, err := fetchSupportedModelsFromKaito(modelsCacheDefault)
+	assert.NoError(t, err)
+	assert.Len(t, models, 1)
+	assert.Equal(t, "mirrored-model", models[0].Name)
+
+	// The mirror has its own cache file
+	mirrorCache, err := modelsCachePath()
+	assert.NoError(t, err)
+	assert.NotEqual(t, defaultCache, mirrorCache)
+	assert.FileExists(t, mirrorCache)
+	assert.NoFileExists(t, defaultCache)
+}
+
+func TestModelsConditionalFetch(t *testing.T) {
+	const etag = `"v1"`
+	const lastModified = "Wed, 14 Oct 2026 08:00:00 GMT"
+
+	newServer := func(t *testing.T, withValidators bool) (*int32, *int32) {
+		var downloads, notModified int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if withValidators {
+				if r.Header.Get("If-None-Match") == etag && r.Header.Get("If-Modified-Since") == lastModified {
+					atomic.AddInt32(&notModified, 1)
+					w.WriteHeader(http.StatusNotModified)
+					return
+				}
+				w.Header().Set("ETag", etag)
+				w.Header().Set("Last-Modified", lastModified)
+			} else {
+				assert.Empty(t, r.Header.Get("If-None-Match"), "no validator to send")
+				assert.Empty(t, r.Header.Get("If-Modified-Since"), "no validator to send")
+			}
+			atomic.AddInt32(&downloads, 1)
+			_, _ = w.Write([]byte("models:\n  - name: phi-4\n"))
+		}))
+		t.Cleanup(server.Close)
+
+		origURL := modelsURL
+		modelsURL = server.URL + "/supported_models.yaml"
+		t.Cleanup(func() { modelsURL = origURL })
+		return &downloads, &notModified
+	}
+	expireCache := func(t *testing.T) {
+		cachePath, err := modelsCachePath()
+		assert.NoError(t, err)
+		old := time.Now().Add(-2 * modelsCacheTTL)
+		assert.NoError(t, os.Chtimes(cachePath, old, old))
+	}
+
+	t.Run("Not modified reuses the cache", func(t *testing.T) {
+		t.Setenv("HOME", t.TempDir())
+		downloads, notModified := newServer(t, true)
+
+		models, err := fetchSupportedModelsFromKaito(modelsCacheDefault)
+		assert.NoError(t, err)
+		assert.Len(t, models, 1)
+		validators, err := readModelsCacheValidators()
+		assert.NoError(t, err)
+		assert.Equal(t, modelsCacheValidators{ETag: etag, LastModified: lastModified}, validators)
+
+		expireCache(t)
+		models = loadSupportedModels(modelsCacheDefault)
+		assert.Len(t, models, 1)
+		assert.Equal(t, "phi-4", models[0].Name)
+		assert.Equal(t, int32(1), atomic.LoadInt32(downloads))
+		assert.Equal(t, int32(1), atomic.LoadInt32(notModified))
+
+		// The revalidated cache is fresh again
+		_, err = readModelsCache(time.Hour)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Refresh downloads the full list", func(t *testing.T) {
+		t.Setenv("HOME", t.TempDir())
+		downloads, notModified := newServer(t, true)
+
+		_, err := fetchSupportedModelsFromKaito(modelsCacheDefault)
+		assert.NoError(t, err)
+		_, err = fetchSupportedModelsFromKaito(modelsCacheRefresh)
+		assert.NoError(t, err)
+		assert.Equal(t, int32(2), atomic.LoadInt32(downloads))
+		assert.Equal(t, int32(0), atomic.LoadInt32(notModified))
+	})
+
+	t.Run("Missing cache body falls back to a full download", func(t *testing.T) {
+		t.Setenv("HOME", t.TempDir())
+		downloads, notModified := newServer(t, true)
+		assert.NoError(t, writeModelsCacheValidators(modelsCacheValidators{ETag: etag, LastModified: lastModified}))
+
+		models, err := fetchSupportedModelsFromKaito(modelsCacheDefault)
+		assert.NoError(t, err)
+		assert.Len(t, models, 1)
+		assert.Equal(t, int32(1), atomic.LoadInt32(notModified))
+		assert.Equal(t, int32(1), atomic.LoadInt32(downloads))
+	})
+
+	t.Run("No validators sends a plain GET", func(t *testing.T) {
+		t.Setenv("HOME", t.TempDir())
+		downloads, _ := newServer(t, false)
+
+		_, err := fetchSupportedModelsFromKaito(modelsCacheDefault)
+		assert.NoError(t, err)
+		_, err = readModelsCacheValidators()
+		assert.Error(t, err, "no validators are stored")
+
+		expireCache(t)
+		loadSupportedModels(modelsCacheDefault)
+		assert.Equal(t, int32(2), atomic.LoadInt32(downloads))
+	})
+}
+
+func TestModelsHTTPClientUsesProxyFromEnvironment(t *testing.T) {
+	transport, ok := newModelsHTTPClient(time.Second).Transport.(*http.Transport)
+	assert.True(t, ok)
+	assert.NotNil(t, transport.Proxy)
+}
+
+func TestModelsHTTPClientInsecureSkipTLSVerify(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("models: []\n"))
+	}))
+	defer server.Close()
+
+	// The test server's certificate is self-signed
+	_, err := fetchModelsBody(newModelsHTTPClient(time.Second), server.URL, time.Second, modelsCacheValidators{})
+	assert.Error(t, err)
+
+	insecureSkipTLSVerify = true
+	defer func() { insecureSkipTLSVerify = false }()
+	resp, err := fetchModelsBody(newModelsHTTPClient(time.Second), server.URL, time.Second, modelsCacheValidators{})
+	assert.NoError(t, err)
+	assert.Equal(t, "models: []\n", string(resp.Body))
+}
+
+func TestModelsCAFile(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("models: []\n"))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	caFile := filepath.Join(dir, "ca.pem")
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	assert.NoError(t, os.WriteFile(caFile, certPEM, 0o600))
+
+	t.Run("Trusts the bundle", func(t *testing.T) {
+		pool, err := loadCertPool(caFile)
+		assert.NoError(t, err)
+		modelsRootCAs = pool
+		defer func() { modelsRootCAs = nil }()
+
+		resp, err := fetchModelsBody(newModelsHTTPClient(time.Second), server.URL, time.Second, modelsCacheValidators{})
+		assert.NoError(t, err)
+		assert.Equal(t, "models: []\n", string(resp.Body))
+
+		transport := newModelsHTTPClient(time.Second).Transport.(*http.Transport)
+		assert.NotNil(t, transport.Proxy, "the proxy settings are kept")
+	})
+
+	t.Run("Invalid bundles", func(t *testing.T) {
+		_, err := loadCertPool(filepath.Join(dir, "missing.pem"))
+		assert.ErrorContains(t, err, "failed to read --models-ca-file")
+
+		notPEM := filepath.Join(dir, "not.pem")
+		assert.NoError(t, os.WriteFile(notPEM, []byte("not a certificate"), 0o600))
+		_, err = loadCertPool(notPEM)
+		assert.ErrorContains(t, err, "contains no PEM certificates")
+	})
+
+	t.Run("Flag is loaded before the command runs", func(t *testing.T) {
+		defer func() { modelsCAFile, modelsRootCAs = "", nil }()
+		cmd := NewRootCmd(genericclioptions.NewConfigFlags(true), true)
+
+		assert.NoError(t, cmd.PersistentFlags().Set("models-ca-file", caFile))
+		assert.NoError(t, cmd.PersistentPreRunE(cmd, nil))
+		assert.NotNil(t, modelsRootCAs)
+
+		assert.NoError(t, cmd.PersistentFlags().Set("models-ca-file", filepath.Join(dir, "missing.pem")))
+		assert.ErrorContains(t, cmd.PersistentPreRunE(cmd, nil), "failed to read --models-ca-file")
+	})
+}
+
+func TestInsecureSkipTLSVerifyFlag(t *testing.T) {
+	configFlags := genericclioptions.NewConfigFlags(true)
+	cmd := NewRootCmd(configFlags, true)
+	defer func() { insecureSkipTLSVerify = false }()
+
+	assert.NoError(t, cmd.PersistentFlags().Set("insecure-skip-tls-verify", "true"))
+	assert.NoError(t, cmd.PersistentPreRunE(cmd, nil))
+	assert.True(t, *configFlags.Insecure, "the REST config must see the flag too")
+	assert.True(t, insecureSkipTLSVerify)
+}
+
+func TestModelsURLFlag(t *testing.T) {
+	// TestMain points modelsURL at a local server; the flag defaults to its value
+	origURL := modelsURL
+	modelsURL = SupportedModelsURL
+	defer func() { modelsURL = origURL }()
+	cmd := NewRootCmd(genericclioptions.NewConfigFlags(true), true)
+
+	flag := cmd.PersistentFlags().Lookup("models-url")
+	assert.NotNil(t, flag)
+	assert.Equal(t, SupportedModelsURL, flag.DefValue)
+
+	assert.NoError(t, validateHTTPURL("--models-url", "https://mirror.internal/kaito/supported_models.yaml"))
+	assert.ErrorContains(t, validateHTTPURL("--models-url", "mirror.internal/supported_models.yaml"), "invalid --models-url")
+}
+
+func TestModelsValidate(t *testing.T) {
+	models := []Model{{Name: "phi-4"}, {Name: "phi-3.5-mini-instruct"}}
+
+	assert.NoError(t, runModelsValidate([]string{"phi-4"}, models))
+	assert.NoError(t, runModelsValidate([]string{"phi-4", "phi-3.5-mini-instruct"}, models))
+
+	err := runModelsValidate([]string{"phi-4", "phi"}, models)
+	var exitErr *ExitError
+	assert.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 1, exitErr.Code)
+
+	assert.Error(t, runModelsValidate([]string{""}, models))
+
+	cmd := newModelsValidateCmd()
+	assert.Error(t, cmd.Args(cmd, nil), "at least one model name is required")
+}
+
+func TestModelsCompare(t *testing.T) {
+	models := []Model{
+		{Name: "phi-4", Type: "text-generation", Runtime: "tfs", Version: "v1", Tag: "0.1.0", MinNodes: 1, MaxNodes: 1, GPUMemory: "28Gi", InstanceType: "Standard_NC24ads_A100_v4"},
+		{Name: "phi-3.5-mini-instruct", Type: "text-generation", Runtime: "tfs", Tag: "0.2.0", MinNodes: 1, MaxNodes: 2},
+		{Name: "falcon-7b", Type: "text-generation", Runtime: "tfs"},
+	}
+
+	t.Run("Models are returned in argument order", func(t *testing.T) {
+		selected, err := lookupModels(models, []string{"phi-3.5-mini-instruct", "phi-4"})
+		assert.NoError(t, err)
+		assert.Equal(t, []Model{models[1], models[0]}, selected)
+	})
+
+	t.Run("Unknown model suggests similar names", func(t *testing.T) {
+		_, err := lookupModels(models, []string{"phi-4", "phi"})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "model 'phi' is not supported")
+		assert.Contains(t, err.Error(), "phi-3.5-mini-instruct")
+	})
+
+	t.Run("Table has a column per model", func(t *testing.T) {
+		var out bytes.Buffer
+		assert.NoError(t, printModelsComparison(&out, []Model{models[0], models[1]}))
+
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		assert.Len(t, lines, 9)
+		assert.Equal(t, []string{"MODEL", "phi-4", "phi-3.5-mini-instruct"}, strings.Fields(lines[0]))
+		assert.Equal(t, []string{"VERSION", "v1", "-"}, strings.Fields(lines[3]))
+		assert.Equal(t, []string{"MAX", "NODES", "1", "2"}, strings.Fields(lines[6]))
+		assert.Equal(t, []string{"INSTANCE", "TYPE", "Standard_NC24ads_A100_v4", "-"}, strings.Fields(lines[8]))
+	})
+
+	t.Run("Requires at least two models", func(t *testing.T) {
+		cmd := newModelsCompareCmd()
+		assert.Error(t, cmd.Args(cmd, []string{"phi-4"}))
+		assert.NoError(t, cmd.Args(cmd, []string{"phi-4", "falcon-7b"}))
+	})
+}
+
+func TestModelParameterSize(t *testing.T) {
+	tests := map[string]string{
+		"llama-3.1-8b-instruct":      "8B",
+		"qwen2.5-coder-32b-instruct": "32B",
+		"falcon-40b":                 "40B",
+		"qwen2.5-0.5b-instruct":      "0.5B",
+		"mixtral-8x7b-instruct":      "8x7B",
+		"phi-3-mini-4k-instruct":     "",
+		"phi-4":                      "",
+	}
+	for name, expected := range tests {
+		assert.Equal(t, expected, modelParameterSize(name), name)
+	}
+}
+
+func TestPrintModelsTableSizingColumns(t *testing.T) {
+	t.Run("Columns are shown when any model has data", func(t *testing.T) {
+		var out bytes.Buffer
+		assert.NoError(t, printModelsTable(&out, []Model{
+			{Name: "falcon-7b", Type: "text-generation", Runtime: "tfs", Tag: "0.1.0", GPUMemory: "14Gi"},
+			{Name: "phi-4", Type: "text-generation", Runtime: "tfs", Tag: "0.1.0"},
+		}, false))
+
+		lines := strings.Split(out.String(), "\n")
+		assert.Equal(t, []string{"NAME", "TYPE", "FAMILY", "RUNTIME", "TAG", "PARAMS", "GPU", "MEMORY"}, strings.Fields(lines[0]))
+		assert.Equal(t, []string{"falcon-7b", "text-generation", "Falcon", "tfs", "0.1.0", "7B", "14Gi"}, strings.Fields(lines[1]))
+		assert.Equal(t, []string{"phi-4", "text-generation", "Phi", "tfs", "0.1.0", "-", "-"}, strings.Fields(lines[2]))
+	})
+
+	t.Run("Columns are hidden without data", func(t *testing.T) {
+		var out bytes.Buffer
+		assert.NoError(t, printModelsTable(&out, []Model{{Name: "phi-4", Type: "text-generation", Runtime: "tfs"}}, false))
+		assert.NotContains(t, out.String(), "GPU MEMORY")
+		assert.NotContains(t, out.String(), "PARAMS")
+	})
+
+	t.Run("No headers", func(t *testing.T) {
+		var out bytes.Buffer
+		assert.NoError(t, printModelsTable(&out, []Model{
+			{Name: "phi-4", Type: "text-generation", Runtime: "tfs", Tag: "0.1.0"},
+			{Name: "base"},
+		}, true))
+		assert.Equal(t, []string{"phi-4  text-generation  Phi  tfs  0.1.0"}, strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n"))
+	})
+
+	t.Run("Detailed output includes sizing fields", func(t *testing.T) {
+		var out bytes.Buffer
+		assert.NoError(t, printModelsDetailed(&out, []Model{{
+			Name: "llama-3.1-8b-instruct", GPUMemory: "16Gi", MinNodes: 1, MaxNodes: 2, InstanceType: "Standard_NC24ads_A100_v4",
+		}}))
+		assert.Contains(t, out.String(), "Parameters: 8B\n")
+		assert.Contains(t, out.String(), "GPU Memory: 16Gi\n")
+		assert.Contains(t, out.String(), "Nodes: min 1, max 2\n")
+		assert.Contains(t, out.String(), "Instance Type: Standard_NC24ads_A100_v4\n")
+	})
+}
+
+func TestGroupModelsByFamily(t *testing.T) {
+	models := []Model{
+		{Name: "phi-4", Tag: "0.1.0"},
+		{Name: "llama-3.1-8b-instruct", Tag: "0.2.0"},
+		{Name: "phi-3.5-mini-instruct", Tag: "0.10.0"},
+		{Name: "deepseek-r1-distill-qwen-14b", Tag: "0.2.0"},
+		{Name: "phi-2", Tag: "0.1.0"},
+	}
+
+	groups := groupModelsByFamily(models)
+	families := make([]string, len(groups))
+	for i, group := range groups {
+		families[i] = group.Family
+	}
+	assert.Equal(t, []string{"DeepSeek", "Llama", "Phi"}, families)
+
+	var phi []string
+	for _, model := range groups[2].Models {
+		phi = append(phi, model.Name)
+	}
+	assert.Equal(t, []string{"phi-3.5-mini-instruct", "phi-2", "phi-4"}, phi, "newest tag first, then by name")
+
+	var out bytes.Buffer
+	assert.NoError(t, printModelsDetailedByFamily(&out, groups))
+	assert.Contains(t, out.String(), "Phi (3 models)\n==============\nName: phi-3.5-mini-instruct")
+	assert.Contains(t, out.String(), "Llama (1 model)\n")
+
+	t.Run("Tag comparison", func(t *testing.T) {
+		assert.Positive(t, compareModelTags("0.10.0", "0.2.0"))
+		assert.Negative(t, compareModelTags("0.2", "0.2.1"))
+		assert.Zero(t, compareModelTags("1.0.0", "1.0.0"))
+		assert.Positive(t, compareModelTags("0.2.0-rc2", "0.2.0-rc1"))
+	})
+
+	t.Run("Invalid --group-by", func(t *testing.T) {
+		assert.NoError(t, (&ModelsListOptions{GroupBy: "family"}).validate())
+		assert.ErrorContains(t, (&ModelsListOptions{GroupBy: "runtime"}).validate(), `must be "family"`)
+	})
+}
@@ -0,0 +1,271 @@
+/*
+Copyright (c) 2024 Kaito Project
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+	"k8s.io/klog/v2"
+)
+
+// PortForwardOptions holds the options for the port-forward command
+type PortForwardOptions struct {
+	configFlags *genericclioptions.ConfigFlags
+	clients     *clientFactory
+
+	WorkspaceName string
+	Namespace     string
+	LocalPort     int
+	Port          int
+}
+
+// NewPortForwardCmd creates the port-forward command
+func NewPortForwardCmd(configFlags *genericclioptions.ConfigFlags) *cobra.Command {
+	o := &PortForwardOptions{
+		configFlags: configFlags,
+		clients:     newClientFactory(configFlags),
+	}
+
+	cmd := &cobra.Command{
+		Use:   "port-forward",
+		Short: "Forward a local port to the inference endpoint of a workspace",
+		Long: `Port-forward makes the inference service of a Kaito workspace reachable on
+localhost, for clusters whose service and LoadBalancer endpoints cannot be
+reached directly.
+
+The workspace service is found the same way as 'get-endpoint' and the forward
+goes to a ready pod behind it. The command prints the local chat completions URL
+and keeps forwarding until interrupted with Ctrl+C.`,
+		Example: `  # Forward a random free local port to the workspace service
+  kubectl kaito port-forward --workspace-name my-workspace
+
+  # Forward localhost:8080 and chat through it from another terminal
+  kubectl kaito port-forward --workspace-name my-workspace --local-port 8080
+  kubectl kaito chat --workspace-name my-workspace --endpoint http://localhost:8080`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.validate(); err != nil {
+				klog.Errorf("Validation failed: %v", err)
+				return fmt.Errorf("validation failed: %w", err)
+			}
+			ctx, stop := withInterrupt(cmd.Context())
+			defer stop()
+			return interruptedError(ctx, o.run(ctx))
+		},
+	}
+
+	cmd.Flags().StringVar(&o.WorkspaceName, "workspace-name", "", "Name of the workspace (required)")
+	cmd.Flags().StringVarP(&o.Namespace, "namespace", "n", "", "Kubernetes namespace")
+	cmd.Flags().IntVar(&o.LocalPort, "local-port", 0, "Local port to listen on (a random free port by default)")
+	cmd.Flags().IntVar(&o.Port, "port", 0, "Service port of the inference endpoint (detected from the service ports by default)")
+
+	if err := cmd.MarkFlagRequired("workspace-name"); err != nil {
+		klog.Errorf("Failed to mark workspace-name flag as required: %v", err)
+	}
+
+	return cmd
+}
+
+func (o *PortForwardOptions) validate() error {
+	klog.V(4).Info("Validating port-forward options")
+
+	if o.WorkspaceName == "" {
+		return fmt.Errorf("workspace name is required")
+	}
+	if o.LocalPort < 0 || o.LocalPort > 65535 {
+		return fmt.Errorf("local-port must be between 1 and 65535")
+	}
+	if err := validatePort(o.Port); err != nil {
+		return err
+	}
+
+	klog.V(4).Info("Port-forward validation completed successfully")
+	return nil
+}
+
+func (o *PortForwardOptions) run(ctx context.Context) error {
+	klog.V(2).Infof("Port-forwarding to workspace: %s", o.WorkspaceName)
+
+	// Get namespace
+	o.Namespace = resolveNamespace(o.configFlags, o.Namespace)
+
+	clientset, err := o.clients.KubernetesClient()
+	if err != nil {
+		return err
+	}
+
+	svc, err := clientset.CoreV1().Services(o.Namespace).Get(ctx, o.WorkspaceName, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return serviceNotFoundError(ctx, o.clients, o.Namespace, o.WorkspaceName)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get service for workspace %s: %w", o.WorkspaceName, err)
+	}
+
+	pod, podPort, err := servicePodTarget(ctx, clientset, svc, servicePort(svc, o.Port))
+	if err != nil {
+		return err
+	}
+
+	return o.forward(ctx, clientset, pod, podPort, serviceScheme(svc, ""))
+}
+
+// servicePodTarget picks a ready pod behind svc and resolves the service port to
+// the pod port it targets, the way 'kubectl port-forward svc/...' does
+func servicePodTarget(ctx context.Context, clientset kubernetes.Interface, svc *corev1.Service, port int32) (*corev1.Pod, int32, error) {
+	var svcPort *corev1.ServicePort
+	for i := range svc.Spec.Ports {
+		if svc.Spec.Ports[i].Port == port {
+			svcPort = &svc.Spec.Ports[i]
+			break
+		}
+	}
+	if svcPort == nil {
+		return nil, 0, fmt.Errorf("service %s has no port %d", svc.Name, port)
+	}
+	if len(svc.Spec.Selector) == 0 {
+		return nil, 0, fmt.Errorf("service %s has no pod selector to forward to", svc.Name)
+	}
+
+	pods, err := clientset.CoreV1().Pods(svc.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(svc.Spec.Selector).String(),
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list pods for service %s: %w", svc.Name, err)
+	}
+
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Status.Phase != corev1.PodRunning || !isPodReady(pod) {
+			continue
+		}
+		podPort, err := resolveTargetPort(pod, *svcPort)
+		if err != nil {
+			return nil, 0, err
+		}
+		klog.V(3).Infof("Forwarding to pod %s port %d", pod.Name, podPort)
+		return pod, podPort, nil
+	}
+
+	return nil, 0, fmt.Errorf("no ready pods found for service %s; use 'kubectl kaito status --workspace-name %s -n %s' to check the workspace",
+		svc.Name, svc.Name, svc.Namespace)
+}
+
+// resolveTargetPort returns the pod port a service port targets, looking up named
+// target ports in the pod's container ports
+func resolveTargetPort(pod *corev1.Pod, svcPort corev1.ServicePort) (int32, error) {
+	switch {
+	case svcPort.TargetPort.Type == intstr.String && svcPort.TargetPort.StrVal != "":
+		for _, container := range pod.Spec.Containers {
+			for _, containerPort := range container.Ports {
+				if containerPort.Name == svcPort.TargetPort.StrVal {
+					return containerPort.ContainerPort, nil
+				}
+			}
+		}
+		return 0, fmt.Errorf("pod %s has no container port named %q", pod.Name, svcPort.TargetPort.StrVal)
+	case svcPort.TargetPort.IntVal > 0:
+		return svcPort.TargetPort.IntVal, nil
+	default:
+		// An unset target port defaults to the service port
+		return svcPort.Port, nil
+	}
+}
+
+// isPodReady reports whether the pod's Ready condition is True
+func isPodReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// forward runs the port-forward to the pod until ctx is cancelled by Ctrl+C, then closes it
+func (o *PortForwardOptions) forward(ctx context.Context, clientset kubernetes.Interface, pod *corev1.Pod, podPort int32, scheme string) error {
+	config, err := o.clients.RESTConfig()
+	if err != nil {
+		return err
+	}
+
+	transport, upgrader, err := spdy.RoundTripperFor(config)
+	if err != nil {
+		return fmt.Errorf("failed to create port-forward transport: %w", err)
+	}
+	url := clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(pod.Namespace).
+		Name(pod.Name).
+		SubResource("portforward").
+		URL()
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, url)
+
+	stopCh := make(chan struct{})
+	readyCh := make(chan struct{})
+	stopForwarding := context.AfterFunc(ctx, func() { close(stopCh) })
+	defer stopForwarding()
+
+	ports := []string{fmt.Sprintf("%d:%d", o.LocalPort, podPort)}
+	forwarder, err := portforward.NewOnAddresses(dialer, []string{"localhost"}, ports, stopCh, readyCh, io.Discard, os.Stderr)
+	if err != nil {
+		return fmt.Errorf("failed to set up port-forward: %w", err)
+	}
+	defer forwarder.Close()
+
+	go func() {
+		<-readyCh
+		forwarded, err := forwarder.GetPorts()
+		if err != nil || len(forwarded) == 0 {
+			klog.Errorf("Failed to get forwarded ports: %v", err)
+			return
+		}
+		printForwardInfo(os.Stdout, o.WorkspaceName, pod.Name, scheme, forwarded[0])
+	}()
+
+	if err := forwarder.ForwardPorts(); err != nil && ctx.Err() == nil {
+		return fmt.Errorf("port-forward to pod %s failed: %w", pod.Name, err)
+	}
+
+	fmt.Println()
+	printStatus(os.Stdout, "✓ Port-forward stopped\n")
+	return nil
+}
+
+// printForwardInfo prints where the forwarded inference endpoint can be reached
+func printForwardInfo(out io.Writer, workspaceName, podName, scheme string, port portforward.ForwardedPort) {
+	baseURL := fmt.Sprintf("%s://localhost:%d", scheme, port.Local)
+	printStatus(out, "✓ Forwarding localhost:%d -> pod/%s:%d\n", port.Local, podName, port.Remote)
+	fmt.Fprintln(out)
+	fmt.Fprintf(out, "  %s/v1/chat/completions\n", baseURL)
+	fmt.Fprintln(out)
+	printStatus(out, "💡 Use it with: kubectl kaito chat --workspace-name %s --endpoint %s\n", workspaceName, baseURL)
+	printStatus(out, "ℹ️  Press Ctrl+C to stop forwarding\n")
+}
@@ -0,0 +1,117 @@
+/*
+Copyright (c) 2024 Kaito Project
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/portforward"
+)
+
+func TestPortForwardCmd(t *testing.T) {
+	cmd := NewPortForwardCmd(genericclioptions.NewConfigFlags(true))
+
+	assert.Equal(t, "port-forward", cmd.Use)
+	assert.NotNil(t, cmd.Flags().Lookup("local-port"))
+	assert.NotNil(t, cmd.Flags().Lookup("port"))
+
+	t.Run("Validation", func(t *testing.T) {
+		assert.NoError(t, (&PortForwardOptions{WorkspaceName: "ws"}).validate())
+		assert.Error(t, (&PortForwardOptions{}).validate())
+		assert.ErrorContains(t, (&PortForwardOptions{WorkspaceName: "ws", LocalPort: 70000}).validate(), "local-port")
+		assert.Error(t, (&PortForwardOptions{WorkspaceName: "ws", Port: -1}).validate())
+	})
+}
+
+func TestServicePodTarget(t *testing.T) {
+	newPod := func(name string, ready bool) *corev1.Pod {
+		status := corev1.ConditionFalse
+		if ready {
+			status = corev1.ConditionTrue
+		}
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: map[string]string{"app": "my-ws"}},
+			Spec: corev1.PodSpec{Containers: []corev1.Container{{
+				Name:  "model",
+				Ports: []corev1.ContainerPort{{Name: "http", ContainerPort: 5000}},
+			}}},
+			Status: corev1.PodStatus{
+				Phase:      corev1.PodRunning,
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: status}},
+			},
+		}
+	}
+	newService := func(targetPort intstr.IntOrString) *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-ws", Namespace: "default"},
+			Spec: corev1.ServiceSpec{
+				Selector: map[string]string{"app": "my-ws"},
+				Ports:    []corev1.ServicePort{{Name: "http", Port: 80, TargetPort: targetPort}},
+			},
+		}
+	}
+
+	t.Run("Ready pod and numeric target port", func(t *testing.T) {
+		clientset := fake.NewSimpleClientset(newPod("my-ws-0", false), newPod("my-ws-1", true))
+		pod, port, err := servicePodTarget(context.TODO(), clientset, newService(intstr.FromInt32(5000)), 80)
+		assert.NoError(t, err)
+		assert.Equal(t, "my-ws-1", pod.Name)
+		assert.Equal(t, int32(5000), port)
+	})
+
+	t.Run("Named target port is resolved from the pod", func(t *testing.T) {
+		clientset := fake.NewSimpleClientset(newPod("my-ws-0", true))
+		_, port, err := servicePodTarget(context.TODO(), clientset, newService(intstr.FromString("http")), 80)
+		assert.NoError(t, err)
+		assert.Equal(t, int32(5000), port)
+	})
+
+	t.Run("Unset target port uses the service port", func(t *testing.T) {
+		clientset := fake.NewSimpleClientset(newPod("my-ws-0", true))
+		_, port, err := servicePodTarget(context.TODO(), clientset, newService(intstr.IntOrString{}), 80)
+		assert.NoError(t, err)
+		assert.Equal(t, int32(80), port)
+	})
+
+	t.Run("No ready pods", func(t *testing.T) {
+		clientset := fake.NewSimpleClientset(newPod("my-ws-0", false))
+		_, _, err := servicePodTarget(context.TODO(), clientset, newService(intstr.FromInt32(5000)), 80)
+		assert.ErrorContains(t, err, "no ready pods found for service my-ws")
+	})
+
+	t.Run("Unknown service port", func(t *testing.T) {
+		_, _, err := servicePodTarget(context.TODO(), fake.NewSimpleClientset(), newService(intstr.FromInt32(5000)), 8080)
+		assert.ErrorContains(t, err, "service my-ws has no port 8080")
+	})
+}
+
+func TestPrintForwardInfo(t *testing.T) {
+	var out bytes.Buffer
+	printForwardInfo(&out, "my-ws", "my-ws-0", "http", portforward.ForwardedPort{Local: 8080, Remote: 5000})
+
+	assert.Contains(t, out.String(), "localhost:8080 -> pod/my-ws-0:5000")
+	assert.Contains(t, out.String(), "http://localhost:8080/v1/chat/completions")
+	assert.Contains(t, out.String(), "--workspace-name my-ws --endpoint http://localhost:8080")
+}
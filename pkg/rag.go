@@ -0,0 +1,1036 @@
+/*
+Copyright (c) 2024 Kaito Project
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+)
+
+// defaultRAGEmbeddingModel is the local embedding model used when none is specified
+const defaultRAGEmbeddingModel = "BAAI/bge-small-en-v1.5"
+
+// supportedVectorDBs lists the vector stores the RAGEngine service can index into.
+// The RAGEngine spec has no field to select one; the service always uses faiss.
+var supportedVectorDBs = []string{"faiss"}
+
+// RagDeployOptions holds the options for the rag deploy command
+type RagDeployOptions struct {
+	configFlags     *genericclioptions.ConfigFlags
+	clients         *clientFactory
+	LabelSelector   map[string]string
+	WorkspaceName   string
+	Namespace       string
+	VectorDB        string
+	EmbeddingModel  string
+	EmbeddingURL    string
+	InferenceURL    string
+	InferenceSecret string
+	InstanceType    string
+	Timeout         time.Duration
+	DryRun          bool
+	Wait            bool
+}
+
+// RagStatusOptions holds the options for the rag status command
+type RagStatusOptions struct {
+	configFlags   *genericclioptions.ConfigFlags
+	clients       *clientFactory
+	WorkspaceName string
+	Namespace     string
+}
+
+// RagQueryOptions holds the options for the rag query command
+type RagQueryOptions struct {
+	configFlags   *genericclioptions.ConfigFlags
+	clients       *clientFactory
+	WorkspaceName string
+	Namespace     string
+	Query         string
+	IndexName     string
+	TopK          int
+	ShowSources   bool
+}
+
+// RagIndexOptions holds the options for the rag index command
+type RagIndexOptions struct {
+	configFlags   *genericclioptions.ConfigFlags
+	clients       *clientFactory
+	Files         []string
+	URLs          []string
+	WorkspaceName string
+	Namespace     string
+	IndexName     string
+	ChunkSize     int
+	Timeout       time.Duration
+}
+
+// defaultRAGChunkSize is the maximum number of bytes of document text sent per index request
+const defaultRAGChunkSize = 512 * 1024
+
+// ragSource is a document chunk retrieved to answer a RAG query
+type ragSource struct {
+	DocID string
+	Text  string
+	Score float64
+}
+
+// NewRagCmd creates the rag command with subcommands
+func NewRagCmd(configFlags *genericclioptions.ConfigFlags) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rag",
+		Short: "Manage retrieval-augmented generation (RAG) engines",
+		Long: `Deploy and manage Kaito RAGEngine resources.
+
+A RAGEngine indexes your documents into a vector database using an embedding
+model and answers queries by combining retrieved context with a deployed
+inference workspace.`,
+		Example: `  # Deploy a RAG engine backed by an existing inference workspace
+  kubectl kaito rag deploy --workspace-name my-rag --inference-url http://my-llama.default.svc.cluster.local/v1/completions
+
+  # Check the status of a RAG engine
+  kubectl kaito rag status --workspace-name my-rag
+
+  # Index local documents
+  kubectl kaito rag index --workspace-name my-rag --files docs/guide.md,docs/faq.md
+
+  # Ask a question answered from the indexed documents
+  kubectl kaito rag query --workspace-name my-rag --query "How do I rotate credentials?" --show-sources`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(newRagDeployCmd(configFlags))
+	cmd.AddCommand(newRagStatusCmd(configFlags))
+	cmd.AddCommand(newRagQueryCmd(configFlags))
+	cmd.AddCommand(newRagIndexCmd(configFlags))
+
+	return cmd
+}
+
+func newRagDeployCmd(configFlags *genericclioptions.ConfigFlags) *cobra.Command {
+	o := &RagDeployOptions{
+		configFlags: configFlags,
+		clients:     newClientFactory(configFlags),
+	}
+
+	cmd := &cobra.Command{
+		Use:   "deploy",
+		Short: "Deploy a Kaito RAGEngine",
+		Long: `Deploy creates a Kaito RAGEngine resource.
+
+The RAGEngine runs an embedding model (locally on a GPU node, or via a remote
+embedding endpoint), stores document embeddings in its faiss index, and
+forwards augmented prompts to an inference service.`,
+		Example: `  # Deploy a RAG engine with the default local embedding model
+  kubectl kaito rag deploy --workspace-name my-rag --inference-url http://my-llama.default.svc.cluster.local/v1/completions
+
+  # Use a specific embedding model and instance type
+  kubectl kaito rag deploy --workspace-name my-rag --embedding-model BAAI/bge-base-en-v1.5 --instance-type Standard_NC6s_v3 --inference-url http://my-llama/v1/completions
+
+  # Deploy and wait until the embedding model and index service are ready
+  kubectl kaito rag deploy --workspace-name my-rag --inference-url http://my-llama/v1/completions --wait
+
+  # Show what would be created
+  kubectl kaito rag deploy --workspace-name my-rag --inference-url http://my-llama/v1/completions --dry-run`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Validate(); err != nil {
+				klog.Errorf("Validation failed: %v", err)
+				return err
+			}
+			ctx, stop := withInterrupt(cmd.Context())
+			defer stop()
+			return interruptedError(ctx, o.Run(ctx))
+		},
+	}
+
+	cmd.Flags().StringVar(&o.WorkspaceName, "workspace-name", "", "Name of the RAGEngine to create (required)")
+	cmd.Flags().StringVarP(&o.Namespace, "namespace", "n", "", "Kubernetes namespace")
+	cmd.Flags().StringVar(&o.VectorDB, "vector-db", "faiss", fmt.Sprintf("Vector database for document embeddings (%s); informational only, the RAGEngine always uses faiss", strings.Join(supportedVectorDBs, ", ")))
+	cmd.Flags().StringVar(&o.EmbeddingModel, "embedding-model", defaultRAGEmbeddingModel, "Embedding model ID to run locally")
+	cmd.Flags().StringVar(&o.EmbeddingURL, "embedding-url", "", "URL of a remote embedding service (instead of a local embedding model)")
+	cmd.Flags().StringVar(&o.InferenceURL, "inference-url", "", "URL of the inference service used to answer queries (required)")
+	cmd.Flags().StringVar(&o.InferenceSecret, "inference-secret", "", "Secret holding the access token for the inference service")
+	cmd.Flags().StringVar(&o.InstanceType, "instance-type", "", "GPU instance type for the embedding model (e.g., Standard_NC4as_T4_v3)")
+	cmd.Flags().StringToStringVar(&o.LabelSelector, "node-selector", nil, "Node selector labels")
+	cmd.Flags().BoolVar(&o.DryRun, "dry-run", false, "Show what would be created without actually creating")
+	cmd.Flags().BoolVar(&o.Wait, "wait", false, "Wait for the RAGEngine to become ready after creating it")
+	cmd.Flags().DurationVar(&o.Timeout, "timeout", 15*time.Minute, "Maximum time to wait for the RAGEngine to become ready (used with --wait)")
+
+	if err := cmd.MarkFlagRequired("workspace-name"); err != nil {
+		klog.Errorf("Failed to mark workspace-name flag as required: %v", err)
+	}
+
+	return cmd
+}
+
+// Validate validates the rag deploy options
+func (o *RagDeployOptions) Validate() error {
+	klog.V(4).Info("Validating rag deploy options")
+
+	if o.WorkspaceName == "" {
+		return fmt.Errorf("workspace name is required")
+	}
+
+	if !isSupportedVectorDB(o.VectorDB) {
+		return fmt.Errorf("unsupported vector database '%s'; supported: %s", o.VectorDB, strings.Join(supportedVectorDBs, ", "))
+	}
+
+	if o.InferenceURL == "" {
+		return fmt.Errorf("--inference-url is required")
+	}
+	if !strings.HasPrefix(o.InferenceURL, "http://") && !strings.HasPrefix(o.InferenceURL, "https://") {
+		return fmt.Errorf("--inference-url must start with http:// or https://")
+	}
+
+	if o.EmbeddingURL != "" {
+		if o.EmbeddingModel != "" && o.EmbeddingModel != defaultRAGEmbeddingModel {
+			return fmt.Errorf("cannot use --embedding-model together with --embedding-url")
+		}
+		if o.InstanceType != "" {
+			return fmt.Errorf("--instance-type is only used with a local embedding model, not with --embedding-url")
+		}
+	} else if o.EmbeddingModel == "" {
+		return fmt.Errorf("either --embedding-model or --embedding-url is required")
+	}
+
+	if o.Wait && o.Timeout <= 0 {
+		return fmt.Errorf("--timeout must be greater than 0 when --wait is set")
+	}
+
+	klog.V(4).Info("RAG deploy options validation completed successfully")
+	return nil
+}
+
+// Run executes the rag deploy command
+func (o *RagDeployOptions) Run(ctx context.Context) error {
+	klog.V(2).Infof("Starting rag deploy command for RAGEngine: %s", o.WorkspaceName)
+
+	// Get namespace from config flags if not set
+	o.Namespace = resolveNamespace(o.configFlags, o.Namespace)
+
+	ragEngine := o.buildRAGEngine()
+
+	if o.DryRun {
+		return o.showDryRun(ragEngine)
+	}
+
+	dynamicClient, err := o.clients.DynamicClient()
+	if err != nil {
+		return err
+	}
+
+	if err := o.createRAGEngine(ctx, dynamicClient, ragEngine); err != nil {
+		return err
+	}
+
+	if o.Wait {
+		return o.waitForRAGEngineReady(ctx, dynamicClient)
+	}
+
+	printStatus(os.Stdout, "ℹ️  Use 'kubectl kaito rag status --workspace-name %s' to check status\n", o.WorkspaceName)
+	return nil
+}
+
+// waitForRAGEngineReady watches the RAGEngine until both the embedding model and
+// the index service are ready, or the timeout elapses
+func (o *RagDeployOptions) waitForRAGEngineReady(ctx context.Context, dynamicClient dynamic.Interface) error {
+	return waitForResourceReady(ctx, dynamicClient, readinessTarget{
+		GVR: schema.GroupVersionResource{
+			Group:    "kaito.sh",
+			Version:  "v1alpha1",
+			Resource: "ragengines",
+		},
+		Namespace:     o.Namespace,
+		Name:          o.WorkspaceName,
+		Kind:          "RAGEngine",
+		StatusCommand: fmt.Sprintf("kubectl kaito rag status --workspace-name %s", o.WorkspaceName),
+		Timeout:       o.Timeout,
+		Phase:         ragReadinessPhase,
+	})
+}
+
+// createRAGEngine submits the RAGEngine to the cluster
+func (o *RagDeployOptions) createRAGEngine(ctx context.Context, dynamicClient dynamic.Interface, ragEngine *unstructured.Unstructured) error {
+	klog.V(2).Infof("Creating RAGEngine %s in namespace %s", o.WorkspaceName, o.Namespace)
+
+	gvr := schema.GroupVersionResource{
+		Group:    "kaito.sh",
+		Version:  "v1alpha1",
+		Resource: "ragengines",
+	}
+
+	_, err := dynamicClient.Resource(gvr).Namespace(o.Namespace).Create(
+		ctx,
+		ragEngine,
+		metav1.CreateOptions{},
+	)
+	if err != nil {
+		if !errors.IsAlreadyExists(err) {
+			klog.Errorf("Failed to create RAGEngine: %v", err)
+			return fmt.Errorf("failed to create RAGEngine: %w", err)
+		}
+		printStatus(os.Stdout, "✓ RAGEngine %s already exists\n", o.WorkspaceName)
+		return nil
+	}
+
+	printStatus(os.Stdout, "✓ RAGEngine %s created successfully\n", o.WorkspaceName)
+	return nil
+}
+
+// buildRAGEngine creates a RAGEngine object with the specified configuration
+func (o *RagDeployOptions) buildRAGEngine() *unstructured.Unstructured {
+	klog.V(4).Info("Building RAGEngine configuration")
+
+	ragEngine := &unstructured.Unstructured{
+		Object: make(map[string]interface{}),
+	}
+	ragEngine.SetAPIVersion("kaito.sh/v1alpha1")
+	ragEngine.SetKind("RAGEngine")
+	ragEngine.SetName(o.WorkspaceName)
+	ragEngine.SetNamespace(o.Namespace)
+
+	spec := map[string]interface{}{
+		"embedding":        o.buildEmbeddingSpec(),
+		"inferenceService": o.buildInferenceServiceSpec(),
+	}
+
+	// Compute is only needed when the embedding model runs in the cluster
+	if o.EmbeddingURL == "" {
+		spec["compute"] = o.buildComputeSpec()
+	}
+
+	if err := unstructured.SetNestedField(ragEngine.Object, spec, "spec"); err != nil {
+		klog.Errorf("Failed to set spec field: %v", err)
+	}
+
+	return ragEngine
+}
+
+func (o *RagDeployOptions) buildComputeSpec() map[string]interface{} {
+	matchLabels := map[string]interface{}{
+		"kaito.sh/ragengine": o.WorkspaceName,
+	}
+	if len(o.LabelSelector) > 0 {
+		matchLabels = make(map[string]interface{}, len(o.LabelSelector))
+		for key, value := range o.LabelSelector {
+			matchLabels[key] = value
+		}
+	}
+
+	compute := map[string]interface{}{
+		"labelSelector": map[string]interface{}{
+			"matchLabels": matchLabels,
+		},
+	}
+	if o.InstanceType != "" {
+		compute["instanceType"] = o.InstanceType
+	}
+	return compute
+}
+
+func (o *RagDeployOptions) buildEmbeddingSpec() map[string]interface{} {
+	if o.EmbeddingURL != "" {
+		return map[string]interface{}{
+			"remote": map[string]interface{}{
+				"url": o.EmbeddingURL,
+			},
+		}
+	}
+	return map[string]interface{}{
+		"local": map[string]interface{}{
+			"modelID": o.EmbeddingModel,
+		},
+	}
+}
+
+func (o *RagDeployOptions) buildInferenceServiceSpec() map[string]interface{} {
+	inferenceService := map[string]interface{}{
+		"url": o.InferenceURL,
+	}
+	if o.InferenceSecret != "" {
+		inferenceService["accessSecret"] = o.InferenceSecret
+	}
+	return inferenceService
+}
+
+func (o *RagDeployOptions) showDryRun(ragEngine *unstructured.Unstructured) error {
+	klog.V(2).Info("Running in dry-run mode")
+
+	printStatus(os.Stdout, "🔍 Dry-run mode: Showing what would be created\n")
+	fmt.Println()
+	fmt.Println("RAGEngine Configuration:")
+	fmt.Println("========================")
+	fmt.Printf("Name: %s\n", o.WorkspaceName)
+	fmt.Printf("Namespace: %s\n", o.Namespace)
+	if o.EmbeddingURL != "" {
+		fmt.Printf("Embedding: remote (%s)\n", o.EmbeddingURL)
+	} else {
+		fmt.Printf("Embedding: local (%s)\n", o.EmbeddingModel)
+		if o.InstanceType != "" {
+			fmt.Printf("Instance Type: %s\n", o.InstanceType)
+		}
+	}
+	fmt.Printf("Inference URL: %s\n", o.InferenceURL)
+	if o.InferenceSecret != "" {
+		fmt.Printf("Inference Secret: %s\n", o.InferenceSecret)
+	}
+
+	fmt.Println()
+	printStatus(os.Stdout, "✓ RAGEngine definition is valid\n")
+
+	yamlData, err := yaml.Marshal(ragEngine.Object)
+	if err != nil {
+		klog.Errorf("Failed to marshal RAGEngine to YAML: %v", err)
+	} else {
+		fmt.Println()
+		fmt.Println("RAGEngine YAML:")
+		fmt.Println("===============")
+		fmt.Print(string(yamlData))
+	}
+
+	fmt.Println()
+	printStatus(os.Stdout, "ℹ️  Run without --dry-run to create the RAGEngine\n")
+	return nil
+}
+
+func newRagStatusCmd(configFlags *genericclioptions.ConfigFlags) *cobra.Command {
+	o := &RagStatusOptions{
+		configFlags: configFlags,
+		clients:     newClientFactory(configFlags),
+	}
+
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Check status of a Kaito RAGEngine",
+		Long: `Check the status of a Kaito RAGEngine.
+
+This command displays the embedding model, the readiness of the compute
+resources and the index service, and the endpoint used to query the engine.`,
+		Example: `  # Check status of a RAG engine
+  kubectl kaito rag status --workspace-name my-rag -n <namespace>`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if o.WorkspaceName == "" {
+				return fmt.Errorf("workspace name is required")
+			}
+			ctx, stop := withInterrupt(cmd.Context())
+			defer stop()
+			return interruptedError(ctx, o.Run(ctx))
+		},
+	}
+
+	cmd.Flags().StringVar(&o.WorkspaceName, "workspace-name", "", "Name of the RAGEngine (required)")
+	cmd.Flags().StringVarP(&o.Namespace, "namespace", "n", "", "Kubernetes namespace")
+
+	if err := cmd.MarkFlagRequired("workspace-name"); err != nil {
+		klog.Errorf("Failed to mark workspace-name flag as required: %v", err)
+	}
+
+	return cmd
+}
+
+// Run executes the rag status command
+func (o *RagStatusOptions) Run(ctx context.Context) error {
+	klog.V(2).Infof("Getting status for RAGEngine: %s", o.WorkspaceName)
+
+	// Get namespace
+	o.Namespace = resolveNamespace(o.configFlags, o.Namespace)
+
+	config, err := o.clients.RESTConfig()
+	if err != nil {
+		return err
+	}
+
+	dynamicClient, err := o.clients.DynamicClient()
+	if err != nil {
+		return err
+	}
+
+	clientset, err := o.clients.KubernetesClient()
+	if err != nil {
+		return err
+	}
+
+	return o.showRAGEngineStatus(ctx, dynamicClient, clientset, config.Host)
+}
+
+func (o *RagStatusOptions) showRAGEngineStatus(ctx context.Context, dynamicClient dynamic.Interface, clientset kubernetes.Interface, apiServerHost string) error {
+	gvr := schema.GroupVersionResource{
+		Group:    "kaito.sh",
+		Version:  "v1alpha1",
+		Resource: "ragengines",
+	}
+
+	ragEngine, err := dynamicClient.Resource(gvr).Namespace(o.Namespace).Get(
+		ctx,
+		o.WorkspaceName,
+		metav1.GetOptions{},
+	)
+	if err != nil {
+		klog.Errorf("Failed to get RAGEngine %s: %v", o.WorkspaceName, err)
+		return fmt.Errorf("failed to get RAGEngine %s: %w", o.WorkspaceName, err)
+	}
+
+	fmt.Println("RAGEngine Details")
+	fmt.Println("=================")
+	fmt.Printf("Name: %s\n", ragEngine.GetName())
+	fmt.Printf("Namespace: %s\n", ragEngine.GetNamespace())
+	fmt.Printf("Embedding: %s\n", describeRAGEmbedding(ragEngine))
+	if instanceType, found, _ := unstructured.NestedString(ragEngine.Object, "spec", "compute", "instanceType"); found {
+		fmt.Printf("Instance Type: %s\n", instanceType)
+	}
+	if inferenceURL, found, _ := unstructured.NestedString(ragEngine.Object, "spec", "inferenceService", "url"); found {
+		fmt.Printf("Inference URL: %s\n", inferenceURL)
+	}
+
+	fmt.Println()
+	fmt.Println("Deployment Status:")
+	fmt.Println("==================")
+	conditions, _, _ := unstructured.NestedSlice(ragEngine.Object, "status", "conditions")
+	fmt.Printf("Resource Ready: %s\n", conditionStatus(conditions, "ResourceReady"))
+	fmt.Printf("Index Service Ready: %s\n", conditionStatus(conditions, "ServiceReady"))
+	fmt.Printf("RAGEngine Ready: %s\n", conditionStatus(conditions, "RAGEngineSucceeded"))
+
+	fmt.Println()
+	fmt.Println("Query Endpoints:")
+	fmt.Println("================")
+	svc, err := clientset.CoreV1().Services(o.Namespace).Get(ctx, o.WorkspaceName, metav1.GetOptions{})
+	if err != nil {
+		klog.V(3).Infof("Could not get service for RAGEngine %s: %v", o.WorkspaceName, err)
+		fmt.Println("Not available yet (the RAGEngine service has not been created)")
+	} else {
+		for _, endpoint := range ragEndpoints(svc, apiServerHost, "/query") {
+			fmt.Printf("%s: %s\n", endpoint.Type, endpoint.URL)
+		}
+	}
+
+	fmt.Println()
+	fmt.Printf("Age: %s\n", resourceAge(ragEngine))
+	return nil
+}
+
+func newRagQueryCmd(configFlags *genericclioptions.ConfigFlags) *cobra.Command {
+	o := &RagQueryOptions{
+		configFlags: configFlags,
+		clients:     newClientFactory(configFlags),
+	}
+
+	cmd := &cobra.Command{
+		Use:   "query",
+		Short: "Send a query to a deployed Kaito RAGEngine",
+		Long: `Send a retrieval-augmented query to a deployed Kaito RAGEngine.
+
+The query endpoint is resolved the same way as for 'get-endpoint': the
+cluster-internal service is used when reachable, otherwise the Kubernetes API
+proxy. The answer is printed, optionally followed by the retrieved sources.`,
+		Example: `  # Ask a question against the default index
+  kubectl kaito rag query --workspace-name my-rag --query "What is Kaito?"
+
+  # Query a specific index and show the retrieved sources
+  kubectl kaito rag query --workspace-name my-rag --index-name docs --query "What is Kaito?" --show-sources`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.validate(); err != nil {
+				return err
+			}
+			ctx, stop := withInterrupt(cmd.Context())
+			defer stop()
+			return interruptedError(ctx, o.Run(ctx))
+		},
+	}
+
+	cmd.Flags().StringVar(&o.WorkspaceName, "workspace-name", "", "Name of the RAGEngine (required)")
+	cmd.Flags().StringVarP(&o.Namespace, "namespace", "n", "", "Kubernetes namespace")
+	cmd.Flags().StringVar(&o.Query, "query", "", "Question to ask (required)")
+	cmd.Flags().StringVar(&o.IndexName, "index-name", "default", "Name of the index to query")
+	cmd.Flags().IntVar(&o.TopK, "top-k", 5, "Number of document chunks to retrieve")
+	cmd.Flags().BoolVar(&o.ShowSources, "show-sources", false, "Print the retrieved source chunks after the answer")
+
+	if err := cmd.MarkFlagRequired("workspace-name"); err != nil {
+		klog.Errorf("Failed to mark workspace-name flag as required: %v", err)
+	}
+	if err := cmd.MarkFlagRequired("query"); err != nil {
+		klog.Errorf("Failed to mark query flag as required: %v", err)
+	}
+
+	return cmd
+}
+
+func (o *RagQueryOptions) validate() error {
+	if o.WorkspaceName == "" {
+		return fmt.Errorf("workspace name is required")
+	}
+	if strings.TrimSpace(o.Query) == "" {
+		return fmt.Errorf("query must not be empty")
+	}
+	if o.IndexName == "" {
+		return fmt.Errorf("index name must not be empty")
+	}
+	if o.TopK <= 0 {
+		return fmt.Errorf("top-k must be greater than 0")
+	}
+	return nil
+}
+
+// Run executes the rag query command
+func (o *RagQueryOptions) Run(ctx context.Context) error {
+	klog.V(2).Infof("Querying RAGEngine: %s", o.WorkspaceName)
+
+	// Get namespace
+	o.Namespace = resolveNamespace(o.configFlags, o.Namespace)
+
+	endpoint, err := resolveRAGEndpoint(ctx, o.clients, o.Namespace, o.WorkspaceName, "/query")
+	if err != nil {
+		return err
+	}
+	klog.V(3).Infof("Using RAG query endpoint: %s", endpoint)
+
+	payload, err := json.Marshal(o.buildQueryPayload())
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	response, err := postJSON(ctx, o.clients, endpoint, payload, defaultEndpointTimeout)
+	if err != nil {
+		return fmt.Errorf("RAG query failed: %w", err)
+	}
+
+	answer, sources, err := parseRAGQueryResponse(response)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(answer)
+	if o.ShowSources {
+		printRAGSources(sources)
+	}
+	return nil
+}
+
+func (o *RagQueryOptions) buildQueryPayload() map[string]interface{} {
+	return map[string]interface{}{
+		"index_name": o.IndexName,
+		"query":      o.Query,
+		"top_k":      o.TopK,
+	}
+}
+
+func newRagIndexCmd(configFlags *genericclioptions.ConfigFlags) *cobra.Command {
+	o := &RagIndexOptions{
+		configFlags: configFlags,
+		clients:     newClientFactory(configFlags),
+	}
+
+	cmd := &cobra.Command{
+		Use:   "index",
+		Short: "Index documents into a Kaito RAGEngine",
+		Long: `Submit documents to a Kaito RAGEngine for indexing.
+
+Documents are read from local files or downloaded from URLs, split into chunks
+no larger than --chunk-size, and sent to the RAGEngine index endpoint. Progress
+and the result for each document are reported as they are indexed.`,
+		Example: `  # Index local files
+  kubectl kaito rag index --workspace-name my-rag --files guide.md,faq.md
+
+  # Index web pages into a named index
+  kubectl kaito rag index --workspace-name my-rag --index-name docs --urls https://example.com/guide.html
+
+  # Allow more time per request for a slow embedding model
+  kubectl kaito rag index --workspace-name my-rag --files big-manual.txt --timeout 10m`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.validate(); err != nil {
+				return err
+			}
+			ctx, stop := withInterrupt(cmd.Context())
+			defer stop()
+			return interruptedError(ctx, o.Run(ctx))
+		},
+	}
+
+	cmd.Flags().StringVar(&o.WorkspaceName, "workspace-name", "", "Name of the RAGEngine (required)")
+	cmd.Flags().StringVarP(&o.Namespace, "namespace", "n", "", "Kubernetes namespace")
+	cmd.Flags().StringVar(&o.IndexName, "index-name", "default", "Name of the index to add documents to")
+	cmd.Flags().StringSliceVar(&o.Files, "files", nil, "Local files to index")
+	cmd.Flags().StringSliceVar(&o.URLs, "urls", nil, "URLs of documents to download and index")
+	cmd.Flags().IntVar(&o.ChunkSize, "chunk-size", defaultRAGChunkSize, "Maximum bytes of document text sent per request")
+	cmd.Flags().DurationVar(&o.Timeout, "timeout", 2*time.Minute, "Timeout for each download and index request")
+
+	if err := cmd.MarkFlagRequired("workspace-name"); err != nil {
+		klog.Errorf("Failed to mark workspace-name flag as required: %v", err)
+	}
+
+	return cmd
+}
+
+func (o *RagIndexOptions) validate() error {
+	if o.WorkspaceName == "" {
+		return fmt.Errorf("workspace name is required")
+	}
+	if len(o.Files) == 0 && len(o.URLs) == 0 {
+		return fmt.Errorf("at least one of --files or --urls is required")
+	}
+	if o.IndexName == "" {
+		return fmt.Errorf("index name must not be empty")
+	}
+	if o.ChunkSize <= 0 {
+		return fmt.Errorf("chunk-size must be greater than 0")
+	}
+	if o.Timeout <= 0 {
+		return fmt.Errorf("timeout must be greater than 0")
+	}
+	for _, u := range o.URLs {
+		if !strings.HasPrefix(u, "http://") && !strings.HasPrefix(u, "https://") {
+			return fmt.Errorf("invalid URL %q: must start with http:// or https://", u)
+		}
+	}
+	return nil
+}
+
+// Run executes the rag index command
+func (o *RagIndexOptions) Run(ctx context.Context) error {
+	klog.V(2).Infof("Indexing documents into RAGEngine: %s", o.WorkspaceName)
+
+	// Get namespace
+	o.Namespace = resolveNamespace(o.configFlags, o.Namespace)
+
+	endpoint, err := resolveRAGEndpoint(ctx, o.clients, o.Namespace, o.WorkspaceName, "/index")
+	if err != nil {
+		return err
+	}
+	klog.V(3).Infof("Using RAG index endpoint: %s", endpoint)
+
+	return o.indexDocuments(ctx, func(data []byte) error {
+		_, err := postJSONBody(ctx, o.clients, endpoint, data, o.Timeout)
+		return err
+	})
+}
+
+// indexDocuments reads every source, splits it into chunks and submits each chunk with
+// send. Once ctx is cancelled it stops instead of reporting every remaining source.
+func (o *RagIndexOptions) indexDocuments(ctx context.Context, send func([]byte) error) error {
+	sources := append(append([]string{}, o.Files...), o.URLs...)
+	failed := 0
+
+	for i, source := range sources {
+		printStatus(os.Stdout, "⏳ [%d/%d] Indexing %s\n", i+1, len(sources), source)
+
+		chunks, err := o.readSourceChunks(ctx, source, i < len(o.Files))
+		if err == nil {
+			err = o.sendChunks(source, chunks, send)
+		}
+		if err != nil && ctx.Err() != nil {
+			// Interrupted; the remaining sources are not attempted
+			return ctx.Err()
+		}
+		if err != nil {
+			failed++
+			printStatus(os.Stdout, "✗ %s: %v\n", source, err)
+			continue
+		}
+
+		printStatus(os.Stdout, "✓ %s indexed (%d chunk(s))\n", source, len(chunks))
+	}
+
+	fmt.Println()
+	fmt.Printf("Indexed %d/%d document(s) into index %s\n", len(sources)-failed, len(sources), o.IndexName)
+	if failed > 0 {
+		return fmt.Errorf("failed to index %d document(s)", failed)
+	}
+	return nil
+}
+
+func (o *RagIndexOptions) readSourceChunks(ctx context.Context, source string, isFile bool) ([]string, error) {
+	var data []byte
+	var err error
+	if isFile {
+		data, err = os.ReadFile(source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file: %w", err)
+		}
+	} else {
+		data, err = o.downloadDocument(ctx, source)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	chunks := splitDocumentText(string(data), o.ChunkSize)
+	if len(chunks) == 0 {
+		return nil, fmt.Errorf("document is empty")
+	}
+	return chunks, nil
+}
+
+func (o *RagIndexOptions) downloadDocument(ctx context.Context, documentURL string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, o.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", documentURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := (&http.Client{Transport: newHTTPTransport(), Timeout: o.Timeout}).Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download document: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("download failed with status %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read document: %w", err)
+	}
+	return data, nil
+}
+
+func (o *RagIndexOptions) sendChunks(source string, chunks []string, send func([]byte) error) error {
+	for i, chunk := range chunks {
+		if len(chunks) > 1 {
+			fmt.Printf("   chunk %d/%d\n", i+1, len(chunks))
+		}
+
+		payload := map[string]interface{}{
+			"index_name": o.IndexName,
+			"documents": []interface{}{
+				map[string]interface{}{
+					"text": chunk,
+					"metadata": map[string]interface{}{
+						"source":       source,
+						"chunk":        i + 1,
+						"total_chunks": len(chunks),
+					},
+				},
+			},
+		}
+
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+		if err := send(data); err != nil {
+			return fmt.Errorf("chunk %d/%d: %w", i+1, len(chunks), err)
+		}
+	}
+	return nil
+}
+
+// splitDocumentText splits text into chunks of at most maxBytes, preferring line
+// boundaries and never splitting a UTF-8 character. Whitespace-only text yields no chunks.
+func splitDocumentText(text string, maxBytes int) []string {
+	if strings.TrimSpace(text) == "" {
+		return nil
+	}
+
+	var chunks []string
+	var current strings.Builder
+
+	flush := func() {
+		if strings.TrimSpace(current.String()) != "" {
+			chunks = append(chunks, current.String())
+		}
+		current.Reset()
+	}
+
+	for _, line := range strings.SplitAfter(text, "\n") {
+		if current.Len()+len(line) > maxBytes {
+			flush()
+		}
+		// Lines longer than a chunk are split on rune boundaries
+		for len(line) > maxBytes {
+			cut := maxBytes
+			for cut > 0 && !utf8.RuneStart(line[cut]) {
+				cut--
+			}
+			if cut == 0 {
+				cut = maxBytes
+			}
+			current.WriteString(line[:cut])
+			flush()
+			line = line[cut:]
+		}
+		current.WriteString(line)
+	}
+	flush()
+
+	return chunks
+}
+
+// resolveRAGEndpoint returns the URL of the RAGEngine API path, resolved from its service
+func resolveRAGEndpoint(ctx context.Context, clients *clientFactory, namespace, name, path string) (string, error) {
+	config, err := clients.RESTConfig()
+	if err != nil {
+		return "", err
+	}
+
+	clientset, err := clients.KubernetesClient()
+	if err != nil {
+		return "", err
+	}
+
+	svc, err := clientset.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get service for RAGEngine %s: %w", name, err)
+	}
+
+	return selectRAGEndpoint(ragEndpoints(svc, config.Host, path)), nil
+}
+
+// selectRAGEndpoint prefers the cluster-internal endpoint when its DNS name resolves
+func selectRAGEndpoint(endpoints []EndpointInfo) string {
+	for _, endpoint := range endpoints {
+		if endpoint.Type != "ClusterIP" {
+			continue
+		}
+		if u, err := url.Parse(endpoint.URL); err == nil {
+			if _, err := net.LookupHost(u.Hostname()); err == nil {
+				return endpoint.URL
+			}
+		}
+	}
+	return endpoints[0].URL
+}
+
+// parseRAGQueryResponse extracts the answer and the retrieved sources from a RAG query response
+func parseRAGQueryResponse(response map[string]interface{}) (string, []ragSource, error) {
+	answer, ok := response["response"].(string)
+	if !ok {
+		return "", nil, fmt.Errorf("unexpected response format: no response")
+	}
+
+	var sources []ragSource
+	nodes, _ := response["source_nodes"].([]interface{})
+	for _, node := range nodes {
+		nodeMap, ok := node.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		source := ragSource{}
+		source.DocID, _ = nodeMap["doc_id"].(string)
+		source.Text, _ = nodeMap["text"].(string)
+		source.Score, _ = nodeMap["score"].(float64)
+		sources = append(sources, source)
+	}
+
+	return strings.TrimSpace(answer), sources, nil
+}
+
+func printRAGSources(sources []ragSource) {
+	fmt.Println()
+	if len(sources) == 0 {
+		fmt.Println("Sources: None")
+		return
+	}
+
+	fmt.Println("Sources:")
+	for i, source := range sources {
+		text := []rune(strings.Join(strings.Fields(source.Text), " "))
+		if len(text) > 200 {
+			text = append(text[:200], []rune("...")...)
+		}
+		fmt.Printf("  [%d] %s (score %.3f)\n", i+1, source.DocID, source.Score)
+		fmt.Printf("      %s\n", string(text))
+	}
+}
+
+// describeRAGEmbedding summarizes the embedding configuration of a RAGEngine
+func describeRAGEmbedding(ragEngine *unstructured.Unstructured) string {
+	if modelID, found, _ := unstructured.NestedString(ragEngine.Object, "spec", "embedding", "local", "modelID"); found {
+		return fmt.Sprintf("local (%s)", modelID)
+	}
+	if remoteURL, found, _ := unstructured.NestedString(ragEngine.Object, "spec", "embedding", "remote", "url"); found {
+		return fmt.Sprintf("remote (%s)", remoteURL)
+	}
+	return "Unknown"
+}
+
+// ragEndpoints returns the URLs of the RAGEngine API path (e.g. /query) exposed by svc
+func ragEndpoints(svc *corev1.Service, apiServerHost, path string) []EndpointInfo {
+	scheme := serviceScheme(svc, "")
+	port := servicePort(svc, 0)
+
+	endpoints := []EndpointInfo{{
+		URL: fmt.Sprintf("%s/api/v1/namespaces/%s/services/%s/proxy%s",
+			strings.TrimSuffix(apiServerHost, "/"), svc.Namespace, apiProxyServiceName(svc.Name, scheme, port), path),
+		Type:        "APIProxy",
+		Access:      "cluster",
+		Description: "Kubernetes API proxy (works anywhere kubectl works)",
+	}}
+
+	if svc.Spec.ClusterIP != "" && svc.Spec.ClusterIP != "None" {
+		endpoints = append(endpoints, EndpointInfo{
+			URL:         fmt.Sprintf("%s://%s.%s.svc.cluster.local:%d%s", scheme, svc.Name, svc.Namespace, port, path),
+			Type:        "ClusterIP",
+			Access:      "internal",
+			Description: "Direct cluster-internal access (for pods)",
+		})
+	}
+
+	return endpoints
+}
+
+// ragReadinessPhase describes which RAGEngine component is still initializing, or "" when it is ready.
+// A remote embedding service needs no GPU node, so ResourceReady is only required for local embedding.
+func ragReadinessPhase(ragEngine *unstructured.Unstructured) string {
+	conditions, _, _ := unstructured.NestedSlice(ragEngine.Object, "status", "conditions")
+	_, localEmbedding, _ := unstructured.NestedMap(ragEngine.Object, "spec", "embedding", "local")
+
+	switch {
+	case localEmbedding && conditionStatus(conditions, "ResourceReady") != "True":
+		return "waiting for the embedding model node to be provisioned"
+	case conditionStatus(conditions, "ServiceReady") != "True":
+		return "waiting for the embedding model and vector DB index service to start"
+	case conditionStatus(conditions, "RAGEngineSucceeded") != "True":
+		return "waiting for the RAGEngine to finish initializing"
+	default:
+		return ""
+	}
+}
+
+// isSupportedVectorDB reports whether name is one of supportedVectorDBs
+func isSupportedVectorDB(name string) bool {
+	for _, db := range supportedVectorDBs {
+		if strings.EqualFold(db, name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,431 @@
+/*
+Copyright (c) 2024 Kaito Project
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestRagCmd(t *testing.T) {
+	configFlags := genericclioptions.NewConfigFlags(true)
+	cmd := NewRagCmd(configFlags)
+
+	assert.Equal(t, "rag", cmd.Use)
+	assert.NotEmpty(t, cmd.Short)
+	assert.NotEmpty(t, cmd.Long)
+
+	subcommandNames := []string{}
+	for _, subcmd := range cmd.Commands() {
+		subcommandNames = append(subcommandNames, subcmd.Name())
+	}
+	assert.Contains(t, subcommandNames, "deploy")
+	assert.Contains(t, subcommandNames, "status")
+	assert.Contains(t, subcommandNames, "query")
+	assert.Contains(t, subcommandNames, "index")
+
+	deployCmd, _, err := cmd.Find([]string{"deploy"})
+	assert.NoError(t, err)
+	for _, flag := range []string{"workspace-name", "namespace", "vector-db", "embedding-model", "embedding-url", "inference-url", "dry-run"} {
+		assert.NotNil(t, deployCmd.Flags().Lookup(flag), "Missing flag: %s", flag)
+	}
+}
+
+func TestRagDeployOptionsValidation(t *testing.T) {
+	tests := []struct {
+		name        string
+		options     RagDeployOptions
+		expectError bool
+	}{
+		{
+			name: "Valid local embedding",
+			options: RagDeployOptions{
+				WorkspaceName:  "my-rag",
+				VectorDB:       "faiss",
+				EmbeddingModel: defaultRAGEmbeddingModel,
+				InferenceURL:   "http://my-llama/v1/completions",
+			},
+		},
+		{
+			name: "Valid remote embedding",
+			options: RagDeployOptions{
+				WorkspaceName:  "my-rag",
+				VectorDB:       "faiss",
+				EmbeddingModel: defaultRAGEmbeddingModel,
+				EmbeddingURL:   "http://embedder/v1/embeddings",
+				InferenceURL:   "http://my-llama/v1/completions",
+			},
+		},
+		{
+			name: "Missing workspace name",
+			options: RagDeployOptions{
+				VectorDB:       "faiss",
+				EmbeddingModel: defaultRAGEmbeddingModel,
+				InferenceURL:   "http://my-llama/v1/completions",
+			},
+			expectError: true,
+		},
+		{
+			name: "Unsupported vector database",
+			options: RagDeployOptions{
+				WorkspaceName:  "my-rag",
+				VectorDB:       "unknown-db",
+				EmbeddingModel: defaultRAGEmbeddingModel,
+				InferenceURL:   "http://my-llama/v1/completions",
+			},
+			expectError: true,
+		},
+		{
+			name: "Missing inference URL",
+			options: RagDeployOptions{
+				WorkspaceName:  "my-rag",
+				VectorDB:       "faiss",
+				EmbeddingModel: defaultRAGEmbeddingModel,
+			},
+			expectError: true,
+		},
+		{
+			name: "Wait without timeout",
+			options: RagDeployOptions{
+				WorkspaceName:  "my-rag",
+				VectorDB:       "faiss",
+				EmbeddingModel: defaultRAGEmbeddingModel,
+				InferenceURL:   "http://my-llama/v1/completions",
+				Wait:           true,
+			},
+			expectError: true,
+		},
+		{
+			name: "Embedding model and URL together",
+			options: RagDeployOptions{
+				WorkspaceName:  "my-rag",
+				VectorDB:       "faiss",
+				EmbeddingModel: "BAAI/bge-base-en-v1.5",
+				EmbeddingURL:   "http://embedder/v1/embeddings",
+				InferenceURL:   "http://my-llama/v1/completions",
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.options.Validate()
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestBuildRAGEngine(t *testing.T) {
+	t.Run("Local embedding", func(t *testing.T) {
+		o := &RagDeployOptions{
+			WorkspaceName:   "my-rag",
+			Namespace:       "default",
+			EmbeddingModel:  defaultRAGEmbeddingModel,
+			InferenceURL:    "http://my-llama/v1/completions",
+			InferenceSecret: "llm-token",
+			InstanceType:    "Standard_NC4as_T4_v3",
+		}
+
+		ragEngine := o.buildRAGEngine()
+		assert.Equal(t, "RAGEngine", ragEngine.GetKind())
+		assert.Equal(t, "kaito.sh/v1alpha1", ragEngine.GetAPIVersion())
+
+		modelID, _, _ := unstructured.NestedString(ragEngine.Object, "spec", "embedding", "local", "modelID")
+		assert.Equal(t, defaultRAGEmbeddingModel, modelID)
+		instanceType, _, _ := unstructured.NestedString(ragEngine.Object, "spec", "compute", "instanceType")
+		assert.Equal(t, "Standard_NC4as_T4_v3", instanceType)
+		url, _, _ := unstructured.NestedString(ragEngine.Object, "spec", "inferenceService", "url")
+		assert.Equal(t, "http://my-llama/v1/completions", url)
+		secret, _, _ := unstructured.NestedString(ragEngine.Object, "spec", "inferenceService", "accessSecret")
+		assert.Equal(t, "llm-token", secret)
+	})
+
+	t.Run("Remote embedding has no compute", func(t *testing.T) {
+		o := &RagDeployOptions{
+			WorkspaceName: "my-rag",
+			Namespace:     "default",
+			EmbeddingURL:  "http://embedder/v1/embeddings",
+			InferenceURL:  "http://my-llama/v1/completions",
+		}
+
+		ragEngine := o.buildRAGEngine()
+		url, _, _ := unstructured.NestedString(ragEngine.Object, "spec", "embedding", "remote", "url")
+		assert.Equal(t, "http://embedder/v1/embeddings", url)
+		_, hasCompute, _ := unstructured.NestedMap(ragEngine.Object, "spec", "compute")
+		assert.False(t, hasCompute)
+	})
+}
+
+func TestRagEndpoints(t *testing.T) {
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-rag", Namespace: "default"},
+		Spec:       corev1.ServiceSpec{ClusterIP: "10.0.0.5", Ports: []corev1.ServicePort{{Name: "http", Port: 80}}},
+	}
+
+	endpoints := ragEndpoints(svc, "https://api.example.com/", "/query")
+	assert.Len(t, endpoints, 2)
+	assert.Equal(t, "https://api.example.com/api/v1/namespaces/default/services/my-rag:80/proxy/query", endpoints[0].URL)
+	assert.Equal(t, "http://my-rag.default.svc.cluster.local:80/query", endpoints[1].URL)
+}
+
+func TestShowRAGEngineStatus(t *testing.T) {
+	ragEngine := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"embedding": map[string]interface{}{
+				"local": map[string]interface{}{"modelID": defaultRAGEmbeddingModel},
+			},
+		},
+		"status": map[string]interface{}{
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "ResourceReady", "status": "True"},
+			},
+		},
+	}}
+	ragEngine.SetAPIVersion("kaito.sh/v1alpha1")
+	ragEngine.SetKind("RAGEngine")
+	ragEngine.SetName("my-rag")
+	ragEngine.SetNamespace("default")
+
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			{Group: "kaito.sh", Version: "v1alpha1", Resource: "ragengines"}: "RAGEngineList",
+		}, ragEngine)
+
+	o := &RagStatusOptions{WorkspaceName: "my-rag", Namespace: "default"}
+	assert.NoError(t, o.showRAGEngineStatus(context.TODO(), dynamicClient, fake.NewSimpleClientset(), "https://api.example.com"))
+	assert.Equal(t, "local (BAAI/bge-small-en-v1.5)", describeRAGEmbedding(ragEngine))
+
+	missing := &RagStatusOptions{WorkspaceName: "missing", Namespace: "default"}
+	assert.Error(t, missing.showRAGEngineStatus(context.TODO(), dynamicClient, fake.NewSimpleClientset(), "https://api.example.com"))
+}
+
+func newTestRAGEngine(name string, local bool, conditions map[string]string) *unstructured.Unstructured {
+	embedding := map[string]interface{}{
+		"remote": map[string]interface{}{"url": "http://embedder/v1/embeddings"},
+	}
+	if local {
+		embedding = map[string]interface{}{
+			"local": map[string]interface{}{"modelID": defaultRAGEmbeddingModel},
+		}
+	}
+
+	var conditionList []interface{}
+	for condType, status := range conditions {
+		conditionList = append(conditionList, map[string]interface{}{"type": condType, "status": status})
+	}
+
+	ragEngine := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec":   map[string]interface{}{"embedding": embedding},
+		"status": map[string]interface{}{"conditions": conditionList},
+	}}
+	ragEngine.SetAPIVersion("kaito.sh/v1alpha1")
+	ragEngine.SetKind("RAGEngine")
+	ragEngine.SetName(name)
+	ragEngine.SetNamespace("default")
+	return ragEngine
+}
+
+func TestRagReadinessPhase(t *testing.T) {
+	tests := []struct {
+		name       string
+		local      bool
+		conditions map[string]string
+		expected   string
+	}{
+		{"Local embedding without node", true, map[string]string{}, "waiting for the embedding model node to be provisioned"},
+		{"Remote embedding skips node", false, map[string]string{}, "waiting for the embedding model and vector DB index service to start"},
+		{"Service pending", true, map[string]string{"ResourceReady": "True"}, "waiting for the embedding model and vector DB index service to start"},
+		{"Service ready", true, map[string]string{"ResourceReady": "True", "ServiceReady": "True"}, "waiting for the RAGEngine to finish initializing"},
+		{"Ready", true, map[string]string{"ResourceReady": "True", "ServiceReady": "True", "RAGEngineSucceeded": "True"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ragReadinessPhase(newTestRAGEngine("my-rag", tt.local, tt.conditions)))
+		})
+	}
+}
+
+func TestWaitForRAGEngineReady(t *testing.T) {
+	newClient := func(objects ...runtime.Object) *dynamicfake.FakeDynamicClient {
+		return dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+			map[schema.GroupVersionResource]string{
+				{Group: "kaito.sh", Version: "v1alpha1", Resource: "ragengines"}: "RAGEngineList",
+			}, objects...)
+	}
+
+	t.Run("Ready RAGEngine returns immediately", func(t *testing.T) {
+		client := newClient(newTestRAGEngine("my-rag", true, map[string]string{
+			"ResourceReady":      "True",
+			"ServiceReady":       "True",
+			"RAGEngineSucceeded": "True",
+		}))
+		o := &RagDeployOptions{WorkspaceName: "my-rag", Namespace: "default", Timeout: time.Second}
+
+		assert.NoError(t, o.waitForRAGEngineReady(context.TODO(), client))
+	})
+
+	t.Run("Pending RAGEngine times out", func(t *testing.T) {
+		client := newClient(newTestRAGEngine("my-rag", true, map[string]string{"ResourceReady": "True"}))
+		o := &RagDeployOptions{WorkspaceName: "my-rag", Namespace: "default", Timeout: 100 * time.Millisecond}
+
+		err := o.waitForRAGEngineReady(context.TODO(), client)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "timed out")
+		assert.Contains(t, err.Error(), "kubectl kaito rag status --workspace-name my-rag")
+	})
+
+	t.Run("Missing RAGEngine fails", func(t *testing.T) {
+		o := &RagDeployOptions{WorkspaceName: "missing", Namespace: "default", Timeout: time.Second}
+		assert.Error(t, o.waitForRAGEngineReady(context.TODO(), newClient()))
+	})
+}
+
+func TestRagQueryOptions(t *testing.T) {
+	o := &RagQueryOptions{WorkspaceName: "my-rag", Query: "What is Kaito?", IndexName: "docs", TopK: 3}
+	assert.NoError(t, o.validate())
+
+	payload := o.buildQueryPayload()
+	assert.Equal(t, "docs", payload["index_name"])
+	assert.Equal(t, "What is Kaito?", payload["query"])
+	assert.Equal(t, 3, payload["top_k"])
+
+	assert.Error(t, (&RagQueryOptions{WorkspaceName: "my-rag", Query: "  ", IndexName: "docs", TopK: 3}).validate())
+	assert.Error(t, (&RagQueryOptions{WorkspaceName: "my-rag", Query: "q", IndexName: "docs", TopK: 0}).validate())
+}
+
+func TestParseRAGQueryResponse(t *testing.T) {
+	response := map[string]interface{}{
+		"response": " Kaito is an operator. ",
+		"source_nodes": []interface{}{
+			map[string]interface{}{"doc_id": "doc-1", "text": "Kaito automates...", "score": 0.87},
+		},
+	}
+
+	answer, sources, err := parseRAGQueryResponse(response)
+	assert.NoError(t, err)
+	assert.Equal(t, "Kaito is an operator.", answer)
+	assert.Equal(t, []ragSource{{DocID: "doc-1", Text: "Kaito automates...", Score: 0.87}}, sources)
+
+	_, _, err = parseRAGQueryResponse(map[string]interface{}{})
+	assert.Error(t, err)
+}
+
+func TestSelectRAGEndpoint(t *testing.T) {
+	endpoints := []EndpointInfo{
+		{URL: "https://api.example.com/api/v1/namespaces/default/services/my-rag:80/proxy/query", Type: "APIProxy"},
+		{URL: "http://my-rag.default.svc.cluster.local:80/query", Type: "ClusterIP"},
+	}
+
+	// Cluster DNS does not resolve outside the cluster, so the API proxy is used
+	assert.Equal(t, endpoints[0].URL, selectRAGEndpoint(endpoints))
+}
+
+func TestSplitDocumentText(t *testing.T) {
+	assert.Empty(t, splitDocumentText("  \n ", 10))
+	assert.Equal(t, []string{"short\n"}, splitDocumentText("short\n", 100))
+
+	chunks := splitDocumentText("line one\nline two\nline three\n", 12)
+	assert.Equal(t, []string{"line one\n", "line two\n", "line three\n"}, chunks)
+
+	long := strings.Repeat("é", 10) // 20 bytes
+	chunks = splitDocumentText(long, 5)
+	assert.Equal(t, long, strings.Join(chunks, ""))
+	for _, chunk := range chunks {
+		assert.LessOrEqual(t, len(chunk), 5)
+		assert.True(t, strings.HasPrefix(chunk, "é"), "chunks must not split characters")
+	}
+}
+
+func TestRagIndexDocuments(t *testing.T) {
+	dir := t.TempDir()
+	goodFile := filepath.Join(dir, "guide.md")
+	assert.NoError(t, os.WriteFile(goodFile, []byte("first line\nsecond line\n"), 0o644))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte("remote doc"))
+	}))
+	defer server.Close()
+
+	o := &RagIndexOptions{
+		Files:     []string{goodFile, filepath.Join(dir, "missing.md")},
+		URLs:      []string{server.URL + "/doc", server.URL + "/missing"},
+		IndexName: "docs",
+		ChunkSize: 12,
+		Timeout:   5 * time.Second,
+	}
+
+	var requests []map[string]interface{}
+	err := o.indexDocuments(context.TODO(), func(data []byte) error {
+		var payload map[string]interface{}
+		assert.NoError(t, json.Unmarshal(data, &payload))
+		requests = append(requests, payload)
+		return nil
+	})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to index 2 document(s)")
+	// The local file is split into two chunks and the remote document fits into one
+	assert.Len(t, requests, 3)
+	assert.Equal(t, "docs", requests[0]["index_name"])
+
+	t.Run("Send failures are reported per document", func(t *testing.T) {
+		o := &RagIndexOptions{Files: []string{goodFile}, IndexName: "docs", ChunkSize: defaultRAGChunkSize, Timeout: time.Second}
+		err := o.indexDocuments(context.TODO(), func([]byte) error { return fmt.Errorf("boom") })
+		assert.Error(t, err)
+	})
+}
+
+func TestRagIndexValidation(t *testing.T) {
+	valid := RagIndexOptions{WorkspaceName: "my-rag", Files: []string{"a.md"}, IndexName: "default", ChunkSize: 1024, Timeout: time.Minute}
+	assert.NoError(t, valid.validate())
+
+	noSources := valid
+	noSources.Files = nil
+	assert.Error(t, noSources.validate())
+
+	badURL := valid
+	badURL.URLs = []string{"ftp://example.com/doc"}
+	assert.Error(t, badURL.validate())
+}
@@ -17,13 +17,167 @@ limitations under the License.
 package cmd
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/klog/v2"
 )
 
+// ExitError is returned by commands that report their result through the process
+// exit code. The error has already been reported (or deliberately not printed).
+type ExitError struct {
+	Code int
+}
+
+func (e *ExitError) Error() string {
+	return fmt.Sprintf("exit status %d", e.Code)
+}
+
+// jsonErrors is the --json-errors switch that reports command failures as JSON
+var jsonErrors bool
+
+// commandError is the object --json-errors prints for a failed command
+type commandError struct {
+	Error string `json:"error"`
+	// Command is the subcommand path, e.g. "models list", or empty for the root command
+	Command string `json:"command"`
+	Code    int    `json:"code"`
+}
+
+// Execute runs the root command with args and returns the process exit code.
+// Failures are printed to stderr as cobra does, or as a single JSON object with
+// --json-errors. Commands that set SilenceErrors, like 'status --quiet', print nothing.
+func Execute(root *cobra.Command, args []string) int {
+	// Errors are printed here so the format can follow --json-errors
+	root.SilenceErrors = true
+	root.SetArgs(args)
+	cmd, err := root.ExecuteC()
+	if err == nil {
+		return 0
+	}
+	if !jsonErrors && (cmd == nil || cmd == root) {
+		// An unknown command fails before any flags are parsed
+		jsonErrors = jsonErrorsRequested(args)
+	}
+
+	code := 1
+	var exitErr *ExitError
+	interrupted := errors.Is(err, errInterrupted)
+	switch {
+	case errors.As(err, &exitErr):
+		code = exitErr.Code
+	case interrupted:
+		code = interruptExitCode
+	}
+	if cmd != nil && cmd != root && cmd.SilenceErrors {
+		return code
+	}
+
+	if !jsonErrors {
+		if interrupted {
+			// The line after ^C, without the "Error:" prefix of real failures
+			root.PrintErrln("\nInterrupted")
+			return code
+		}
+		root.PrintErrln(root.ErrPrefix(), err.Error())
+		return code
+	}
+	// The command is reported without the binary name, which differs between
+	// 'kubectl kaito' and the standalone binary
+	var command string
+	if cmd != nil && cmd != root {
+		command = strings.TrimPrefix(cmd.CommandPath(), root.CommandPath()+" ")
+	}
+	data, marshalErr := json.Marshal(commandError{Error: err.Error(), Command: command, Code: code})
+	if marshalErr != nil {
+		root.PrintErrln(root.ErrPrefix(), err.Error())
+		return code
+	}
+	fmt.Fprintln(root.ErrOrStderr(), string(data))
+	return code
+}
+
+// jsonErrorsRequested reports whether args contain --json-errors, for failures that
+// happen before cobra parses the flags
+func jsonErrorsRequested(args []string) bool {
+	flags := pflag.NewFlagSet("json-errors", pflag.ContinueOnError)
+	flags.ParseErrorsWhitelist.UnknownFlags = true
+	flags.SetOutput(io.Discard)
+	requested := flags.Bool("json-errors", false, "")
+	_ = flags.Parse(args)
+	return *requested
+}
+
+// allNamespacesAnnotation marks commands (and their subcommands) that support
+// the global -A/--all-namespaces flag
+const allNamespacesAnnotation = "kaito.sh/all-namespaces"
+
+// checkAllNamespaces rejects -A/--all-namespaces for commands that need a single namespace
+func checkAllNamespaces(cmd *cobra.Command) error {
+	flag := cmd.Flag("all-namespaces")
+	if flag == nil || flag.Value.String() != "true" {
+		return nil
+	}
+	for c := cmd; c != nil; c = c.Parent() {
+		if c.Annotations[allNamespacesAnnotation] == "true" {
+			return nil
+		}
+	}
+	return fmt.Errorf("--all-namespaces is not supported by '%s'; it works on a single workspace in one namespace, use -n instead", cmd.CommandPath())
+}
+
+// Log formats accepted by --log-format
+const (
+	logFormatText = "text"
+	logFormatJSON = "json"
+)
+
+// logFormat is the --log-format of the plugin's own klog output
+var logFormat = logFormatText
+
+// configureLogging routes klog output through a JSON slog handler writing to out
+// for --log-format json. The text format keeps klog's default output.
+func configureLogging(format string, out io.Writer) error {
+	switch format {
+	case logFormatText:
+		klog.ClearLogger()
+	case logFormatJSON:
+		klog.SetSlogLogger(slog.New(slog.NewJSONHandler(out, nil)))
+	default:
+		return fmt.Errorf("invalid --log-format %q; must be %q or %q", format, logFormatText, logFormatJSON)
+	}
+	return nil
+}
+
+// parseRequestTimeout parses a --request-timeout value the way kubectl does: a
+// duration with a unit, or a bare number of seconds. Zero disables the timeout.
+func parseRequestTimeout(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		value = fmt.Sprintf("%ds", seconds)
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid --request-timeout %q; must be a duration such as 30s or 2m, or 0 for no timeout", value)
+	}
+	if timeout < 0 {
+		return 0, fmt.Errorf("--request-timeout must not be negative, got %s", value)
+	}
+	return timeout, nil
+}
+
 // NewRootCmd creates the root command for kubectl-kaito
 func NewRootCmd(configFlags *genericclioptions.ConfigFlags, isPlugin bool) *cobra.Command {
 	var cmdName = "kaito"
@@ -53,24 +207,65 @@ in Kubernetes clusters through Kaito workspaces.`,
   %s chat --workspace-name my-llama
 
   # List supported models
-  %s models list`, cmdName, cmdName, cmdName, cmdName, cmdName),
+  %s models list
+
+  # Deploy a RAG engine
+  %s rag deploy --workspace-name my-rag --inference-url http://my-llama/v1/completions`, cmdName, cmdName, cmdName, cmdName, cmdName, cmdName),
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := configureLogging(logFormat, os.Stderr); err != nil {
+				return err
+			}
 			klog.V(4).Info("Initializing kubectl-kaito command")
-			return nil
+			if modelsFetchTimeout <= 0 {
+				return fmt.Errorf("--models-timeout must be positive, got %s", modelsFetchTimeout)
+			}
+			if err := validateHTTPURL("--models-url", modelsURL); err != nil {
+				return err
+			}
+			modelsRootCAs = nil
+			if modelsCAFile != "" {
+				pool, err := loadCertPool(modelsCAFile)
+				if err != nil {
+					return err
+				}
+				modelsRootCAs = pool
+			}
+			if _, err := parseRequestTimeout(*configFlags.Timeout); err != nil {
+				return err
+			}
+			insecureSkipTLSVerify = configFlags.Insecure != nil && *configFlags.Insecure
+			return checkAllNamespaces(cmd)
 		},
 	}
 
 	// Add only essential global flags for Kaito users
 	cmd.PersistentFlags().StringVar(configFlags.KubeConfig, "kubeconfig", *configFlags.KubeConfig, "Path to the kubeconfig file to use for CLI requests")
 	cmd.PersistentFlags().StringVar(configFlags.Context, "context", *configFlags.Context, "The name of the kubeconfig context to use")
+	cmd.PersistentFlags().BoolVar(configFlags.Insecure, "insecure-skip-tls-verify", *configFlags.Insecure, "If true, the server's certificate will not be checked for validity. This will make your HTTPS connections insecure")
 	cmd.PersistentFlags().StringVarP(configFlags.Namespace, "namespace", "n", *configFlags.Namespace, "If present, the namespace scope for this CLI request")
+	cmd.PersistentFlags().StringVar(configFlags.Timeout, "request-timeout", *configFlags.Timeout, "The length of time to wait before giving up on a single server request. Non-zero values should contain a corresponding time unit (e.g. 1s, 2m, 3h). A value of zero means don't timeout requests.")
+	cmd.PersistentFlags().BoolP("all-namespaces", "A", false, "List workspaces across all namespaces (supported by status and endpoints; models are not namespaced)")
+	cmd.PersistentFlags().DurationVar(&modelsFetchTimeout, "models-timeout", defaultModelsFetchTimeout, "Timeout for each attempt to fetch the supported models list")
+	cmd.PersistentFlags().StringVar(&modelsURL, "models-url", modelsURL, "URL of the supported_models.yaml list, e.g. an internal mirror")
+	cmd.PersistentFlags().StringVar(&modelsCAFile, "models-ca-file", "", "PEM CA bundle to trust, in addition to the system roots, when fetching the supported models list")
+	cmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Disable colored output and status emoji (also disabled by NO_COLOR or when output is not a terminal)")
+	cmd.PersistentFlags().StringVar(&logFormat, "log-format", logFormatText, `Format of the plugin's log messages: "text" or "json" (one JSON object per line on stderr)`)
+	cmd.PersistentFlags().BoolVar(&jsonErrors, "json-errors", false, `Report a command failure as a single JSON object {"error", "command", "code"} on stderr`)
 
 	// Add subcommands
 	cmd.AddCommand(NewDeployCmd(configFlags))
 	cmd.AddCommand(NewStatusCmd(configFlags))
+	cmd.AddCommand(NewDescribeCmd(configFlags))
+	cmd.AddCommand(NewScaleCmd(configFlags))
 	cmd.AddCommand(NewModelsCmd(configFlags))
 	cmd.AddCommand(NewGetEndpointCmd(configFlags))
+	cmd.AddCommand(NewEndpointsCmd(configFlags))
+	cmd.AddCommand(NewPortForwardCmd(configFlags))
 	cmd.AddCommand(NewChatCmd(configFlags))
+	cmd.AddCommand(NewGenerateCmd(configFlags))
+	cmd.AddCommand(NewEmbedCmd(configFlags))
+	cmd.AddCommand(NewRagCmd(configFlags))
+	cmd.AddCommand(NewCompletionCmd(isPlugin))
 
 	return cmd
 }
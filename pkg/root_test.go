@@ -17,12 +17,16 @@ limitations under the License.
 package cmd
 
 import (
+	"bytes"
+	"encoding/json"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/klog/v2"
 )
 
 func TestNewRootCmd(t *testing.T) {
@@ -82,7 +86,7 @@ func TestRootCmdStructure(t *testing.T) {
 		assert.Contains(t, cmd.Example, "kubectl kaito get-endpoint")
 		assert.Contains(t, cmd.Example, "kubectl kaito chat")
 		assert.Contains(t, cmd.Example, "kubectl kaito models")
-		// Note: RAG command is currently commented out in root.go
+		assert.Contains(t, cmd.Example, "kubectl kaito rag")
 	})
 }
 
@@ -93,9 +97,17 @@ func TestRootCmdSubcommands(t *testing.T) {
 	expectedSubcommands := []string{
 		"deploy",
 		"status",
+		"describe",
+		"scale",
 		"get-endpoint",
+		"endpoints",
+		"port-forward",
 		"chat",
+		"generate",
+		"embed",
 		"models",
+		"rag",
+		"completion",
 	}
 
 	t.Run("Subcommands present", func(t *testing.T) {
@@ -137,6 +149,32 @@ func TestRootCmdPersistentPreRunE(t *testing.T) {
 	})
 }
 
+func TestRootCmdAllNamespaces(t *testing.T) {
+	configFlags := genericclioptions.NewConfigFlags(true)
+	cmd := NewRootCmd(configFlags, false)
+	assert.NoError(t, cmd.PersistentFlags().Set("all-namespaces", "true"))
+
+	for _, tt := range []struct {
+		args    []string
+		wantErr bool
+	}{
+		{args: []string{"status"}, wantErr: false},
+		{args: []string{"models", "list"}, wantErr: false},
+		{args: []string{"endpoints"}, wantErr: false},
+		{args: []string{"get-endpoint"}, wantErr: true},
+		{args: []string{"chat"}, wantErr: true},
+	} {
+		subcmd, _, err := cmd.Find(tt.args)
+		assert.NoError(t, err)
+		err = cmd.PersistentPreRunE(subcmd, nil)
+		if tt.wantErr {
+			assert.ErrorContains(t, err, "--all-namespaces is not supported")
+		} else {
+			assert.NoError(t, err)
+		}
+	}
+}
+
 func TestRootCmdFlags(t *testing.T) {
 	configFlags := genericclioptions.NewConfigFlags(true)
 	cmd := NewRootCmd(configFlags, false)
@@ -220,3 +258,131 @@ func TestRootCmdValidation(t *testing.T) {
 		assert.NoError(t, cmd.ValidateArgs([]string{"arg1"}))
 	})
 }
+
+func TestConfigureLogging(t *testing.T) {
+	defer klog.ClearLogger()
+
+	t.Run("JSON lines", func(t *testing.T) {
+		var out bytes.Buffer
+		assert.NoError(t, configureLogging(logFormatJSON, &out))
+		klog.ErrorS(nil, "Failed to fetch supported models", "url", "https://example.com/models.yaml")
+		klog.Flush()
+
+		var entry map[string]interface{}
+		assert.NoError(t, json.Unmarshal(out.Bytes(), &entry), out.String())
+		assert.Equal(t, "Failed to fetch supported models", entry["msg"])
+		assert.Equal(t, "https://example.com/models.yaml", entry["url"])
+	})
+
+	t.Run("Text and invalid formats", func(t *testing.T) {
+		assert.NoError(t, configureLogging(logFormatText, nil))
+		assert.ErrorContains(t, configureLogging("yaml", nil), `invalid --log-format "yaml"`)
+	})
+
+	t.Run("Flag is validated before running a command", func(t *testing.T) {
+		cmd := NewRootCmd(genericclioptions.NewConfigFlags(true), false)
+		assert.NotNil(t, cmd.PersistentFlags().Lookup("log-format"))
+		assert.NoError(t, cmd.PersistentFlags().Set("log-format", "xml"))
+		defer func() { logFormat = logFormatText }()
+		assert.Error(t, cmd.PersistentPreRunE(cmd, nil))
+	})
+}
+
+func TestParseRequestTimeout(t *testing.T) {
+	for _, tt := range []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{value: "", want: 0},
+		{value: "0", want: 0},
+		{value: "30", want: 30 * time.Second},
+		{value: "2m", want: 2 * time.Minute},
+		{value: "1h30m", want: 90 * time.Minute},
+		{value: "-5s", wantErr: true},
+		{value: "soon", wantErr: true},
+	} {
+		got, err := parseRequestTimeout(tt.value)
+		if tt.wantErr {
+			assert.Error(t, err, tt.value)
+			continue
+		}
+		assert.NoError(t, err, tt.value)
+		assert.Equal(t, tt.want, got, tt.value)
+	}
+
+	t.Run("Flag is validated before running a command", func(t *testing.T) {
+		configFlags := genericclioptions.NewConfigFlags(true)
+		cmd := NewRootCmd(configFlags, false)
+		assert.NoError(t, cmd.PersistentFlags().Set("request-timeout", "45s"))
+		assert.Equal(t, "45s", *configFlags.Timeout)
+		assert.NoError(t, cmd.PersistentPreRunE(cmd, nil))
+
+		assert.NoError(t, cmd.PersistentFlags().Set("request-timeout", "later"))
+		assert.ErrorContains(t, cmd.PersistentPreRunE(cmd, nil), `invalid --request-timeout "later"`)
+	})
+}
+
+func TestExecute(t *testing.T) {
+	newRoot := func() (*cobra.Command, *bytes.Buffer) {
+		root := NewRootCmd(genericclioptions.NewConfigFlags(true), true)
+		root.AddCommand(&cobra.Command{
+			Use: "fail",
+			RunE: func(cmd *cobra.Command, args []string) error {
+				return &ExitError{Code: 3}
+			},
+		}, &cobra.Command{
+			Use: "quiet-fail",
+			RunE: func(cmd *cobra.Command, args []string) error {
+				cmd.SilenceErrors = true
+				return &ExitError{Code: 2}
+			},
+		})
+		var stderr bytes.Buffer
+		root.SetErr(&stderr)
+		return root, &stderr
+	}
+	t.Cleanup(func() { jsonErrors = false })
+
+	t.Run("Text error", func(t *testing.T) {
+		root, stderr := newRoot()
+		assert.Equal(t, 1, Execute(root, []string{"status", "--bogus"}))
+		assert.Equal(t, "Error: unknown flag: --bogus\n", stderr.String())
+	})
+
+	t.Run("JSON error", func(t *testing.T) {
+		root, stderr := newRoot()
+		assert.Equal(t, 1, Execute(root, []string{"status", "--json-errors", "--bogus"}))
+
+		var reported commandError
+		assert.NoError(t, json.Unmarshal(stderr.Bytes(), &reported))
+		assert.Equal(t, commandError{Error: "unknown flag: --bogus", Command: "status", Code: 1}, reported)
+		assert.Equal(t, 1, strings.Count(stderr.String(), "\n"))
+	})
+
+	t.Run("JSON error for an unknown command", func(t *testing.T) {
+		root, stderr := newRoot()
+		assert.Equal(t, 1, Execute(root, []string{"--json-errors", "bogus"}))
+		assert.Contains(t, stderr.String(), `"command":""`)
+		assert.Contains(t, stderr.String(), `unknown command \"bogus\"`)
+	})
+
+	t.Run("JSON error keeps the exit code", func(t *testing.T) {
+		root, stderr := newRoot()
+		assert.Equal(t, 3, Execute(root, []string{"fail", "--json-errors"}))
+		assert.JSONEq(t, `{"error": "exit status 3", "command": "fail", "code": 3}`, stderr.String())
+	})
+
+	t.Run("Silenced command prints nothing", func(t *testing.T) {
+		root, stderr := newRoot()
+		assert.Equal(t, 2, Execute(root, []string{"quiet-fail", "--json-errors"}))
+		assert.Empty(t, stderr.String())
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		root, stderr := newRoot()
+		root.AddCommand(&cobra.Command{Use: "ok", Run: func(*cobra.Command, []string) {}})
+		assert.Equal(t, 0, Execute(root, []string{"ok"}))
+		assert.Empty(t, stderr.String())
+	})
+}
@@ -0,0 +1,220 @@
+/*
+Copyright (c) 2024 Kaito Project
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+)
+
+// ScaleOptions holds the options for the scale command
+type ScaleOptions struct {
+	configFlags   *genericclioptions.ConfigFlags
+	clients       *clientFactory
+	WorkspaceName string
+	Namespace     string
+	Count         int
+	DryRun        bool
+	Yes           bool
+}
+
+// NewScaleCmd creates the scale command
+func NewScaleCmd(configFlags *genericclioptions.ConfigFlags) *cobra.Command {
+	o := &ScaleOptions{
+		configFlags: configFlags,
+		clients:     newClientFactory(configFlags),
+	}
+
+	cmd := &cobra.Command{
+		Use:   "scale",
+		Short: "Change the GPU node count of a Kaito workspace",
+		Long: `Scale updates the number of GPU nodes (resource.count) of an existing
+Kaito workspace.
+
+The requested count is checked against the node range supported by the
+workspace's preset model.
+
+Changing the count adds or removes GPU nodes, so the command asks for
+confirmation first. Pass --yes to skip it in scripts; without a terminal the
+command fails instead of waiting for an answer.`,
+		Example: `  # Scale a workspace to 2 GPU nodes
+  kubectl kaito scale --workspace-name my-llama --count 2
+
+  # Show the resulting workspace without applying it
+  kubectl kaito scale --workspace-name my-llama --count 2 --dry-run
+
+  # Scale without the confirmation prompt, e.g. in CI
+  kubectl kaito scale --workspace-name my-llama --count 1 --yes`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.validate(); err != nil {
+				return err
+			}
+			return o.run()
+		},
+	}
+
+	cmd.Flags().StringVar(&o.WorkspaceName, "workspace-name", "", "Name of the workspace (required)")
+	cmd.Flags().StringVarP(&o.Namespace, "namespace", "n", "", "Kubernetes namespace")
+	cmd.Flags().IntVar(&o.Count, "count", 0, "Number of GPU nodes (required)")
+	cmd.Flags().BoolVar(&o.DryRun, "dry-run", false, "Print the scaled workspace without applying it")
+	cmd.Flags().BoolVarP(&o.Yes, "yes", "y", false, "Scale without asking for confirmation")
+
+	if err := cmd.MarkFlagRequired("workspace-name"); err != nil {
+		klog.Errorf("Failed to mark workspace-name flag as required: %v", err)
+	}
+	if err := cmd.MarkFlagRequired("count"); err != nil {
+		klog.Errorf("Failed to mark count flag as required: %v", err)
+	}
+
+	return cmd
+}
+
+func (o *ScaleOptions) validate() error {
+	klog.V(4).Info("Validating scale options")
+
+	if o.WorkspaceName == "" {
+		return fmt.Errorf("workspace name is required")
+	}
+	if o.Count <= 0 {
+		return fmt.Errorf("count must be greater than 0")
+	}
+
+	klog.V(4).Info("Scale validation completed successfully")
+	return nil
+}
+
+func (o *ScaleOptions) run() error {
+	klog.V(2).Infof("Scaling workspace %s to %d nodes", o.WorkspaceName, o.Count)
+
+	// Get namespace
+	o.Namespace = resolveNamespace(o.configFlags, o.Namespace)
+
+	dynamicClient, err := o.clients.DynamicClient()
+	if err != nil {
+		return err
+	}
+
+	return o.scaleWorkspace(dynamicClient, getSupportedModels())
+}
+
+// scaleWorkspace sets resource.count on the workspace, checking it against the preset's node range
+func (o *ScaleOptions) scaleWorkspace(dynamicClient dynamic.Interface, models []Model) error {
+	gvr := schema.GroupVersionResource{
+		Group:    "kaito.sh",
+		Version:  "v1beta1",
+		Resource: "workspaces",
+	}
+
+	workspace, err := dynamicClient.Resource(gvr).Namespace(o.Namespace).Get(
+		context.TODO(),
+		o.WorkspaceName,
+		metav1.GetOptions{},
+	)
+	if err != nil {
+		klog.Errorf("Failed to get workspace %s: %v", o.WorkspaceName, err)
+		return fmt.Errorf("failed to get workspace %s: %w", o.WorkspaceName, err)
+	}
+
+	_, modelName := workspacePreset(workspace)
+	if err := validateNodeCount(models, modelName, o.Count); err != nil {
+		return err
+	}
+
+	currentCount, _, _ := unstructured.NestedInt64(workspace.Object, "resource", "count")
+
+	if err := unstructured.SetNestedField(workspace.Object, int64(o.Count), "resource", "count"); err != nil {
+		return fmt.Errorf("failed to set resource count: %w", err)
+	}
+
+	if o.DryRun {
+		printStatus(os.Stdout, "🔍 Dry-run mode: workspace %s would be scaled from %d to %d nodes\n", o.WorkspaceName, currentCount, o.Count)
+		fmt.Println()
+
+		yamlData, err := yaml.Marshal(workspace.Object)
+		if err != nil {
+			return fmt.Errorf("failed to marshal workspace to YAML: %w", err)
+		}
+		fmt.Print(string(yamlData))
+		return nil
+	}
+
+	if currentCount != int64(o.Count) {
+		action := fmt.Sprintf("scale workspace %s in namespace %s from %d to %d nodes", o.WorkspaceName, o.Namespace, currentCount, o.Count)
+		if err := confirmAction(action, o.Yes); err != nil {
+			return err
+		}
+	}
+
+	patch := map[string]interface{}{}
+	if err := unstructured.SetNestedField(patch, int64(o.Count), "resource", "count"); err != nil {
+		return fmt.Errorf("failed to build patch: %w", err)
+	}
+	patchData, err := json.Marshal(patch)
+	if err != nil {
+		return fmt.Errorf("failed to marshal patch: %w", err)
+	}
+
+	_, err = dynamicClient.Resource(gvr).Namespace(o.Namespace).Patch(
+		context.TODO(),
+		o.WorkspaceName,
+		types.MergePatchType,
+		patchData,
+		metav1.PatchOptions{},
+	)
+	if err != nil {
+		klog.Errorf("Failed to patch workspace: %v", err)
+		return fmt.Errorf("failed to scale workspace %s: %w", o.WorkspaceName, err)
+	}
+
+	printStatus(os.Stdout, "✓ Workspace %s scaled from %d to %d nodes\n", o.WorkspaceName, currentCount, o.Count)
+	printStatus(os.Stdout, "ℹ️  Use 'kubectl kaito status --workspace-name %s' to check status\n", o.WorkspaceName)
+	return nil
+}
+
+// validateNodeCount checks count against the MinNodes/MaxNodes of modelName.
+// Models that are not in the supported list (e.g. custom templates) are not checked.
+func validateNodeCount(models []Model, modelName string, count int) error {
+	for _, model := range models {
+		if model.Name != modelName {
+			continue
+		}
+		if model.MinNodes > 0 && count < model.MinNodes {
+			return fmt.Errorf("model %s requires at least %d nodes, got %d; use 'kubectl kaito models describe %s' for its requirements",
+				modelName, model.MinNodes, count, modelName)
+		}
+		if model.MaxNodes > 0 && count > model.MaxNodes {
+			return fmt.Errorf("model %s supports at most %d nodes, got %d; use 'kubectl kaito models describe %s' for its requirements",
+				modelName, model.MaxNodes, count, modelName)
+		}
+		return nil
+	}
+
+	klog.V(3).Infof("Model %q not found in supported models, skipping node count check", modelName)
+	return nil
+}
@@ -0,0 +1,127 @@
+/*
+Copyright (c) 2024 Kaito Project
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func TestScaleCmd(t *testing.T) {
+	configFlags := genericclioptions.NewConfigFlags(true)
+	cmd := NewScaleCmd(configFlags)
+
+	assert.Equal(t, "scale", cmd.Use)
+	assert.NotEmpty(t, cmd.Short)
+	assert.NotNil(t, cmd.RunE)
+
+	for _, flag := range []string{"workspace-name", "namespace", "count", "dry-run", "yes"} {
+		assert.NotNil(t, cmd.Flags().Lookup(flag), "Missing flag: %s", flag)
+	}
+}
+
+func TestValidateNodeCount(t *testing.T) {
+	models := []Model{{Name: "llama-3.3-70b-instruct", MinNodes: 2, MaxNodes: 4}}
+
+	assert.NoError(t, validateNodeCount(models, "llama-3.3-70b-instruct", 2))
+	assert.NoError(t, validateNodeCount(models, "llama-3.3-70b-instruct", 4))
+	assert.ErrorContains(t, validateNodeCount(models, "llama-3.3-70b-instruct", 1), "at least 2")
+	assert.ErrorContains(t, validateNodeCount(models, "llama-3.3-70b-instruct", 5), "at most 4")
+	assert.NoError(t, validateNodeCount(models, "custom-model", 10), "unknown models are not checked")
+}
+
+func TestScaleWorkspace(t *testing.T) {
+	gvr := schema.GroupVersionResource{Group: "kaito.sh", Version: "v1beta1", Resource: "workspaces"}
+	models := []Model{{Name: "phi-4", MinNodes: 1, MaxNodes: 3}}
+
+	newWorkspace := func() *unstructured.Unstructured {
+		workspace := newTestWorkspace("my-ws", "default", nil)
+		workspace.Object["resource"] = map[string]interface{}{"count": int64(1)}
+		workspace.Object["inference"] = map[string]interface{}{
+			"preset": map[string]interface{}{"name": "phi-4"},
+		}
+		return workspace
+	}
+
+	t.Run("Patches resource count", func(t *testing.T) {
+		client := newFakeDynamicClient(newWorkspace())
+		o := &ScaleOptions{WorkspaceName: "my-ws", Namespace: "default", Count: 3, Yes: true}
+
+		assert.NoError(t, o.scaleWorkspace(client, models))
+
+		workspace, err := client.Resource(gvr).Namespace("default").Get(context.TODO(), "my-ws", metav1.GetOptions{})
+		assert.NoError(t, err)
+		count, _, _ := unstructured.NestedInt64(workspace.Object, "resource", "count")
+		assert.Equal(t, int64(3), count)
+	})
+
+	t.Run("Dry run leaves workspace unchanged", func(t *testing.T) {
+		client := newFakeDynamicClient(newWorkspace())
+		o := &ScaleOptions{WorkspaceName: "my-ws", Namespace: "default", Count: 2, DryRun: true}
+
+		assert.NoError(t, o.scaleWorkspace(client, models))
+
+		workspace, err := client.Resource(gvr).Namespace("default").Get(context.TODO(), "my-ws", metav1.GetOptions{})
+		assert.NoError(t, err)
+		count, _, _ := unstructured.NestedInt64(workspace.Object, "resource", "count")
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("Asks for confirmation", func(t *testing.T) {
+		defer stubConfirm(t, "n\n", true)()
+		client := newFakeDynamicClient(newWorkspace())
+		o := &ScaleOptions{WorkspaceName: "my-ws", Namespace: "default", Count: 2}
+
+		assert.ErrorContains(t, o.scaleWorkspace(client, models), "aborted, did not scale workspace my-ws in namespace default from 1 to 2 nodes")
+
+		workspace, err := client.Resource(gvr).Namespace("default").Get(context.TODO(), "my-ws", metav1.GetOptions{})
+		assert.NoError(t, err)
+		count, _, _ := unstructured.NestedInt64(workspace.Object, "resource", "count")
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("Unchanged count needs no confirmation", func(t *testing.T) {
+		defer stubConfirm(t, "", false)()
+		o := &ScaleOptions{WorkspaceName: "my-ws", Namespace: "default", Count: 1}
+		assert.NoError(t, o.scaleWorkspace(newFakeDynamicClient(newWorkspace()), models))
+	})
+
+	t.Run("Models without a declared node range can scale out", func(t *testing.T) {
+		catalog, err := parseSupportedModels([]byte("models:\n  - name: phi-4\n    type: text-generation\n"))
+		assert.NoError(t, err)
+
+		client := newFakeDynamicClient(newWorkspace())
+		o := &ScaleOptions{WorkspaceName: "my-ws", Namespace: "default", Count: 2, Yes: true}
+		assert.NoError(t, o.scaleWorkspace(client, catalog))
+
+		workspace, err := client.Resource(gvr).Namespace("default").Get(context.TODO(), "my-ws", metav1.GetOptions{})
+		assert.NoError(t, err)
+		count, _, _ := unstructured.NestedInt64(workspace.Object, "resource", "count")
+		assert.Equal(t, int64(2), count)
+	})
+
+	t.Run("Rejects counts above the model maximum", func(t *testing.T) {
+		o := &ScaleOptions{WorkspaceName: "my-ws", Namespace: "default", Count: 4}
+		assert.Error(t, o.scaleWorkspace(newFakeDynamicClient(newWorkspace()), models))
+	})
+}
@@ -19,33 +19,69 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
+	"sort"
 	"strings"
 	"text/tabwriter"
 	"time"
 
 	"github.com/spf13/cobra"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
 )
 
 // StatusOptions holds the options for the status command
 type StatusOptions struct {
 	configFlags *genericclioptions.ConfigFlags
+	clients     *clientFactory
 
 	WorkspaceName string
 	Namespace     string
 	Watch         bool
+	ShowEvents    bool
+	Quiet         bool
+	AllNamespaces bool
+	// ReplicasReady makes --quiet also require every requested worker node
+	ReplicasReady bool
+	// Selector lists the workspaces matching a label selector instead of one workspace
+	Selector string
+	// ShowConditions and ShowWorkerNodes add detail to the default concise view
+	ShowConditions  bool
+	ShowWorkerNodes bool
+	// ShowManagedResources lists the pods, services, jobs and other objects Kaito
+	// created for the workspace
+	ShowManagedResources bool
+	// ShowYAML prints the raw workspace object after the summary, for bug reports
+	ShowYAML          bool
+	ShowManagedFields bool
 }
 
+// Exit codes of 'status --quiet'
+const (
+	statusExitNotReady = 1
+	statusExitNotFound = 2
+)
+
+// maxStatusEvents is how many of the most recent related events --show-events prints
+const maxStatusEvents = 15
+
 // NewStatusCmd creates the status command
 func NewStatusCmd(configFlags *genericclioptions.ConfigFlags) *cobra.Command {
 	o := &StatusOptions{
 		configFlags: configFlags,
+		clients:     newClientFactory(configFlags),
 	}
 
 	cmd := &cobra.Command{
@@ -54,77 +90,329 @@ func NewStatusCmd(configFlags *genericclioptions.ConfigFlags) *cobra.Command {
 		Long: `Check the status of one or more Kaito workspaces.
 
 This command displays the current state of workspace resources, including
-readiness conditions, resource allocation, and deployment status.`,
+readiness conditions, resource allocation, and deployment status.
+
+Use --show-conditions for the full condition messages and --show-worker-nodes
+for the nodes running the workspace.`,
 		Example: `  # Check status of a specific workspace
   kubectl kaito status --workspace-name my-workspace
 
   # Watch for changes in real-time
   kubectl kaito status --workspace-name my-workspace -n <namespace> --watch
 
+  # List the status of all workspaces in the cluster
+  kubectl kaito status -A
+
+  # List the status of every workspace labeled team=research
+  kubectl kaito status --selector team=research -A
+
+  # Wait in a script until the workspace is ready
+  until kubectl kaito status --workspace-name my-workspace --quiet; do sleep 5; done
+
+  # Also wait until all requested worker nodes are running
+  until kubectl kaito status --workspace-name my-workspace --quiet --replicas-ready; do sleep 5; done
+
+  # Show recent events for the workspace and its pods
+  kubectl kaito status --workspace-name my-workspace --show-events
+
   # Show detailed conditions and worker node information
-  kubectl kaito status --workspace-name my-workspace --show-conditions --show-worker-nodes`,
+  kubectl kaito status --workspace-name my-workspace --show-conditions --show-worker-nodes
+
+  # See what Kaito created for the workspace
+  kubectl kaito status --workspace-name my-workspace --show-managed-resources
+
+  # Include the full workspace object, e.g. to attach it to a bug report
+  kubectl kaito status --workspace-name my-workspace --show-yaml`,
+		Annotations: map[string]string{allNamespacesAnnotation: "true"},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			// -A/--all-namespaces is a global flag
+			o.AllNamespaces, _ = cmd.Flags().GetBool("all-namespaces")
 			if err := o.validate(); err != nil {
 				klog.Errorf("Validation failed: %v", err)
 				return fmt.Errorf("validation failed: %w", err)
 			}
-			return o.Run()
+			if o.Quiet {
+				// The result is reported only through the exit code
+				cmd.SilenceErrors = true
+			}
+			ctx, stop := withInterrupt(cmd.Context())
+			defer stop()
+			return interruptedError(ctx, o.Run(ctx))
 		},
 	}
 
 	cmd.Flags().StringVar(&o.WorkspaceName, "workspace-name", "", "Name of the workspace to check")
 	cmd.Flags().StringVarP(&o.Namespace, "namespace", "n", "", "Kubernetes namespace")
+	cmd.Flags().StringVarP(&o.Selector, "selector", "l", "", "Label selector (e.g. team=research) to list the status of all matching workspaces")
 	cmd.Flags().BoolVarP(&o.Watch, "watch", "w", false, "Watch for changes in real-time")
+	cmd.Flags().BoolVarP(&o.Quiet, "quiet", "q", false, "Print nothing; exit 0 if the workspace is ready, 1 if it is not ready and 2 if it does not exist")
+	cmd.Flags().BoolVar(&o.ReplicasReady, "replicas-ready", false, "With --quiet, also exit 1 until the workspace runs on all requested worker nodes")
+	cmd.Flags().BoolVar(&o.ShowEvents, "show-events", false, "Show recent events for the workspace and its pods")
+	cmd.Flags().BoolVar(&o.ShowConditions, "show-conditions", false, "Show the detailed conditions table")
+	cmd.Flags().BoolVar(&o.ShowWorkerNodes, "show-worker-nodes", false, "Show the nodes running the workspace")
+	cmd.Flags().BoolVar(&o.ShowManagedResources, "show-managed-resources", false, "List the pods, services, jobs and PVCs of the workspace, grouped by kind")
+	cmd.Flags().BoolVar(&o.ShowYAML, "show-yaml", false, "Also print the full workspace object as YAML")
+	cmd.Flags().BoolVar(&o.ShowManagedFields, "show-managed-fields", false, "Keep managedFields and the last-applied-configuration annotation in the --show-yaml output")
 
 	return cmd
 }
 
-func (o *StatusOptions) Run() error {
+func (o *StatusOptions) Run(ctx context.Context) error {
 	klog.V(2).Info("Starting status command")
 
-	// Get REST config
-	config, err := o.configFlags.ToRESTConfig()
+	dynamicClient, err := o.clients.DynamicClient()
 	if err != nil {
-		klog.Errorf("Failed to get REST config: %v", err)
-		return fmt.Errorf("failed to get REST config: %w", err)
+		return err
 	}
 
-	// Create dynamic client
-	dynamicClient, err := dynamic.NewForConfig(config)
+	// Events and tuning jobs are read with the typed client
+	clientset, err := o.clients.KubernetesClient()
 	if err != nil {
-		klog.Errorf("Failed to create dynamic client: %v", err)
-		return fmt.Errorf("failed to create dynamic client: %w", err)
+		return err
 	}
 
 	// Get namespace
-	if o.Namespace == "" {
-		if ns, _, err := o.configFlags.ToRawKubeConfigLoader().Namespace(); err == nil && ns != "" {
-			o.Namespace = ns
-		} else {
-			klog.V(4).Info("No namespace specified, using 'default'")
-			o.Namespace = "default"
-		}
+	explicitNamespace := o.Namespace != ""
+	if !o.AllNamespaces {
+		o.Namespace = resolveNamespace(o.configFlags, o.Namespace)
+	}
+
+	if o.AllNamespaces || o.Selector != "" {
+		return o.showWorkspaceTable(ctx, dynamicClient)
+	}
+
+	if o.Quiet {
+		return o.quietStatus(ctx, dynamicClient)
+	}
+
+	// Warn when the name is ambiguous and the user did not pick a namespace
+	if !explicitNamespace {
+		o.warnIfDuplicateWorkspaceName(ctx, dynamicClient)
 	}
 
 	// Handle watch mode for specific workspace
 	if o.Watch {
-		return o.watchWorkspace(dynamicClient)
+		return o.watchWorkspace(ctx, dynamicClient, clientset)
 	}
 
-	return o.showWorkspaceStatus(dynamicClient)
+	return o.showWorkspaceStatus(ctx, dynamicClient, clientset)
 }
 
 // validates the status options
 func (o *StatusOptions) validate() error {
 	klog.V(4).Info("Validating status options")
 
+	if o.Selector != "" {
+		if _, err := labels.Parse(o.Selector); err != nil {
+			return fmt.Errorf("invalid --selector %q: %w", o.Selector, err)
+		}
+		if o.WorkspaceName != "" {
+			return fmt.Errorf("--selector cannot be used with --workspace-name")
+		}
+		if o.Watch || o.Quiet || o.ShowEvents || o.ShowYAML || o.ShowManagedResources {
+			return fmt.Errorf("--selector cannot be used with --watch, --quiet, --show-events, --show-yaml or --show-managed-resources")
+		}
+	}
+	if o.AllNamespaces {
+		if o.Namespace != "" {
+			return fmt.Errorf("--namespace cannot be used with --all-namespaces")
+		}
+		if o.Watch || o.Quiet || o.ShowEvents || o.ShowYAML || o.ShowManagedResources {
+			return fmt.Errorf("--all-namespaces cannot be used with --watch, --quiet, --show-events, --show-yaml or --show-managed-resources")
+		}
+		return nil
+	}
+	if o.Selector != "" {
+		return nil
+	}
 	if o.WorkspaceName == "" {
-		return fmt.Errorf("workspace name is required")
+		return fmt.Errorf("workspace name is required (or use --all-namespaces or --selector to list workspaces)")
+	}
+	if o.Quiet && (o.Watch || o.ShowEvents || o.ShowConditions || o.ShowWorkerNodes || o.ShowManagedResources || o.ShowYAML) {
+		return fmt.Errorf("--quiet cannot be used with --watch, --show-events, --show-conditions, --show-worker-nodes, --show-managed-resources or --show-yaml")
+	}
+	if o.ReplicasReady && !o.Quiet {
+		return fmt.Errorf("--replicas-ready requires --quiet")
+	}
+	if o.ShowManagedFields && !o.ShowYAML {
+		return fmt.Errorf("--show-managed-fields requires --show-yaml")
 	}
 	return nil
 }
 
-func (o *StatusOptions) showWorkspaceStatus(dynamicClient dynamic.Interface) error {
+// showWorkspaceTable prints a summary table of the workspaces in the namespace, or
+// in all namespaces, that match --selector, limited to those named --workspace-name
+// when it is set
+func (o *StatusOptions) showWorkspaceTable(ctx context.Context, dynamicClient dynamic.Interface) error {
+	items, err := o.listWorkspaces(ctx, dynamicClient)
+	if err != nil {
+		return err
+	}
+	if len(items) == 0 {
+		switch {
+		case o.Selector != "" && o.AllNamespaces:
+			fmt.Printf("No workspaces found matching selector %s\n", o.Selector)
+		case o.Selector != "":
+			fmt.Printf("No workspaces found matching selector %s in namespace %s\n", o.Selector, o.Namespace)
+		default:
+			fmt.Println("No workspaces found")
+		}
+		return nil
+	}
+
+	printWorkspaceTable(os.Stdout, items, o.AllNamespaces)
+	return nil
+}
+
+// listWorkspaces lists the workspaces for showWorkspaceTable, sorted by namespace and name
+func (o *StatusOptions) listWorkspaces(ctx context.Context, dynamicClient dynamic.Interface) ([]unstructured.Unstructured, error) {
+	gvr := schema.GroupVersionResource{
+		Group:    "kaito.sh",
+		Version:  "v1beta1",
+		Resource: "workspaces",
+	}
+
+	listOptions := metav1.ListOptions{LabelSelector: o.Selector}
+	if o.WorkspaceName != "" {
+		listOptions.FieldSelector = fmt.Sprintf("metadata.name=%s", o.WorkspaceName)
+	}
+	// An empty namespace lists across all namespaces
+	namespace := o.Namespace
+	if o.AllNamespaces {
+		namespace = ""
+	}
+	workspaces, err := dynamicClient.Resource(gvr).Namespace(namespace).List(ctx, listOptions)
+	if err != nil {
+		klog.Errorf("Failed to list workspaces: %v", err)
+		return nil, fmt.Errorf("failed to list workspaces: %w", err)
+	}
+
+	items := workspaces.Items
+	if o.WorkspaceName != "" {
+		// Field selectors are not applied by every client; filter again
+		items = items[:0:0]
+		for _, workspace := range workspaces.Items {
+			if workspace.GetName() == o.WorkspaceName {
+				items = append(items, workspace)
+			}
+		}
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].GetNamespace() != items[j].GetNamespace() {
+			return items[i].GetNamespace() < items[j].GetNamespace()
+		}
+		return items[i].GetName() < items[j].GetName()
+	})
+	return items, nil
+}
+
+// printWorkspaceTable prints one summary row per workspace
+func printWorkspaceTable(out io.Writer, items []unstructured.Unstructured, showNamespace bool) {
+	w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	defer w.Flush()
+
+	columns := "NAME\tMODE\tINSTANCE\tRESOURCEREADY\tINFERENCEREADY\tWORKSPACEREADY\tAGE"
+	if showNamespace {
+		columns = "NAMESPACE\t" + columns
+	}
+	fmt.Fprintln(w, columns)
+	for i := range items {
+		workspace := &items[i]
+		conditions, _, _ := unstructured.NestedSlice(workspace.Object, "status", "conditions")
+		resourceReady, inferenceReady, workspaceReady := extractConditionStatuses(conditions)
+		instanceType, _, _ := unstructured.NestedString(workspace.Object, "resource", "instanceType")
+		if instanceType == "" {
+			instanceType = "-"
+		}
+		mode := "inference"
+		if _, found := workspace.Object["tuning"]; found {
+			mode = "tuning"
+		}
+		row := fmt.Sprintf("%s\t%s\t%s\t%s\t%s\t%s\t%s",
+			workspace.GetName(), mode, instanceType, resourceReady, inferenceReady, workspaceReady, resourceAge(workspace))
+		if showNamespace {
+			row = workspace.GetNamespace() + "\t" + row
+		}
+		fmt.Fprintln(w, row)
+	}
+}
+
+// quietStatus reports the workspace readiness only through an ExitError
+func (o *StatusOptions) quietStatus(ctx context.Context, dynamicClient dynamic.Interface) error {
+	gvr := schema.GroupVersionResource{
+		Group:    "kaito.sh",
+		Version:  "v1beta1",
+		Resource: "workspaces",
+	}
+
+	workspace, err := dynamicClient.Resource(gvr).Namespace(o.Namespace).Get(
+		ctx,
+		o.WorkspaceName,
+		metav1.GetOptions{},
+	)
+	if errors.IsNotFound(err) {
+		klog.V(2).Infof("Workspace %s not found in namespace %s", o.WorkspaceName, o.Namespace)
+		return &ExitError{Code: statusExitNotFound}
+	}
+	if err != nil {
+		klog.V(2).Infof("Failed to get workspace %s: %v", o.WorkspaceName, err)
+		return &ExitError{Code: statusExitNotReady}
+	}
+
+	if !isWorkspaceReady(workspace.Object["status"]) {
+		klog.V(2).Infof("Workspace %s is not ready", o.WorkspaceName)
+		return &ExitError{Code: statusExitNotReady}
+	}
+	if o.ReplicasReady {
+		if ready, requested, _ := workerReadiness(workspace); ready < requested {
+			klog.V(2).Infof("Workspace %s has %d of %d worker nodes ready", o.WorkspaceName, ready, requested)
+			return &ExitError{Code: statusExitNotReady}
+		}
+	}
+	return nil
+}
+
+// warnIfDuplicateWorkspaceName warns when a workspace with the same name exists in
+// more than one namespace, so the user can select the intended one with -n
+func (o *StatusOptions) warnIfDuplicateWorkspaceName(ctx context.Context, dynamicClient dynamic.Interface) {
+	gvr := schema.GroupVersionResource{
+		Group:    "kaito.sh",
+		Version:  "v1beta1",
+		Resource: "workspaces",
+	}
+
+	workspaces, err := dynamicClient.Resource(gvr).List(ctx, metav1.ListOptions{
+		FieldSelector: fmt.Sprintf("metadata.name=%s", o.WorkspaceName),
+	})
+	if err != nil {
+		// Listing across namespaces may be forbidden; this check is best-effort
+		klog.V(4).Infof("Could not check for duplicate workspace names: %v", err)
+		return
+	}
+
+	namespaces := findWorkspaceNamespaces(workspaces.Items, o.WorkspaceName)
+	if len(namespaces) > 1 {
+		printStatus(os.Stderr, "⚠️  Workspace %s exists in multiple namespaces (%s), showing namespace %s\n",
+			o.WorkspaceName, strings.Join(namespaces, ", "), o.Namespace)
+		fmt.Fprintln(os.Stderr, "   Use -n <namespace> to select a workspace explicitly")
+		fmt.Fprintln(os.Stderr)
+	}
+}
+
+// findWorkspaceNamespaces returns the sorted namespaces containing a workspace with the given name
+func findWorkspaceNamespaces(workspaces []unstructured.Unstructured, name string) []string {
+	var namespaces []string
+	for _, workspace := range workspaces {
+		if workspace.GetName() == name {
+			namespaces = append(namespaces, workspace.GetNamespace())
+		}
+	}
+	sort.Strings(namespaces)
+	return namespaces
+}
+
+func (o *StatusOptions) showWorkspaceStatus(ctx context.Context, dynamicClient dynamic.Interface, clientset kubernetes.Interface) error {
 	klog.V(3).Infof("Getting status for workspace: %s", o.WorkspaceName)
 
 	gvr := schema.GroupVersionResource{
@@ -134,7 +422,7 @@ func (o *StatusOptions) showWorkspaceStatus(dynamicClient dynamic.Interface) err
 	}
 
 	workspace, err := dynamicClient.Resource(gvr).Namespace(o.Namespace).Get(
-		context.TODO(),
+		ctx,
 		o.WorkspaceName,
 		metav1.GetOptions{},
 	)
@@ -144,11 +432,24 @@ func (o *StatusOptions) showWorkspaceStatus(dynamicClient dynamic.Interface) err
 	}
 
 	o.printWorkspaceDetails(workspace)
+	o.printTuningProgress(ctx, clientset, workspace)
+	o.printManagedResources(ctx, clientset, workspace)
+	o.printWorkspaceEvents(ctx, clientset)
+	o.printWorkspaceYAML(workspace)
 
 	return nil
 }
 
-func (o *StatusOptions) watchWorkspace(dynamicClient dynamic.Interface) error {
+// Reconnect delays of 'status --watch' after the watch closes or fails
+var (
+	statusWatchBackoff    = time.Second
+	maxStatusWatchBackoff = 30 * time.Second
+)
+
+// watchWorkspace prints the workspace on every change until ctx is cancelled. The API
+// server closes watches periodically, so the watch is re-established from the last
+// seen resourceVersion with exponential backoff.
+func (o *StatusOptions) watchWorkspace(ctx context.Context, dynamicClient dynamic.Interface, clientset kubernetes.Interface) error {
 	klog.V(2).Infof("Starting watch for workspace: %s", o.WorkspaceName)
 	fmt.Printf("Watching workspace %s for changes (Ctrl+C to stop)...\n", o.WorkspaceName)
 	fmt.Println()
@@ -159,24 +460,89 @@ func (o *StatusOptions) watchWorkspace(dynamicClient dynamic.Interface) error {
 		Resource: "workspaces",
 	}
 
-	watcher, err := dynamicClient.Resource(gvr).Namespace(o.Namespace).Watch(context.TODO(), metav1.ListOptions{
-		FieldSelector: fmt.Sprintf("metadata.name=%s", o.WorkspaceName),
-	})
-	if err != nil {
-		klog.Errorf("Failed to watch workspace: %v", err)
-		return fmt.Errorf("failed to watch workspace: %w", err)
+	resourceVersion := ""
+	backoff := statusWatchBackoff
+	for attempt := 0; ; attempt++ {
+		watcher, err := dynamicClient.Resource(gvr).Namespace(o.Namespace).Watch(ctx, metav1.ListOptions{
+			FieldSelector:   fmt.Sprintf("metadata.name=%s", o.WorkspaceName),
+			ResourceVersion: resourceVersion,
+		})
+		switch {
+		case ctx.Err() != nil:
+			return nil
+		case err != nil && attempt == 0:
+			// Fail fast on setup problems such as a missing CRD or RBAC
+			klog.Errorf("Failed to watch workspace: %v", err)
+			return fmt.Errorf("failed to watch workspace: %w", err)
+		case err != nil:
+			printStatus(os.Stderr, "⚠️  Failed to re-establish watch: %v\n", err)
+		default:
+			progressed, watchErr := o.consumeWatch(ctx, watcher, clientset, &resourceVersion)
+			if ctx.Err() != nil {
+				return nil
+			}
+			if progressed {
+				backoff = statusWatchBackoff
+			}
+			if watchErr != nil {
+				if errors.IsResourceExpired(watchErr) || errors.IsGone(watchErr) {
+					// The last resourceVersion is too old; start over from the current state
+					klog.V(2).Infof("Watch resourceVersion %s expired, relisting", resourceVersion)
+					resourceVersion = ""
+				} else {
+					printStatus(os.Stderr, "⚠️  Watch error: %v\n", watchErr)
+				}
+			}
+		}
+
+		klog.V(2).Infof("Watch closed, reconnecting in %s", backoff)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, maxStatusWatchBackoff)
 	}
+}
+
+// consumeWatch prints workspace events until the watch closes, records the last seen
+// resourceVersion and reports whether any workspace event was received. Error events
+// are returned as API errors.
+func (o *StatusOptions) consumeWatch(ctx context.Context, watcher watch.Interface, clientset kubernetes.Interface, resourceVersion *string) (bool, error) {
 	defer watcher.Stop()
 
-	for event := range watcher.ResultChan() {
-		if workspace, ok := event.Object.(*unstructured.Unstructured); ok {
-			fmt.Printf("=== %s at %s ===\n", strings.ToUpper(string(event.Type)), time.Now().Format(time.RFC3339))
+	progressed := false
+	for {
+		select {
+		case <-ctx.Done():
+			return progressed, ctx.Err()
+		case event, ok := <-watcher.ResultChan():
+			if !ok {
+				return progressed, nil
+			}
+			if event.Type == watch.Error {
+				return progressed, errors.FromObject(event.Object)
+			}
+			workspace, ok := event.Object.(*unstructured.Unstructured)
+			if !ok {
+				continue
+			}
+			*resourceVersion = workspace.GetResourceVersion()
+			progressed = true
+
+			header := fmt.Sprintf("%s at %s", strings.ToUpper(string(event.Type)), time.Now().Format(time.RFC3339))
+			if ready, requested, ok := workerReadiness(workspace); ok {
+				header += fmt.Sprintf(", Workers: %d/%d ready", ready, requested)
+			}
+			fmt.Printf("=== %s ===\n", header)
 			o.printWorkspaceDetails(workspace)
+			o.printTuningProgress(ctx, clientset, workspace)
+			o.printManagedResources(ctx, clientset, workspace)
+			o.printWorkspaceEvents(ctx, clientset)
+			o.printWorkspaceYAML(workspace)
 			fmt.Println()
 		}
 	}
-
-	return nil
 }
 
 func (o *StatusOptions) printWorkspaceDetails(workspace *unstructured.Unstructured) {
@@ -191,7 +557,7 @@ func (o *StatusOptions) printWorkspaceDetails(workspace *unstructured.Unstructur
 	o.printWorkspaceMode(workspace)
 	o.printDeploymentStatus(workspace)
 
-	fmt.Printf("Age: %s\n", o.getAge(workspace))
+	fmt.Printf("Age: %s\n", resourceAge(workspace))
 	fmt.Println()
 }
 
@@ -273,11 +639,18 @@ func (o *StatusOptions) printDeploymentStatus(workspace *unstructured.Unstructur
 	}
 
 	o.printConditionStatuses(statusMap)
-	o.printWorkerNodesList(statusMap)
+	if ready, requested, ok := workerReadiness(workspace); ok {
+		fmt.Printf("Workers: %d/%d ready\n", ready, requested)
+	}
+	if o.ShowWorkerNodes {
+		o.printWorkerNodesList(statusMap)
+	}
 
 	// Print detailed conditions
 	fmt.Println()
-	o.printConditions(workspace)
+	if o.ShowConditions {
+		o.printConditions(workspace)
+	}
 }
 
 func (o *StatusOptions) getStatusMap(workspace *unstructured.Unstructured) map[string]interface{} {
@@ -307,35 +680,57 @@ func (o *StatusOptions) printConditionStatuses(statusMap map[string]interface{})
 		return
 	}
 
-	resourceReady, inferenceReady, workspaceReady := o.extractConditionStatuses(condList)
+	resourceReady, inferenceReady, workspaceReady := extractConditionStatuses(condList)
 
 	fmt.Printf("Resource Ready: %s\n", resourceReady)
 	fmt.Printf("Inference Ready: %s\n", inferenceReady)
 	fmt.Printf("Workspace Ready: %s\n", workspaceReady)
 }
 
-func (o *StatusOptions) extractConditionStatuses(condList []interface{}) (string, string, string) {
-	resourceReady := "Unknown"
-	inferenceReady := "Unknown"
-	workspaceReady := "Unknown"
+// extractConditionStatuses returns the ResourceReady, InferenceReady, and WorkspaceSucceeded
+// condition statuses, or "Unknown" for conditions that are not reported yet
+func extractConditionStatuses(condList []interface{}) (string, string, string) {
+	resourceReady := conditionStatus(condList, "ResourceReady")
+	inferenceReady := conditionStatus(condList, "InferenceReady")
+	workspaceReady := conditionStatus(condList, "WorkspaceSucceeded")
+
+	return resourceReady, inferenceReady, workspaceReady
+}
+
+// workerReadiness returns how many worker nodes the workspace runs on, from
+// status.workerNodes, and how many it requested with resource.count (1 when unset).
+// ok is false until the operator reports a status.
+func workerReadiness(workspace *unstructured.Unstructured) (ready, requested int64, ok bool) {
+	if _, found := workspace.Object["status"].(map[string]interface{}); !found {
+		return 0, 0, false
+	}
 
+	requested = 1
+	count, _, _ := unstructured.NestedFieldNoCopy(workspace.Object, "resource", "count")
+	switch count := count.(type) {
+	case int64:
+		requested = count
+	case float64:
+		requested = int64(count)
+	}
+
+	workerNodes, _, _ := unstructured.NestedSlice(workspace.Object, "status", "workerNodes")
+	return int64(len(workerNodes)), requested, true
+}
+
+// conditionStatus returns the status of the condition with the given type, or "Unknown"
+func conditionStatus(condList []interface{}, condType string) string {
 	for _, condition := range condList {
 		if condMap, ok := condition.(map[string]interface{}); ok {
-			condType, _ := condMap["type"].(string)
-			condStatus, _ := condMap["status"].(string)
-
-			switch condType {
-			case "ResourceReady":
-				resourceReady = condStatus
-			case "InferenceReady":
-				inferenceReady = condStatus
-			case "WorkspaceSucceeded":
-				workspaceReady = condStatus
+			if t, _ := condMap["type"].(string); t == condType {
+				if status, _ := condMap["status"].(string); status != "" {
+					return status
+				}
 			}
 		}
 	}
 
-	return resourceReady, inferenceReady, workspaceReady
+	return "Unknown"
 }
 
 func (o *StatusOptions) printWorkerNodesList(statusMap map[string]interface{}) {
@@ -389,15 +784,247 @@ func (o *StatusOptions) printConditions(workspace *unstructured.Unstructured) {
 	fmt.Println()
 }
 
-func (o *StatusOptions) getAge(workspace *unstructured.Unstructured) string {
-	creationTimestamp := workspace.GetCreationTimestamp()
+// printTuningProgress shows the state of the training Job of a tuning workspace and its
+// pods, so users can tell whether training is progressing or crash-looping
+func (o *StatusOptions) printTuningProgress(ctx context.Context, clientset kubernetes.Interface, workspace *unstructured.Unstructured) {
+	if _, found := workspace.Object["tuning"]; !found || clientset == nil {
+		return
+	}
+
+	fmt.Println("Tuning Job:")
+	fmt.Println("===========")
+
+	// Kaito names the tuning Job after the workspace
+	job, err := clientset.BatchV1().Jobs(workspace.GetNamespace()).Get(ctx, workspace.GetName(), metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		fmt.Println("Job: Not created yet")
+		fmt.Println()
+		return
+	}
+	if err != nil {
+		printStatus(os.Stderr, "⚠️  Could not get tuning job: %v\n", err)
+		return
+	}
+
+	fmt.Printf("Job: %s (%s)\n", job.Name, tuningJobState(job))
+	if job.Status.StartTime != nil {
+		elapsed := time.Since(job.Status.StartTime.Time)
+		if job.Status.CompletionTime != nil {
+			elapsed = job.Status.CompletionTime.Sub(job.Status.StartTime.Time)
+		}
+		fmt.Printf("Running Time: %s\n", shortDuration(elapsed))
+	}
+
+	pods, err := clientset.CoreV1().Pods(job.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("job-name=%s", job.Name),
+	})
+	if err != nil {
+		printStatus(os.Stderr, "⚠️  Could not list tuning pods: %v\n", err)
+		return
+	}
+	for _, pod := range pods.Items {
+		phase, restarts := podPhaseAndRestarts(&pod)
+		fmt.Printf("Pod %s: %s (restarts: %d)\n", pod.Name, phase, restarts)
+		if restarts > 0 && phase != string(corev1.PodSucceeded) {
+			printStatus(os.Stdout, "💡 Use 'kubectl logs %s -n %s --previous' to see why training restarted\n", pod.Name, pod.Namespace)
+		}
+	}
+	fmt.Println()
+}
+
+// tuningJobState summarizes a Job's completion counters
+func tuningJobState(job *batchv1.Job) string {
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case batchv1.JobComplete:
+			return "Complete"
+		case batchv1.JobFailed:
+			return fmt.Sprintf("Failed: %s", condition.Reason)
+		}
+	}
+	return fmt.Sprintf("active: %d, succeeded: %d, failed: %d", job.Status.Active, job.Status.Succeeded, job.Status.Failed)
+}
+
+// podPhaseAndRestarts returns the pod phase, or the waiting reason of a container
+// (such as CrashLoopBackOff), and the total container restart count
+func podPhaseAndRestarts(pod *corev1.Pod) (string, int32) {
+	phase := string(pod.Status.Phase)
+	var restarts int32
+	for _, status := range pod.Status.ContainerStatuses {
+		restarts += status.RestartCount
+		if status.State.Waiting != nil && status.State.Waiting.Reason != "" {
+			phase = status.State.Waiting.Reason
+		}
+	}
+	return phase, restarts
+}
+
+// printManagedResources lists the objects Kaito created for the workspace. It does
+// nothing without --show-managed-resources.
+func (o *StatusOptions) printManagedResources(ctx context.Context, clientset kubernetes.Interface, workspace *unstructured.Unstructured) {
+	if !o.ShowManagedResources || clientset == nil {
+		return
+	}
+
+	resources, err := collectManagedResources(ctx, clientset, workspace)
+	if err != nil {
+		printStatus(os.Stderr, "⚠️  Could not list managed resources: %v\n", err)
+		return
+	}
+
+	fmt.Println("Managed Resources:")
+	fmt.Println("==================")
+	writeManagedResources(os.Stdout, resources)
+	fmt.Println()
+}
+
+// printWorkspaceEvents prints the most recent events for the workspace, objects named
+// after it (its deployment or statefulset) and its pods. It does nothing without --show-events.
+func (o *StatusOptions) printWorkspaceEvents(ctx context.Context, clientset kubernetes.Interface) {
+	if !o.ShowEvents || clientset == nil {
+		return
+	}
+
+	events, err := workspaceEvents(ctx, clientset, o.Namespace, o.WorkspaceName)
+	if err != nil {
+		printStatus(os.Stderr, "⚠️  Could not list events: %v\n", err)
+		return
+	}
+
+	fmt.Println("Events:")
+	fmt.Println("=======")
+	if len(events) == 0 {
+		fmt.Println("No recent events")
+		fmt.Println()
+		return
+	}
+	if len(events) > maxStatusEvents {
+		events = events[len(events)-maxStatusEvents:]
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "  AGE\tTYPE\tREASON\tOBJECT\tMESSAGE")
+	for _, event := range events {
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%s/%s\t%s\n",
+			eventAge(event), event.Type, event.Reason,
+			strings.ToLower(event.InvolvedObject.Kind), event.InvolvedObject.Name,
+			strings.TrimSpace(event.Message))
+	}
+	w.Flush()
+	fmt.Println()
+}
+
+// printWorkspaceYAML prints the workspace object as YAML. It does nothing without --show-yaml.
+func (o *StatusOptions) printWorkspaceYAML(workspace *unstructured.Unstructured) {
+	if !o.ShowYAML {
+		return
+	}
+
+	data, err := workspaceYAML(workspace, o.ShowManagedFields)
+	if err != nil {
+		printStatus(os.Stderr, "⚠️  Could not print workspace YAML: %v\n", err)
+		return
+	}
+
+	fmt.Println("Workspace YAML:")
+	fmt.Println("===============")
+	fmt.Print(string(data))
+	fmt.Println()
+}
+
+// workspaceYAML marshals the workspace to YAML. Unless showManagedFields is set,
+// managedFields and the last-applied-configuration annotation are left out, since
+// they repeat the spec and rarely help to reproduce a problem.
+func workspaceYAML(workspace *unstructured.Unstructured, showManagedFields bool) ([]byte, error) {
+	obj := workspace
+	if !showManagedFields {
+		obj = workspace.DeepCopy()
+		obj.SetManagedFields(nil)
+		if annotations := obj.GetAnnotations(); annotations != nil {
+			delete(annotations, corev1.LastAppliedConfigAnnotation)
+			if len(annotations) == 0 {
+				annotations = nil
+			}
+			obj.SetAnnotations(annotations)
+		}
+	}
+
+	data, err := yaml.Marshal(obj.Object)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal workspace %s: %w", workspace.GetName(), err)
+	}
+	return data, nil
+}
+
+// workspaceEvents returns the events related to a workspace, oldest first
+func workspaceEvents(ctx context.Context, clientset kubernetes.Interface, namespace, workspaceName string) ([]corev1.Event, error) {
+	pods, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("kaito.sh/workspace=%s", workspaceName),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workspace pods: %w", err)
+	}
+	podNames := make(map[string]bool, len(pods.Items))
+	for _, pod := range pods.Items {
+		podNames[pod.Name] = true
+	}
+
+	eventList, err := clientset.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events: %w", err)
+	}
+
+	var events []corev1.Event
+	for _, event := range eventList.Items {
+		involved := event.InvolvedObject
+		if involved.Name == workspaceName || (involved.Kind == "Pod" && podNames[involved.Name]) {
+			events = append(events, event)
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return eventTime(events[i]).Before(eventTime(events[j]))
+	})
+	return events, nil
+}
+
+// eventTime returns when an event last occurred
+func eventTime(event corev1.Event) time.Time {
+	switch {
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	case !event.FirstTimestamp.IsZero():
+		return event.FirstTimestamp.Time
+	default:
+		return event.CreationTimestamp.Time
+	}
+}
+
+func eventAge(event corev1.Event) string {
+	t := eventTime(event)
+	if t.IsZero() {
+		return "Unknown"
+	}
+	return shortDuration(time.Since(t))
+}
+
+// resourceAge returns the age of a Kaito resource in kubectl's short format (e.g. 5m, 2d)
+func resourceAge(obj *unstructured.Unstructured) string {
+	creationTimestamp := obj.GetCreationTimestamp()
 	if creationTimestamp.IsZero() {
-		klog.V(6).Infof("Creation timestamp not found for workspace %s", workspace.GetName())
+		klog.V(6).Infof("Creation timestamp not found for %s", obj.GetName())
 		return "Unknown"
 	}
 
-	duration := time.Since(creationTimestamp.Time)
+	return shortDuration(time.Since(creationTimestamp.Time))
+}
 
+// shortDuration formats a duration in kubectl's short age format (e.g. 5m, 2d)
+func shortDuration(duration time.Duration) string {
 	switch {
 	case duration.Seconds() < 60:
 		return fmt.Sprintf("%ds", int(duration.Seconds()))
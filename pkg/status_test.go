@@ -17,10 +17,21 @@ limitations under the License.
 package cmd
 
 import (
+	"bytes"
+	"context"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
 )
 
 func TestStatusCmd(t *testing.T) {
@@ -43,5 +54,312 @@ func TestStatusCmd(t *testing.T) {
 
 		watchFlag := flags.Lookup("watch")
 		assert.NotNil(t, watchFlag)
+
+		assert.NotNil(t, flags.Lookup("show-events"))
+
+		// Detailed sections are opt-in
+		for _, name := range []string{"show-conditions", "show-worker-nodes"} {
+			flag := flags.Lookup(name)
+			if assert.NotNil(t, flag, name) {
+				assert.Equal(t, "false", flag.DefValue, name)
+			}
+		}
+	})
+}
+
+func TestFindWorkspaceNamespaces(t *testing.T) {
+	newWorkspace := func(name, namespace string) unstructured.Unstructured {
+		workspace := unstructured.Unstructured{Object: map[string]interface{}{}}
+		workspace.SetName(name)
+		workspace.SetNamespace(namespace)
+		return workspace
+	}
+
+	workspaces := []unstructured.Unstructured{
+		newWorkspace("my-llama", "team-b"),
+		newWorkspace("other", "team-a"),
+		newWorkspace("my-llama", "team-a"),
+	}
+
+	t.Run("Name in multiple namespaces", func(t *testing.T) {
+		assert.Equal(t, []string{"team-a", "team-b"}, findWorkspaceNamespaces(workspaces, "my-llama"))
+	})
+
+	t.Run("Name in single namespace", func(t *testing.T) {
+		assert.Equal(t, []string{"team-a"}, findWorkspaceNamespaces(workspaces, "other"))
+	})
+
+	t.Run("Name not found", func(t *testing.T) {
+		assert.Empty(t, findWorkspaceNamespaces(workspaces, "missing"))
+	})
+}
+
+func TestWorkspaceEvents(t *testing.T) {
+	now := time.Now()
+	newEvent := func(name, kind, object, reason string, age time.Duration) *corev1.Event {
+		return &corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: name, Namespace: "default"},
+			InvolvedObject: corev1.ObjectReference{Kind: kind, Name: object, Namespace: "default"},
+			Reason:         reason,
+			LastTimestamp:  metav1.NewTime(now.Add(-age)),
+		}
+	}
+
+	clientset := fake.NewSimpleClientset(
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name: "my-ws-0", Namespace: "default", Labels: map[string]string{"kaito.sh/workspace": "my-ws"},
+		}},
+		newEvent("e1", "Pod", "my-ws-0", "FailedScheduling", time.Minute),
+		newEvent("e2", "Workspace", "my-ws", "NodeClaimCreated", 10*time.Minute),
+		newEvent("e3", "StatefulSet", "my-ws", "SuccessfulCreate", 5*time.Minute),
+		newEvent("e4", "Pod", "other-0", "Scheduled", 2*time.Minute),
+	)
+
+	events, err := workspaceEvents(context.TODO(), clientset, "default", "my-ws")
+	assert.NoError(t, err)
+
+	var reasons []string
+	for _, event := range events {
+		reasons = append(reasons, event.Reason)
+	}
+	assert.Equal(t, []string{"NodeClaimCreated", "SuccessfulCreate", "FailedScheduling"}, reasons)
+	assert.Equal(t, "1m", eventAge(events[2]))
+}
+
+func TestStatusQuiet(t *testing.T) {
+	client := newFakeDynamicClient(
+		newTestWorkspace("ready-ws", "default", map[string]string{"ResourceReady": "True", "InferenceReady": "True"}),
+		newTestWorkspace("pending-ws", "default", map[string]string{"ResourceReady": "True", "InferenceReady": "False"}),
+	)
+
+	quietStatus := func(name string) error {
+		o := &StatusOptions{WorkspaceName: name, Namespace: "default", Quiet: true}
+		return o.quietStatus(context.TODO(), client)
+	}
+
+	assert.NoError(t, quietStatus("ready-ws"))
+	assert.Equal(t, &ExitError{Code: statusExitNotReady}, quietStatus("pending-ws"))
+	assert.Equal(t, &ExitError{Code: statusExitNotFound}, quietStatus("missing-ws"))
+
+	o := &StatusOptions{WorkspaceName: "ready-ws", Quiet: true, Watch: true}
+	assert.Error(t, o.validate())
+}
+
+func TestWorkerReadiness(t *testing.T) {
+	newWorkspace := func(count interface{}, workerNodes ...interface{}) *unstructured.Unstructured {
+		workspace := newTestWorkspace("my-ws", "default", map[string]string{"ResourceReady": "True", "InferenceReady": "True"})
+		if count != nil {
+			workspace.Object["resource"] = map[string]interface{}{"count": count}
+		}
+		if len(workerNodes) > 0 {
+			assert.NoError(t, unstructured.SetNestedSlice(workspace.Object, workerNodes, "status", "workerNodes"))
+		}
+		return workspace
+	}
+
+	for _, tt := range []struct {
+		name      string
+		workspace *unstructured.Unstructured
+		ready     int64
+		requested int64
+	}{
+		{name: "Partially ready", workspace: newWorkspace(int64(3), "node-a", "node-b"), ready: 2, requested: 3},
+		{name: "Count decoded as float", workspace: newWorkspace(float64(2), "node-a", "node-b"), ready: 2, requested: 2},
+		{name: "Unset count defaults to one", workspace: newWorkspace(nil), ready: 0, requested: 1},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ready, requested, ok := workerReadiness(tt.workspace)
+			assert.True(t, ok)
+			assert.Equal(t, tt.ready, ready)
+			assert.Equal(t, tt.requested, requested)
+		})
+	}
+
+	noStatus := &unstructured.Unstructured{Object: map[string]interface{}{"resource": map[string]interface{}{"count": int64(2)}}}
+	_, _, ok := workerReadiness(noStatus)
+	assert.False(t, ok)
+
+	t.Run("Quiet gate", func(t *testing.T) {
+		client := newFakeDynamicClient(newWorkspace(int64(2), "node-a"))
+		o := &StatusOptions{WorkspaceName: "my-ws", Namespace: "default", Quiet: true}
+		assert.NoError(t, o.quietStatus(context.TODO(), client))
+
+		o.ReplicasReady = true
+		assert.Equal(t, &ExitError{Code: statusExitNotReady}, o.quietStatus(context.TODO(), client))
+
+		assert.ErrorContains(t, (&StatusOptions{WorkspaceName: "my-ws", ReplicasReady: true}).validate(), "--replicas-ready requires --quiet")
+	})
+}
+
+func TestWorkspaceYAML(t *testing.T) {
+	workspace := newTestWorkspace("my-ws", "default", map[string]string{"ResourceReady": "True"})
+	workspace.SetAnnotations(map[string]string{
+		corev1.LastAppliedConfigAnnotation: `{"apiVersion":"kaito.sh/v1beta1"}`,
+		"team":                             "ml",
+	})
+	workspace.SetManagedFields([]metav1.ManagedFieldsEntry{{Manager: "kubectl", Operation: metav1.ManagedFieldsOperationApply}})
+
+	t.Run("Managed fields are stripped by default", func(t *testing.T) {
+		data, err := workspaceYAML(workspace, false)
+		assert.NoError(t, err)
+		assert.Contains(t, string(data), "name: my-ws")
+		assert.Contains(t, string(data), "team: ml")
+		assert.Contains(t, string(data), "type: ResourceReady")
+		assert.NotContains(t, string(data), "managedFields")
+		assert.NotContains(t, string(data), corev1.LastAppliedConfigAnnotation)
+
+		// The workspace itself is left untouched
+		assert.Len(t, workspace.GetManagedFields(), 1)
+		assert.Contains(t, workspace.GetAnnotations(), corev1.LastAppliedConfigAnnotation)
 	})
+
+	t.Run("Managed fields are kept on request", func(t *testing.T) {
+		data, err := workspaceYAML(workspace, true)
+		assert.NoError(t, err)
+		assert.Contains(t, string(data), "managedFields")
+		assert.Contains(t, string(data), corev1.LastAppliedConfigAnnotation)
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		assert.NoError(t, (&StatusOptions{WorkspaceName: "my-ws", ShowYAML: true, ShowManagedFields: true}).validate())
+		assert.ErrorContains(t, (&StatusOptions{WorkspaceName: "my-ws", ShowManagedFields: true}).validate(), "requires --show-yaml")
+		assert.Error(t, (&StatusOptions{WorkspaceName: "my-ws", ShowYAML: true, Quiet: true}).validate())
+		assert.Error(t, (&StatusOptions{AllNamespaces: true, ShowYAML: true}).validate())
+	})
+}
+
+func TestTuningJobState(t *testing.T) {
+	running := &batchv1.Job{Status: batchv1.JobStatus{Active: 1, Failed: 2}}
+	assert.Equal(t, "active: 1, succeeded: 0, failed: 2", tuningJobState(running))
+
+	complete := &batchv1.Job{Status: batchv1.JobStatus{Succeeded: 1, Conditions: []batchv1.JobCondition{
+		{Type: batchv1.JobComplete, Status: corev1.ConditionTrue},
+	}}}
+	assert.Equal(t, "Complete", tuningJobState(complete))
+
+	failed := &batchv1.Job{Status: batchv1.JobStatus{Failed: 6, Conditions: []batchv1.JobCondition{
+		{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Reason: "BackoffLimitExceeded"},
+	}}}
+	assert.Equal(t, "Failed: BackoffLimitExceeded", tuningJobState(failed))
+}
+
+func TestPodPhaseAndRestarts(t *testing.T) {
+	pod := &corev1.Pod{Status: corev1.PodStatus{
+		Phase: corev1.PodRunning,
+		ContainerStatuses: []corev1.ContainerStatus{{
+			RestartCount: 4,
+			State:        corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+		}},
+	}}
+	phase, restarts := podPhaseAndRestarts(pod)
+	assert.Equal(t, "CrashLoopBackOff", phase)
+	assert.Equal(t, int32(4), restarts)
+
+	phase, restarts = podPhaseAndRestarts(&corev1.Pod{Status: corev1.PodStatus{Phase: corev1.PodRunning}})
+	assert.Equal(t, "Running", phase)
+	assert.Equal(t, int32(0), restarts)
+}
+
+func TestWatchWorkspaceReconnects(t *testing.T) {
+	origBackoff := statusWatchBackoff
+	statusWatchBackoff = time.Millisecond
+	defer func() { statusWatchBackoff = origBackoff }()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	workspace := newTestWorkspace("my-ws", "default", nil)
+	workspace.SetResourceVersion("42")
+
+	var resourceVersions []string
+	client := newFakeDynamicClient()
+	client.PrependWatchReactor("workspaces", func(action clienttesting.Action) (bool, watch.Interface, error) {
+		resourceVersions = append(resourceVersions, action.(clienttesting.WatchActionImpl).GetWatchRestrictions().ResourceVersion)
+
+		watcher := watch.NewFakeWithChanSize(1, false)
+		switch len(resourceVersions) {
+		case 1:
+			// The server closes the watch after one event
+			watcher.Modify(workspace)
+			watcher.Stop()
+		case 2:
+			// The resourceVersion is too old
+			watcher.Error(&metav1.Status{Status: metav1.StatusFailure, Code: 410, Reason: metav1.StatusReasonExpired})
+		default:
+			cancel()
+		}
+		return true, watcher, nil
+	})
+
+	o := &StatusOptions{WorkspaceName: "my-ws", Namespace: "default"}
+	assert.NoError(t, o.watchWorkspace(ctx, client, nil))
+
+	// Reconnects from the last seen version, then relists after it expired
+	assert.Equal(t, []string{"", "42", ""}, resourceVersions)
+}
+
+func TestStatusAllNamespaces(t *testing.T) {
+	o := &StatusOptions{AllNamespaces: true}
+	assert.NoError(t, o.validate(), "workspace name is optional with --all-namespaces")
+
+	o = &StatusOptions{AllNamespaces: true, Namespace: "default"}
+	assert.Error(t, o.validate())
+
+	o = &StatusOptions{AllNamespaces: true, Watch: true}
+	assert.Error(t, o.validate())
+
+	client := newFakeDynamicClient(
+		newTestWorkspace("ws-a", "team-a", nil),
+		newTestWorkspace("ws-b", "team-b", nil),
+	)
+	o = &StatusOptions{AllNamespaces: true, WorkspaceName: "ws-b"}
+	assert.NoError(t, o.showWorkspaceTable(context.TODO(), client))
+}
+
+func TestStatusSelector(t *testing.T) {
+	t.Run("Validation", func(t *testing.T) {
+		assert.NoError(t, (&StatusOptions{Selector: "team=research"}).validate(), "workspace name is optional with --selector")
+		assert.NoError(t, (&StatusOptions{Selector: "team=research", AllNamespaces: true}).validate())
+		assert.ErrorContains(t, (&StatusOptions{Selector: "team in ("}).validate(), "invalid --selector")
+		assert.Error(t, (&StatusOptions{Selector: "team=research", WorkspaceName: "ws"}).validate())
+		assert.Error(t, (&StatusOptions{Selector: "team=research", Watch: true}).validate())
+		assert.Error(t, (&StatusOptions{Selector: "team=research", Quiet: true}).validate())
+		assert.Error(t, (&StatusOptions{Selector: "team=research", ShowManagedResources: true}).validate())
+	})
+
+	labeled := func(name, namespace, team string) *unstructured.Unstructured {
+		workspace := newTestWorkspace(name, namespace, nil)
+		workspace.SetLabels(map[string]string{"team": team})
+		return workspace
+	}
+	client := newFakeDynamicClient(
+		labeled("ws-c", "default", "research"),
+		labeled("ws-a", "default", "research"),
+		labeled("ws-b", "default", "serving"),
+		labeled("ws-d", "other", "research"),
+	)
+	names := func(o *StatusOptions) []string {
+		items, err := o.listWorkspaces(context.TODO(), client)
+		assert.NoError(t, err)
+		var names []string
+		for _, item := range items {
+			names = append(names, item.GetNamespace()+"/"+item.GetName())
+		}
+		return names
+	}
+
+	assert.Equal(t, []string{"default/ws-a", "default/ws-c"},
+		names(&StatusOptions{Selector: "team=research", Namespace: "default"}))
+	assert.Equal(t, []string{"default/ws-a", "default/ws-c", "other/ws-d"},
+		names(&StatusOptions{Selector: "team=research", AllNamespaces: true}))
+	assert.Empty(t, names(&StatusOptions{Selector: "team=unknown", Namespace: "default"}))
+
+	var out bytes.Buffer
+	items, err := (&StatusOptions{Selector: "team in (research,serving)", Namespace: "default"}).listWorkspaces(context.TODO(), client)
+	assert.NoError(t, err)
+	printWorkspaceTable(&out, items, false)
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	assert.Len(t, lines, 4)
+	assert.True(t, strings.HasPrefix(lines[0], "NAME "), "no NAMESPACE column within one namespace")
+	assert.True(t, strings.HasPrefix(lines[2], "ws-b "))
 }
@@ -0,0 +1,81 @@
+/*
+Copyright (c) 2024 Kaito Project
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/klog/v2"
+)
+
+// confirmInput is where confirm reads answers from, and confirmInteractive reports
+// whether a user can answer there; tests replace both
+var (
+	confirmInput       io.Reader = os.Stdin
+	confirmInteractive           = stdinIsTerminal
+)
+
+// resolveNamespace returns the namespace a command acts on: explicit when it is set,
+// otherwise the namespace of the current kubeconfig context (or the global -n flag),
+// and "default" when neither is set
+func resolveNamespace(configFlags *genericclioptions.ConfigFlags, explicit string) string {
+	if explicit != "" {
+		return explicit
+	}
+	if ns, _, err := configFlags.ToRawKubeConfigLoader().Namespace(); err == nil && ns != "" {
+		return ns
+	}
+	klog.V(4).Info("No namespace specified, using 'default'")
+	return "default"
+}
+
+// confirm asks prompt on stderr and reports whether the answer is y or yes. Any
+// other answer, including an empty one or end of input, is a no.
+func confirm(prompt string) bool {
+	fmt.Fprintf(os.Stderr, "%s [y/N]: ", prompt)
+	answer, err := bufio.NewReader(confirmInput).ReadString('\n')
+	if err != nil && answer == "" {
+		fmt.Fprintln(os.Stderr)
+		return false
+	}
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true
+	}
+	return false
+}
+
+// confirmAction asks the user to confirm a destructive action, e.g. "update
+// workspace my-ws in namespace default", unless yes (--yes) is set. Without a
+// terminal to answer from it fails rather than wait for input.
+func confirmAction(action string, yes bool) error {
+	if yes {
+		return nil
+	}
+	if !confirmInteractive() {
+		return fmt.Errorf("refusing to %s without confirmation because stdin is not a terminal; pass --yes to proceed", action)
+	}
+	if !confirm(fmt.Sprintf("Do you want to %s?", action)) {
+		return fmt.Errorf("aborted, did not %s", action)
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+/*
+Copyright (c) 2024 Kaito Project
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func TestResolveNamespace(t *testing.T) {
+	kubeconfig := filepath.Join(t.TempDir(), "config")
+	assert.NoError(t, os.WriteFile(kubeconfig, []byte(`apiVersion: v1
+kind: Config
+current-context: dev
+contexts:
+- name: dev
+  context:
+    cluster: dev
+    namespace: team-a
+clusters:
+- name: dev
+  cluster:
+    server: https://127.0.0.1:6443
+`), 0o600))
+
+	newConfigFlags := func(namespace string) *genericclioptions.ConfigFlags {
+		configFlags := genericclioptions.NewConfigFlags(true)
+		configFlags.KubeConfig = &kubeconfig
+		configFlags.Namespace = &namespace
+		return configFlags
+	}
+
+	assert.Equal(t, "explicit", resolveNamespace(newConfigFlags("global"), "explicit"), "the command flag wins")
+	assert.Equal(t, "global", resolveNamespace(newConfigFlags("global"), ""), "then the global -n flag")
+	assert.Equal(t, "team-a", resolveNamespace(newConfigFlags(""), ""), "then the kubeconfig context")
+
+	empty := filepath.Join(t.TempDir(), "empty")
+	assert.NoError(t, os.WriteFile(empty, []byte("apiVersion: v1\nkind: Config\n"), 0o600))
+	configFlags := genericclioptions.NewConfigFlags(true)
+	configFlags.KubeConfig = &empty
+	assert.Equal(t, "default", resolveNamespace(configFlags, ""))
+}
+
+// stubConfirm makes confirm read answers and report interactive, and returns a
+// function restoring the real stdin
+func stubConfirm(t *testing.T, answers string, interactive bool) func() {
+	t.Helper()
+	origInput, origInteractive := confirmInput, confirmInteractive
+	confirmInput = strings.NewReader(answers)
+	confirmInteractive = func() bool { return interactive }
+	return func() {
+		confirmInput, confirmInteractive = origInput, origInteractive
+	}
+}
+
+func TestConfirm(t *testing.T) {
+	for answer, want := range map[string]bool{
+		"y\n":     true,
+		"YES\n":   true,
+		" yes ":   true,
+		"n\n":     false,
+		"\n":      false,
+		"":        false,
+		"maybe\n": false,
+	} {
+		restore := stubConfirm(t, answer, true)
+		assert.Equal(t, want, confirm("Continue?"), "%q", answer)
+		restore()
+	}
+}
+
+func TestConfirmAction(t *testing.T) {
+	t.Run("--yes skips the prompt", func(t *testing.T) {
+		defer stubConfirm(t, "", false)()
+		assert.NoError(t, confirmAction("delete workspace ws", true))
+	})
+
+	t.Run("Confirmed", func(t *testing.T) {
+		defer stubConfirm(t, "y\n", true)()
+		assert.NoError(t, confirmAction("delete workspace ws", false))
+	})
+
+	t.Run("Declined", func(t *testing.T) {
+		defer stubConfirm(t, "n\n", true)()
+		assert.EqualError(t, confirmAction("delete workspace ws", false), "aborted, did not delete workspace ws")
+	})
+
+	t.Run("Fails without a terminal", func(t *testing.T) {
+		defer stubConfirm(t, "y\n", false)()
+		assert.ErrorContains(t, confirmAction("delete workspace ws", false), "stdin is not a terminal; pass --yes")
+	})
+}
@@ -0,0 +1,143 @@
+/*
+Copyright (c) 2024 Kaito Project
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/klog/v2"
+)
+
+// waitForCondition watches a resource until predicate passes, the timeout elapses or
+// ctx is cancelled, and returns the resource as last seen by the predicate.
+//
+// The current state is checked first, since the watch only reports later changes.
+// When the API server closes the watch it is re-established from the last seen
+// resourceVersion, or from a fresh read when that version has expired. On timeout
+// the error wraps context.DeadlineExceeded, and when ctx is cancelled it wraps
+// ctx.Err(), so callers can tell them apart with errors.Is.
+func waitForCondition(ctx context.Context, dynamicClient dynamic.Interface, gvr schema.GroupVersionResource,
+	name, namespace string, predicate func(*unstructured.Unstructured) bool, timeout time.Duration) (*unstructured.Unstructured, error) {
+	klog.V(3).Infof("Waiting up to %s for %s %s/%s", timeout, gvr.Resource, namespace, name)
+
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	resource := dynamicClient.Resource(gvr).Namespace(namespace)
+
+	// stopped explains why waitCtx is done
+	stopped := func() error {
+		if ctx.Err() != nil {
+			return fmt.Errorf("stopped waiting for %s: %w", name, ctx.Err())
+		}
+		return fmt.Errorf("timed out after %s waiting for %s: %w", timeout, name, context.DeadlineExceeded)
+	}
+
+	var obj *unstructured.Unstructured
+	for {
+		if obj == nil {
+			current, err := resource.Get(waitCtx, name, metav1.GetOptions{})
+			if waitCtx.Err() != nil {
+				return nil, stopped()
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to get %s: %w", name, err)
+			}
+			obj = current
+			if predicate(obj) {
+				return obj, nil
+			}
+		}
+
+		watcher, err := resource.Watch(waitCtx, metav1.ListOptions{
+			FieldSelector:   fmt.Sprintf("metadata.name=%s", name),
+			ResourceVersion: obj.GetResourceVersion(),
+		})
+		if waitCtx.Err() != nil {
+			return obj, stopped()
+		}
+		if err != nil {
+			return obj, fmt.Errorf("failed to watch %s: %w", name, err)
+		}
+
+		updated, done, err := consumeConditionWatch(waitCtx, watcher, name, predicate)
+		watcher.Stop()
+		if updated != nil {
+			obj = updated
+		}
+		switch {
+		case done:
+			return obj, nil
+		case waitCtx.Err() != nil:
+			return obj, stopped()
+		case apierrors.IsResourceExpired(err) || apierrors.IsGone(err):
+			// The last resourceVersion is too old; read the current state again
+			klog.V(2).Infof("Watch resourceVersion %s expired, reading %s again", obj.GetResourceVersion(), name)
+			obj = nil
+		case err != nil:
+			return obj, err
+		default:
+			klog.V(4).Infof("Watch of %s closed, re-establishing it", name)
+		}
+	}
+}
+
+// isWaitTimeout reports whether a waitForCondition error is a timeout
+func isWaitTimeout(err error) bool {
+	return errors.Is(err, context.DeadlineExceeded)
+}
+
+// consumeConditionWatch passes the watched resource to predicate until it passes,
+// the watch closes or ctx is done. It returns the last object seen and whether the
+// predicate passed. Error events are returned as API errors.
+func consumeConditionWatch(ctx context.Context, watcher watch.Interface, name string,
+	predicate func(*unstructured.Unstructured) bool) (*unstructured.Unstructured, bool, error) {
+	var last *unstructured.Unstructured
+	for {
+		select {
+		case <-ctx.Done():
+			return last, false, ctx.Err()
+		case event, ok := <-watcher.ResultChan():
+			if !ok {
+				return last, false, nil
+			}
+			switch event.Type {
+			case watch.Error:
+				return last, false, apierrors.FromObject(event.Object)
+			case watch.Deleted:
+				return last, false, fmt.Errorf("%s was deleted while waiting for it", name)
+			}
+			obj, ok := event.Object.(*unstructured.Unstructured)
+			if !ok || obj.GetName() != name {
+				continue
+			}
+			last = obj
+			if predicate(obj) {
+				return last, true, nil
+			}
+		}
+	}
+}
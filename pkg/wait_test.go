@@ -0,0 +1,110 @@
+/*
+Copyright (c) 2024 Kaito Project
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+func TestWaitForCondition(t *testing.T) {
+	gvr := schema.GroupVersionResource{Group: "kaito.sh", Version: "v1beta1", Resource: "workspaces"}
+	ready := func(workspace *unstructured.Unstructured) bool {
+		return isWorkspaceReady(workspace.Object["status"])
+	}
+	readyWorkspace := func() *unstructured.Unstructured {
+		return newTestWorkspace("my-ws", "default", map[string]string{
+			"ResourceReady":      "True",
+			"InferenceReady":     "True",
+			"WorkspaceSucceeded": "True",
+		})
+	}
+	pendingWorkspace := func() *unstructured.Unstructured {
+		return newTestWorkspace("my-ws", "default", map[string]string{"ResourceReady": "False"})
+	}
+
+	t.Run("Current state passes", func(t *testing.T) {
+		client := newFakeDynamicClient(readyWorkspace())
+		workspace, err := waitForCondition(context.TODO(), client, gvr, "my-ws", "default", ready, time.Second)
+		assert.NoError(t, err)
+		assert.Equal(t, "my-ws", workspace.GetName())
+	})
+
+	t.Run("Watch is re-established after it closes", func(t *testing.T) {
+		client := newFakeDynamicClient(pendingWorkspace())
+		closed := watch.NewFake()
+		updates := watch.NewFakeWithChanSize(2, false)
+		updates.Modify(newTestWorkspace("other-ws", "default", map[string]string{"ResourceReady": "True"}))
+		updates.Modify(readyWorkspace())
+		watches := 0
+		client.PrependWatchReactor("workspaces", func(clienttesting.Action) (bool, watch.Interface, error) {
+			watches++
+			if watches == 1 {
+				closed.Stop()
+				return true, closed, nil
+			}
+			return true, updates, nil
+		})
+
+		workspace, err := waitForCondition(context.TODO(), client, gvr, "my-ws", "default", ready, time.Second)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, watches)
+		assert.True(t, ready(workspace))
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		client := newFakeDynamicClient(pendingWorkspace())
+		workspace, err := waitForCondition(context.TODO(), client, gvr, "my-ws", "default", ready, 50*time.Millisecond)
+		assert.True(t, isWaitTimeout(err))
+		assert.ErrorContains(t, err, "timed out after 50ms waiting for my-ws")
+		assert.Equal(t, "my-ws", workspace.GetName())
+	})
+
+	t.Run("Cancelled context", func(t *testing.T) {
+		client := newFakeDynamicClient(pendingWorkspace())
+		ctx, cancel := context.WithCancel(context.TODO())
+		cancel()
+		_, err := waitForCondition(ctx, client, gvr, "my-ws", "default", ready, time.Minute)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.False(t, isWaitTimeout(err))
+	})
+
+	t.Run("Deleted resource", func(t *testing.T) {
+		client := newFakeDynamicClient(pendingWorkspace())
+		deleted := watch.NewFakeWithChanSize(1, false)
+		deleted.Delete(pendingWorkspace())
+		client.PrependWatchReactor("workspaces", func(clienttesting.Action) (bool, watch.Interface, error) {
+			return true, deleted, nil
+		})
+
+		_, err := waitForCondition(context.TODO(), client, gvr, "my-ws", "default", ready, time.Second)
+		assert.ErrorContains(t, err, "my-ws was deleted while waiting for it")
+	})
+
+	t.Run("Missing resource", func(t *testing.T) {
+		_, err := waitForCondition(context.TODO(), newFakeDynamicClient(), gvr, "my-ws", "default", ready, time.Second)
+		assert.ErrorContains(t, err, "failed to get my-ws")
+		assert.False(t, isWaitTimeout(err))
+	})
+}
@@ -208,8 +208,7 @@ func testHelpCommands(t *testing.T) {
 		{"status help", []string{"status", "--help"}},
 		{"get-endpoint help", []string{"get-endpoint", "--help"}},
 		{"chat help", []string{"chat", "--help"}},
-		// TODO: Uncomment when RAG command is available
-		// {"rag help", []string{"rag", "--help"}},
+		{"rag help", []string{"rag", "--help"}},
 	}
 
 	for _, tt := range tests {